@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	recordAuditUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/audit/record"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	deliverWebhookUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/deliver"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/audit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+	auditPersistence "github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/audit/persistence"
+	auditWorker "github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/audit/worker"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/category/eventsourcing"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/category/persistence"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/config"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/events"
+	webhookPersistence "github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/webhook/persistence"
+)
+
+// catalog holds the category gateways and the event dispatcher, already
+// subscribed by the recorders of audit entries and webhook deliveries;
+// revisions are written by the use cases, with the change. The server and
+// the CLI share it, so a change is recorded the same way whichever one made
+// it.
+type catalog struct {
+	categories category.CategoryGateway
+	// streamer reads the categories table, which the event-sourced gateway
+	// keeps as its projection.
+	streamer      category.CategoryStreamer
+	revisions     category.CategoryRevisionGateway
+	audit         audit.AuditGateway
+	subscriptions webhook.SubscriptionGateway
+	deliveries    webhook.DeliveryGateway
+	dispatcher    *events.Dispatcher
+	// transactor makes writes that span several categories atomic.
+	transactor usecase.Transactor
+	// auditRetry writes the audit entries whose first write failed. The
+	// server runs it in the background; the CLI flushes it before exiting,
+	// leaving the outbox to the server.
+	auditRetry *auditWorker.RetryWorker
+}
+
+func newCatalog(cfg *config.Config, db *sql.DB) *catalog {
+	table := persistence.NewMySQLCategoryGateway(db)
+
+	c := &catalog{
+		categories:    table,
+		streamer:      table,
+		revisions:     persistence.NewMySQLCategoryRevisionGateway(db),
+		audit:         auditPersistence.NewMySQLAuditGateway(db),
+		subscriptions: webhookPersistence.NewMySQLSubscriptionGateway(db),
+		deliveries:    webhookPersistence.NewMySQLDeliveryGateway(db),
+		dispatcher:    events.NewDispatcher(),
+		transactor:    mysql.NewTransactor(db),
+	}
+
+	// The event-sourced gateway keeps categories as event streams and uses the
+	// categories table as a projected read model.
+	if cfg.Category.Gateway == config.GatewayEventSourced {
+		readModel := c.categories
+		eventSourced := eventsourcing.NewEventSourcedCategoryGateway(
+			eventsourcing.NewMySQLEventStore(db),
+			eventsourcing.NewReadModelProjector(readModel),
+			readModel,
+			c.transactor,
+			cfg.Category.SnapshotEvery,
+		)
+
+		if cfg.Category.RebuildProjection {
+			if err := eventSourced.RebuildProjection(context.Background()); err != nil {
+				fatal("error rebuilding category projection", err)
+			}
+			slog.Info("Category projection rebuilt")
+		}
+
+		c.categories = eventSourced
+		slog.Info("Using event-sourced category gateway")
+	}
+
+	auditOutbox := auditPersistence.NewMySQLAuditOutboxGateway(db)
+	auditQueue := recordAuditUC.NewRetryQueue()
+	auditPolicy := recordAuditUC.DefaultRetryPolicy()
+	recordAuditUseCase := recordAuditUC.NewRecordCategoryChangeUseCase(c.audit, auditOutbox, auditQueue, auditPolicy)
+	c.auditRetry = auditWorker.NewRetryWorker(
+		recordAuditUC.NewRetryCategoryChangesUseCase(c.audit, auditOutbox, auditQueue, auditPolicy),
+		5*time.Second,
+	)
+
+	c.dispatcher.Subscribe(func(event category.CategoryEvent) {
+		ctx := eventContext(event)
+		if err := recordAuditUseCase.Execute(ctx, event); err != nil {
+			slog.ErrorContext(ctx, "error recording audit entry, queued for retry", "event_id", event.ID, "error", err)
+		}
+	})
+
+	enqueueUseCase := deliverWebhookUC.NewEnqueueDeliveriesUseCase(c.subscriptions, c.deliveries)
+
+	c.dispatcher.Subscribe(func(event category.CategoryEvent) {
+		ctx := eventContext(event)
+		if _, err := enqueueUseCase.Execute(ctx, event); err != nil {
+			slog.ErrorContext(ctx, "error enqueueing webhook deliveries", "event_id", event.ID, "error", err)
+		}
+	})
+
+	return c
+}
+
+// eventContext carries the originating request's ID into subscribers, so
+// their errors can be traced back to the request that caused them.
+func eventContext(event category.CategoryEvent) context.Context {
+	ctx := context.Background()
+	if event.RequestID != "" {
+		ctx = requestctx.WithRequestID(ctx, event.RequestID)
+	}
+	return ctx
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/user"
+	"strings"
+
+	createCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/create"
+	deleteCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/delete"
+	retriveCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/retrive"
+	updateCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/update"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/config"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/interfaces/cli"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/logging"
+)
+
+const categoriesUsage = `Usage: catalog categories ACTION [ID] [flags]
+
+Actions:
+  list              list categories (-page, -per-page, -terms, -sort, -direction)
+  get ID            show one category
+  create            create a category (-name, -description, -active)
+  update ID         change the fields given (-name, -description, -active)
+  delete ID         delete a category
+  activate ID       activate a category
+  deactivate ID     deactivate a category
+
+Mutating actions accept -dry-run to validate and print the result without
+saving it. Changes are recorded in the audit log and revisions with the
+actor cli:<user>.
+`
+
+// categoriesCommand runs category operations directly against the database
+// through the same use cases as the HTTP API.
+func categoriesCommand(args []string) {
+	if len(args) == 0 {
+		categoriesUsageError("missing action")
+	}
+
+	action, args := args[0], args[1:]
+	if action == "-h" || action == "-help" || action == "--help" || action == "help" {
+		fmt.Print(categoriesUsage)
+		return
+	}
+
+	id := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		id, args = args[0], args[1:]
+	}
+
+	set := flag.NewFlagSet("categories", flag.ContinueOnError)
+	set.SetOutput(io.Discard)
+
+	opts := &cli.CategoryOptions{}
+	opts.Register(set)
+	configFlags := config.RegisterFlags(set)
+
+	if err := set.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Fprint(os.Stderr, categoriesUsage+"\nFlags:\n")
+			set.SetOutput(os.Stderr)
+			set.PrintDefaults()
+			os.Exit(0)
+		}
+		categoriesUsageError(err.Error())
+	}
+	if set.NArg() > 0 {
+		categoriesUsageError("unexpected arguments: " + strings.Join(set.Args(), " "))
+	}
+	if err := opts.Check(action, id); err != nil {
+		categoriesUsageError(err.Error())
+	}
+
+	cfg, err := configFlags.Load(os.LookupEnv)
+	exitOnConfigError(err)
+
+	// Logs go to stderr so that stdout carries only the requested output.
+	slog.SetDefault(logging.New(cfg.Log, os.Stderr))
+
+	db, err := mysql.NewConnection(cfg.Database)
+	if err != nil {
+		fatal("error connecting to database", err)
+	}
+	defer db.Close()
+
+	catalog := newCatalog(cfg, db)
+
+	commands := cli.NewCategoryCommands(
+		createCategoryUC.NewCreateCategoryUseCase(catalog.categories, catalog.revisions, catalog.dispatcher, catalog.transactor),
+		updateCategoryUC.NewUpdateCategoryUseCase(catalog.categories, catalog.revisions, catalog.dispatcher, catalog.transactor),
+		deleteCategoryUC.NewDeleteCategoryUseCase(catalog.categories, catalog.dispatcher),
+		retriveCategoryUC.NewGetCategoryByIDUseCase(catalog.categories),
+		retriveCategoryUC.NewListCategoriesUseCase(catalog.categories),
+		os.Stdout,
+		os.Stderr,
+	)
+
+	ctx := requestctx.WithActor(context.Background(), cliActor())
+
+	err = commands.Run(ctx, action, id, opts)
+	// A failed command may still have changed categories before failing.
+	catalog.auditRetry.Flush()
+	if err != nil {
+		db.Close()
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// cliActor names the operating system user, so audit entries show who ran
+// the command.
+func cliActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return "cli:" + u.Username
+	}
+	return "cli"
+}
+
+func categoriesUsageError(problem string) {
+	fmt.Fprintf(os.Stderr, "%s\n\n%s", problem, categoriesUsage)
+	os.Exit(2)
+}
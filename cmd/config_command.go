@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/config"
+)
+
+// configCommand implements "config print", which dumps the effective
+// configuration, secrets redacted, with the source of every value.
+func configCommand(args []string) {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprint(os.Stderr, "Usage: catalog config print [flags]\n")
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(args[1:], os.LookupEnv)
+	if cfg == nil {
+		exitOnConfigError(err)
+	}
+
+	if err := cfg.Print(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// Invalid values are still printed above, then reported.
+	exitOnConfigError(err)
+}
+
+// loadConfig resolves the configuration or exits listing every problem;
+// the logger is not configured yet, so errors go straight to stderr.
+func loadConfig(args []string) *config.Config {
+	cfg, err := config.Load(args, os.LookupEnv)
+	exitOnConfigError(err)
+	return cfg
+}
+
+func exitOnConfigError(err error) {
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprint(os.Stderr, usage+"\nFlags:\n")
+		config.PrintUsage(os.Stderr)
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
@@ -1,49 +1,151 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
-	"github.com/joho/godotenv"
+	authenticateAPIKeyUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/apikey/authenticate"
+	createAPIKeyUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/apikey/create"
+	retriveAPIKeyUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/apikey/retrive"
+	revokeAPIKeyUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/apikey/revoke"
+	retriveAuditUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/audit/retrive"
+	batchCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/batch"
 	createCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/create"
 	deleteCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/delete"
+	exportCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/export"
+	hierarchyCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/hierarchy"
+	importCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/importing"
 	retriveCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/retrive"
+	revisionCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/revision"
 	updateCategoryUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/update"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	createWebhookUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/create"
+	deleteWebhookUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/delete"
+	deliverWebhookUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/deliver"
+	retriveWebhookUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/retrive"
+	updateWebhookUC "github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/update"
 
 	categoryHTTP "github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/interfaces/http"
 
-	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
-	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/category/persistence"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/apikey"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+	apiKeyPersistence "github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/apikey/persistence"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/auth"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/migration"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/events"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/health"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/idempotency"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/logging"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/metrics"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/ratelimit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/server"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/tracing"
+	webhookSender "github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/webhook/sender"
+	webhookWorker "github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/webhook/worker"
+	"go.opentelemetry.io/otel"
 	// Update the import path below to match the actual location of your category handler package.
 )
 
 func main() {
-	_ = godotenv.Load()
-	cfg, err := mysql.LoadConfigFromEnv()
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		serve(args)
+	case "config":
+		configCommand(args)
+	case "migrate":
+		migrateCommand(args)
+	case "categories":
+		categoriesCommand(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
+
+const usage = `Usage:
+  catalog [serve] [flags]   run the HTTP server (default)
+  catalog config print      print the effective configuration
+  catalog migrate ACTION    manage the database schema (see catalog migrate -h)
+  catalog categories ACTION list and change categories (see catalog categories -h)
+  catalog -h                list configuration flags
+`
+
+func serve(args []string) {
+	cfg := loadConfig(args)
+
+	logger := logging.New(cfg.Log, os.Stdout)
+	slog.SetDefault(logger)
+
+	tracerProvider, err := tracing.NewTracerProvider(context.Background(), cfg.Tracing, os.Stdout)
 	if err != nil {
-		log.Fatalf("error loading config: %v", err)
+		fatal("error creating tracer provider", err)
 	}
+	defer func() {
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			slog.Error("error flushing traces", "error", err)
+		}
+	}()
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(tracing.Propagator())
 
-	db, err := mysql.NewConnection(cfg)
+	db, err := mysql.NewConnection(cfg.Database)
 	if err != nil {
-		log.Fatalf("error connecting to database: %v", err)
+		fatal("error connecting to database", err)
 	}
 
-	log.Println("Database connected")
+	slog.Info("Database connected")
 
-	if err := migration.RunMigrations(db, cfg.Database); err != nil {
-		log.Fatalf("error running migrations: %v", err)
+	migrations := migration.Source(cfg.Migrate.Dir)
+
+	if cfg.Migrate.Auto {
+		if err := migration.RunMigrations(db, cfg.Database.Database, migrations); err != nil {
+			fatal("error running migrations", err)
+		}
+		slog.Info("Migrations executed successfully")
+	} else {
+		slog.Info("Automatic migrations disabled")
 	}
 
-	log.Println("Migrations executed successfully")
+	expectedVersion, err := migration.LatestVersion(migrations)
+	if err != nil {
+		fatal("error reading migration version", err)
+	}
+
+	readiness := health.New(cfg.Health)
+	readiness.AddCheck("database", health.PingCheck(db))
+	readiness.AddCheck("migrations", migration.VersionCheck(db, expectedVersion))
+
+	appMetrics := metrics.New()
+	appMetrics.RegisterDB(db, cfg.Database.Database)
+	usecase.AddObserver(appMetrics)
+	usecase.AddObserver(tracing.NewUseCaseObserver(tracerProvider))
 
-	var gateway category.CategoryGateway = persistence.NewMySQLCategoryGateway(db)
+	// Background workers run until the HTTP server has drained.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
 
-	createUseCase := createCategoryUC.NewCreateCategoryUseCase(gateway)
-	updateUseCase := updateCategoryUC.NewUpdateCategoryUseCase(gateway)
-	deleteUseCase := deleteCategoryUC.NewDeleteCategoryUseCase(gateway)
+	catalog := newCatalog(cfg, db)
+	gateway := catalog.categories
+	dispatcher := catalog.dispatcher
+
+	createUseCase := createCategoryUC.NewCreateCategoryUseCase(gateway, catalog.revisions, dispatcher, catalog.transactor)
+	updateUseCase := updateCategoryUC.NewUpdateCategoryUseCase(gateway, catalog.revisions, dispatcher, catalog.transactor)
+	deleteUseCase := deleteCategoryUC.NewDeleteCategoryUseCase(gateway, dispatcher)
 	getByIDUseCase := retriveCategoryUC.NewGetCategoryByIDUseCase(gateway)
 	listUseCase := retriveCategoryUC.NewListCategoriesUseCase(gateway)
 
@@ -55,17 +157,238 @@ func main() {
 		listUseCase,
 	)
 
+	importHandler := categoryHTTP.NewImportHandler(
+		importCategoryUC.NewImportCategoriesUseCase(gateway, catalog.revisions, dispatcher, catalog.transactor, cfg.Category.ImportMaxRows),
+	)
+
+	exportHandler := categoryHTTP.NewExportHandler(
+		exportCategoryUC.NewExportCategoriesUseCase(catalog.streamer),
+	)
+
+	slugHandler := categoryHTTP.NewSlugHandler(
+		retriveCategoryUC.NewGetCategoryBySlugUseCase(gateway),
+	)
+
+	hierarchyHandler := categoryHTTP.NewHierarchyHandler(
+		hierarchyCategoryUC.NewGetChildrenUseCase(gateway),
+		hierarchyCategoryUC.NewGetAncestorsUseCase(gateway),
+		hierarchyCategoryUC.NewGetTreeUseCase(catalog.streamer),
+	)
+
+	revisionHandler := categoryHTTP.NewRevisionHandler(
+		revisionCategoryUC.NewListRevisionsUseCase(catalog.revisions),
+		revisionCategoryUC.NewGetRevisionUseCase(catalog.revisions),
+		revisionCategoryUC.NewRevertCategoryUseCase(gateway, catalog.revisions, dispatcher, catalog.transactor),
+	)
+
+	auditHandler := categoryHTTP.NewAuditHandler(
+		retriveAuditUC.NewListCategoryHistoryUseCase(catalog.audit),
+	)
+
+	subscriptionGateway := catalog.subscriptions
+	deliveryGateway := catalog.deliveries
+
+	retryPolicy := webhook.DefaultRetryPolicy()
+	sender := webhookSender.NewHTTPSender(nil)
+
+	processUseCase := deliverWebhookUC.NewProcessDeliveriesUseCase(subscriptionGateway, deliveryGateway, sender, retryPolicy)
+
+	stream := events.NewStream(256)
+	dispatcher.Subscribe(stream.Publish)
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		webhookWorker.NewDeliveryWorker(processUseCase, 5*time.Second, 50).Run(workerCtx)
+	}()
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		catalog.auditRetry.Run(workerCtx)
+	}()
+
+	webhookHandler := categoryHTTP.NewWebhookHandler(
+		createWebhookUC.NewCreateSubscriptionUseCase(subscriptionGateway),
+		updateWebhookUC.NewUpdateSubscriptionUseCase(subscriptionGateway),
+		deleteWebhookUC.NewDeleteSubscriptionUseCase(subscriptionGateway),
+		retriveWebhookUC.NewGetSubscriptionByIDUseCase(subscriptionGateway),
+		retriveWebhookUC.NewListSubscriptionsUseCase(subscriptionGateway),
+		retriveWebhookUC.NewListDeliveriesUseCase(deliveryGateway),
+		deliverWebhookUC.NewRedeliverUseCase(deliveryGateway),
+	)
+
+	eventStreamHandler := categoryHTTP.NewEventStreamHandler(stream, 15*time.Second)
+
+	authenticator, err := auth.NewJWTAuthenticatorFromConfig(cfg.Auth)
+	if err != nil {
+		fatal("error configuring authentication", err)
+	}
+
+	authorizer := auth.DefaultRolePermissions()
+	if cfg.Auth.RolePermissionsFile != "" {
+		authorizer, err = auth.LoadRolePermissionsFile(cfg.Auth.RolePermissionsFile)
+		if err != nil {
+			fatal("error loading role permissions", err)
+		}
+	}
+
+	var apiKeyGateway apikey.APIKeyGateway = apiKeyPersistence.NewMySQLAPIKeyGateway(db)
+
+	apiKeyHandler := categoryHTTP.NewAPIKeyHandler(
+		createAPIKeyUC.NewCreateAPIKeyUseCase(apiKeyGateway, auth.APIKeyPermissions()),
+		revokeAPIKeyUC.NewRevokeAPIKeyUseCase(apiKeyGateway),
+		retriveAPIKeyUC.NewListAPIKeysUseCase(apiKeyGateway),
+	)
+	apiKeyAuthenticator := categoryHTTP.NewAPIKeyAuthenticator(
+		authenticateAPIKeyUC.NewAuthenticateAPIKeyUseCase(apiKeyGateway),
+	)
+
+	rateLimitStore := ratelimit.NewMemoryStore()
+
+	var idempotencyStore idempotency.Store = idempotency.NewMySQLStore(db)
+	if cfg.Idempotency.Store == idempotency.StoreMemory {
+		idempotencyStore = idempotency.NewMemoryStore()
+	}
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		idempotency.PurgeEvery(workerCtx, idempotencyStore, time.Hour)
+	}()
+
+	batchHandler := categoryHTTP.NewBatchHandler(
+		batchCategoryUC.NewBatchCategoriesUseCase(gateway, catalog.revisions, dispatcher, catalog.transactor, cfg.Category.BatchMaxSize),
+		authorizer,
+		auth.PermissionCatalogDelete,
+	)
+
+	limited := func(group string, next http.HandlerFunc) http.HandlerFunc {
+		if !cfg.RateLimit.Enabled {
+			return next
+		}
+		return categoryHTTP.RateLimit(rateLimitStore, group, cfg.RateLimit.Groups[group], next)
+	}
+
+	// limitByIP runs ahead of authentication, so that failed logins count
+	// against the client too.
+	limitByIP := func(next http.Handler) http.Handler {
+		if !cfg.RateLimit.Enabled {
+			return next
+		}
+		return categoryHTTP.RateLimitByIP(rateLimitStore, cfg.RateLimit.Groups[ratelimit.GroupIP])(next)
+	}
+
+	// idempotent runs inside the rate limit and the permission check, so a
+	// 429 or 403 is never stored and replayed under the Idempotency-Key.
+	idempotent := func(next http.HandlerFunc) http.HandlerFunc {
+		return categoryHTTP.Idempotency(idempotencyStore, cfg.Idempotency.TTL)(next).ServeHTTP
+	}
+
+	canRead := func(next http.HandlerFunc) http.HandlerFunc {
+		return limited(ratelimit.GroupRead, categoryHTTP.RequirePermission(authorizer, auth.PermissionCatalogRead, next))
+	}
+	canWrite := func(next http.HandlerFunc) http.HandlerFunc {
+		return limited(ratelimit.GroupWrite, categoryHTTP.RequirePermission(authorizer, auth.PermissionCatalogWrite, idempotent(next)))
+	}
+	canDelete := func(next http.HandlerFunc) http.HandlerFunc {
+		return limited(ratelimit.GroupWrite, categoryHTTP.RequirePermission(authorizer, auth.PermissionCatalogDelete, next))
+	}
+
+	canManageAPIKeys := func(next http.HandlerFunc) http.HandlerFunc {
+		return limited(ratelimit.GroupAdmin, categoryHTTP.RequirePermission(authorizer, auth.PermissionAPIKeysManage, idempotent(next)))
+	}
+
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("POST /categories", handler.CreateCategory)
-	mux.HandleFunc("GET /categories", handler.ListCategories)
-	mux.HandleFunc("GET /categories/{id}", handler.GetCategoryByID)
-	mux.HandleFunc("PUT /categories/{id}", handler.UpdateCategory)
-	mux.HandleFunc("DELETE /categories/{id}", handler.DeleteCategory)
+	mux.HandleFunc("POST /categories", canWrite(handler.CreateCategory))
+	mux.HandleFunc("GET /categories", canRead(handler.ListCategories))
+	mux.HandleFunc("POST /categories/import", canWrite(importHandler.ImportCategories))
+	mux.HandleFunc("GET /categories/export", canRead(exportHandler.ExportCategories))
+	mux.HandleFunc("POST /categories/batch", canWrite(batchHandler.ApplyBatch))
+	mux.HandleFunc("GET /categories/tree", canRead(hierarchyHandler.GetTree))
+	mux.HandleFunc("GET /categories/{id}", canRead(handler.GetCategoryByID))
+	mux.HandleFunc("PUT /categories/{id}", canWrite(handler.UpdateCategory))
+	mux.HandleFunc("DELETE /categories/{id}", canDelete(handler.DeleteCategory))
+	mux.HandleFunc("GET /categories/{id}/children", canRead(hierarchyHandler.ListChildren))
+	mux.HandleFunc("GET /categories/{id}/ancestors", canRead(hierarchyHandler.ListAncestors))
+	mux.HandleFunc("GET /categories/{id}/history", canRead(auditHandler.CategoryHistory))
+	mux.HandleFunc("GET /categories/{id}/revisions", canRead(revisionHandler.ListRevisions))
+	mux.HandleFunc("GET /categories/{id}/revisions/{revision}", canRead(revisionHandler.GetRevision))
+	mux.HandleFunc("POST /categories/{id}/revisions/{revision}/revert", canWrite(revisionHandler.Revert))
+	mux.HandleFunc("GET /categories/{id}/as-of", canRead(revisionHandler.GetAsOf))
+
+	mux.HandleFunc("POST /webhooks", canWrite(webhookHandler.CreateSubscription))
+	mux.HandleFunc("GET /webhooks", canRead(webhookHandler.ListSubscriptions))
+	mux.HandleFunc("GET /webhooks/{id}", canRead(webhookHandler.GetSubscriptionByID))
+	mux.HandleFunc("PUT /webhooks/{id}", canWrite(webhookHandler.UpdateSubscription))
+	mux.HandleFunc("DELETE /webhooks/{id}", canDelete(webhookHandler.DeleteSubscription))
+	mux.HandleFunc("GET /webhooks/{id}/deliveries", canRead(webhookHandler.ListDeliveries))
+	mux.HandleFunc("POST /webhooks/{id}/deliveries/{deliveryID}/redeliver", canWrite(webhookHandler.Redeliver))
+
+	mux.HandleFunc("POST /api-keys", canManageAPIKeys(apiKeyHandler.CreateAPIKey))
+	mux.HandleFunc("GET /api-keys", canManageAPIKeys(apiKeyHandler.ListAPIKeys))
+	mux.HandleFunc("DELETE /api-keys/{id}", canManageAPIKeys(apiKeyHandler.RevokeAPIKey))
+
+	mux.HandleFunc("GET /events", canRead(eventStreamHandler.StreamEvents))
+
+	// The slug route would conflict with the /categories/{id}/... patterns
+	// on one mux, so it is matched first from its own.
+	slugMux := http.NewServeMux()
+	slugMux.HandleFunc("GET /categories/by-slug/{slug}", canRead(slugHandler.GetCategoryBySlug))
+
+	routes := categoryHTTP.RouteSet{slugMux, mux}
+
+	authenticate := categoryHTTP.Authenticate(
+		categoryHTTP.AuthScheme{Name: categoryHTTP.SchemeBearer, Authenticator: authenticator},
+		categoryHTTP.AuthScheme{Name: categoryHTTP.SchemeAPIKey, Authenticator: apiKeyAuthenticator},
+	)
+	accessLog := categoryHTTP.AccessLog(logger, routes)
+	requestMetrics := categoryHTTP.RequestMetrics(appMetrics, routes)
+	traceRequests := categoryHTTP.Tracing(tracerProvider.Tracer(tracing.Name), otel.GetTextMapPropagator(), routes)
+
+	healthHandler := categoryHTTP.NewHealthHandler(readiness)
+
+	// Operational endpoints sit outside authentication; the API goes through
+	// the full middleware chain.
+	root := http.NewServeMux()
+	root.Handle("GET /metrics", appMetrics.Handler())
+	root.HandleFunc("GET /healthz", healthHandler.Liveness)
+	root.HandleFunc("GET /readyz", healthHandler.Readiness)
+	root.Handle("/", categoryHTTP.RequestContext(traceRequests(accessLog(requestMetrics(limitByIP(authenticate(routes)))))))
 
-	log.Println("HTTP server running at :8080")
+	srv := server.New(cfg.Server, root)
 
-	if err := http.ListenAndServe(":8080", mux); err != nil {
-		log.Fatalf("server error: %v", err)
+	// Shutdown first fails readiness and keeps serving for the readiness
+	// delay, so the orchestrator stops routing traffic here before
+	// connections are refused. It also ends event streams and refuses new
+	// ones, which would otherwise hold the drain open until the deadline.
+	srv.OnShutdown(readiness.Shutdown)
+	srv.OnShutdown(stream.Close)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	slog.Info("HTTP server running", "addr", cfg.Server.Addr)
+
+	if err := srv.ListenAndServe(ctx); err != nil {
+		slog.Error("server error", "error", err)
+	}
+
+	slog.Info("HTTP server stopped, stopping workers")
+
+	stopWorkers()
+	workers.Wait()
+
+	if err := db.Close(); err != nil {
+		slog.Error("error closing database", "error", err)
 	}
+
+	slog.Info("Shutdown complete")
+}
+
+// fatal logs err and exits; used while the server is being assembled.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
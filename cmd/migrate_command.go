@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/migration"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/logging"
+)
+
+const migrateUsage = `Usage: catalog migrate ACTION [flags]
+
+Actions:
+  up            apply every pending migration
+  down N        roll back the last N migrations
+  goto V        migrate up or down to version V
+  version       print the applied version
+  force V       mark version V as applied and clean, without running it
+  create NAME   write empty up/down files, numbered after the latest
+                migration, into migrate.dir, or ./migrations when the
+                embedded set is used
+
+Flags are the configuration flags listed by catalog -h.
+`
+
+// migrateCommand manages the schema outside server start-up, e.g. to roll
+// back or to recover from a dirty migration.
+func migrateCommand(args []string) {
+	if len(args) == 0 {
+		migrateUsageError("missing action")
+	}
+
+	action, args := args[0], args[1:]
+
+	// run performs the action; operands are checked before connecting.
+	var run func(*migration.Migrator) error
+
+	switch action {
+	case "up":
+		run = (*migration.Migrator).Up
+	case "version":
+		run = func(*migration.Migrator) error { return nil }
+	case "down":
+		n, err := strconv.Atoi(operand(action, &args))
+		if err != nil || n <= 0 {
+			migrateUsageError("down needs a positive number of steps")
+		}
+		run = func(m *migration.Migrator) error { return m.Down(n) }
+	case "goto":
+		v, err := strconv.ParseUint(operand(action, &args), 10, 64)
+		if err != nil {
+			migrateUsageError("goto needs a version")
+		}
+		run = func(m *migration.Migrator) error { return m.Goto(uint(v)) }
+	case "force":
+		v, err := strconv.Atoi(operand(action, &args))
+		if err != nil || v < -1 {
+			migrateUsageError("force needs a version or -1")
+		}
+		run = func(m *migration.Migrator) error { return m.Force(v) }
+	case "create":
+		name := operand(action, &args)
+		cfg := loadConfig(args)
+
+		// New files go next to the embedded ones unless a directory is set.
+		dir := cfg.Migrate.Dir
+		if dir == "" {
+			dir = "migrations"
+		}
+
+		up, down, err := migration.Create(dir, name, migration.Source(cfg.Migrate.Dir))
+		if err != nil {
+			fatal("error creating migration", err)
+		}
+		fmt.Println(up)
+		fmt.Println(down)
+		return
+	case "-h", "-help", "--help", "help":
+		fmt.Print(migrateUsage)
+		return
+	default:
+		migrateUsageError(fmt.Sprintf("unknown action %q", action))
+	}
+
+	cfg := loadConfig(args)
+	slog.SetDefault(logging.New(cfg.Log, os.Stderr))
+
+	db, err := mysql.NewConnection(cfg.Database)
+	if err != nil {
+		fatal("error connecting to database", err)
+	}
+
+	migrator, err := migration.NewMigrator(db, cfg.Database.Database, migration.Source(cfg.Migrate.Dir))
+	if err != nil {
+		fatal("error opening migrations", err)
+	}
+	defer migrator.Close()
+
+	if err := run(migrator); err != nil {
+		migrator.Close()
+		fatal("migrate "+action+" failed", err)
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		migrator.Close()
+		fatal("error reading migration version", err)
+	}
+
+	if dirty {
+		fmt.Printf("version %d (dirty: fix the schema, then run migrate force %d)\n", version, version)
+		return
+	}
+	fmt.Printf("version %d\n", version)
+}
+
+// operand takes the action's positional argument off args.
+func operand(action string, args *[]string) string {
+	if len(*args) == 0 || (strings.HasPrefix((*args)[0], "-") && action != "force") {
+		migrateUsageError(action + " needs an argument")
+	}
+	value := (*args)[0]
+	*args = (*args)[1:]
+	return value
+}
+
+func migrateUsageError(problem string) {
+	fmt.Fprintf(os.Stderr, "%s\n\n%s", problem, migrateUsage)
+	os.Exit(2)
+}
@@ -0,0 +1,85 @@
+// Package authenticate provides the use case that resolves an API key token
+// into the permissions it grants.
+package authenticate
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/apikey"
+)
+
+// ErrInvalidAPIKey is deliberately unspecific so callers cannot probe which
+// keys exist, are revoked or are bound to other networks.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// lastUsedResolution bounds how often a busy key writes its last-used time.
+const lastUsedResolution = time.Minute
+
+type AuthenticateAPIKeyUseCase struct {
+	Gateway apikey.APIKeyGateway
+}
+
+type AuthenticateAPIKeyInput struct {
+	Token    string
+	ClientIP string
+}
+
+type AuthenticateAPIKeyOutput struct {
+	ID          string
+	Name        string
+	Permissions []string
+}
+
+func NewAuthenticateAPIKeyUseCase(gateway apikey.APIKeyGateway) *AuthenticateAPIKeyUseCase {
+	return &AuthenticateAPIKeyUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *AuthenticateAPIKeyUseCase) Execute(ctx context.Context, input AuthenticateAPIKeyInput) (_ *AuthenticateAPIKeyOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "authenticate_api_key")
+	defer func() { end(err) }()
+
+	id, secret, err := apikey.ParseToken(input.Token)
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := uc.Gateway.GetAPIKeyByID(ctx, id)
+	if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !key.Verify(secret) || key.IsRevoked() {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if len(key.AllowedCIDRs) > 0 {
+		addr, err := netip.ParseAddr(input.ClientIP)
+		if err != nil || !key.AllowsAddress(addr) {
+			return nil, ErrInvalidAPIKey
+		}
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(key.LastUsedAt) >= lastUsedResolution {
+		// Failing to record usage must not lock a valid client out.
+		if err := uc.Gateway.RecordUse(ctx, key.ID, now); err != nil {
+			slog.ErrorContext(ctx, "recording api key use failed", "api_key_id", key.ID.String(), "error", err)
+		}
+	}
+
+	return &AuthenticateAPIKeyOutput{
+		ID:          key.ID.String(),
+		Name:        key.Name,
+		Permissions: key.Permissions,
+	}, nil
+}
@@ -0,0 +1,128 @@
+package authenticate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/apikey"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type APIKeyGatewayMock struct {
+	Keys   map[apikey.KeyID]*apikey.APIKey
+	UsedAt map[apikey.KeyID]time.Time
+	GetErr error
+}
+
+func (m *APIKeyGatewayMock) CreateAPIKey(ctx context.Context, key *apikey.APIKey) (*apikey.APIKey, error) {
+	return key, nil
+}
+
+func (m *APIKeyGatewayMock) GetAPIKeyByID(ctx context.Context, id apikey.KeyID) (*apikey.APIKey, error) {
+	if m.GetErr != nil {
+		return nil, m.GetErr
+	}
+	key, ok := m.Keys[id]
+	if !ok {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func (m *APIKeyGatewayMock) UpdateAPIKey(ctx context.Context, key *apikey.APIKey) (*apikey.APIKey, error) {
+	return key, nil
+}
+
+func (m *APIKeyGatewayMock) RecordUse(ctx context.Context, id apikey.KeyID, usedAt time.Time) error {
+	m.UsedAt[id] = usedAt
+	return nil
+}
+
+func (m *APIKeyGatewayMock) FindAll(ctx context.Context, query apikey.SearchAPIKeyQuery) (*pagination.Pagination[apikey.APIKey], error) {
+	return nil, nil
+}
+
+func newGateway(keys ...*apikey.APIKey) *APIKeyGatewayMock {
+	gateway := &APIKeyGatewayMock{
+		Keys:   make(map[apikey.KeyID]*apikey.APIKey),
+		UsedAt: make(map[apikey.KeyID]time.Time),
+	}
+	for _, key := range keys {
+		gateway.Keys[key.ID] = key
+	}
+	return gateway
+}
+
+func TestAuthenticateAPIKeyUseCase_Valid(t *testing.T) {
+	key, token, _ := apikey.NewAPIKey("sync job", []string{"catalog:read"}, []string{"10.0.0.0/8"})
+	gateway := newGateway(key)
+	uc := NewAuthenticateAPIKeyUseCase(gateway)
+
+	output, err := uc.Execute(context.Background(), AuthenticateAPIKeyInput{Token: token, ClientIP: "10.2.3.4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.ID != key.ID.String() || len(output.Permissions) != 1 || output.Permissions[0] != "catalog:read" {
+		t.Errorf("unexpected output %+v", output)
+	}
+
+	if _, ok := gateway.UsedAt[key.ID]; !ok {
+		t.Error("expected last-used time to be recorded")
+	}
+}
+
+func TestAuthenticateAPIKeyUseCase_SkipsRecentlyRecordedUse(t *testing.T) {
+	key, token, _ := apikey.NewAPIKey("sync job", []string{"catalog:read"}, nil)
+	key.LastUsedAt = time.Now().UTC()
+	gateway := newGateway(key)
+
+	if _, err := NewAuthenticateAPIKeyUseCase(gateway).Execute(context.Background(), AuthenticateAPIKeyInput{Token: token}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := gateway.UsedAt[key.ID]; ok {
+		t.Error("expected last-used write to be skipped within the resolution window")
+	}
+}
+
+func TestAuthenticateAPIKeyUseCase_Rejects(t *testing.T) {
+	key, token, _ := apikey.NewAPIKey("sync job", []string{"catalog:read"}, []string{"10.0.0.0/8"})
+	revoked, revokedToken, _ := apikey.NewAPIKey("old job", []string{"catalog:read"}, nil)
+	revoked.Revoke()
+	_, unknownToken, _ := apikey.NewAPIKey("unknown", []string{"catalog:read"}, nil)
+
+	id, _, _ := apikey.ParseToken(token)
+
+	tests := map[string]AuthenticateAPIKeyInput{
+		"malformed":         {Token: "garbage", ClientIP: "10.0.0.1"},
+		"wrong secret":      {Token: id.String() + ".wrong", ClientIP: "10.0.0.1"},
+		"revoked":           {Token: revokedToken, ClientIP: "10.0.0.1"},
+		"unknown key":       {Token: unknownToken, ClientIP: "10.0.0.1"},
+		"outside IP range":  {Token: token, ClientIP: "192.168.1.1"},
+		"unknown client IP": {Token: token},
+	}
+
+	uc := NewAuthenticateAPIKeyUseCase(newGateway(key, revoked))
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := uc.Execute(context.Background(), input); !errors.Is(err, ErrInvalidAPIKey) {
+				t.Errorf("expected ErrInvalidAPIKey, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAPIKeyUseCase_GatewayError(t *testing.T) {
+	_, token, _ := apikey.NewAPIKey("sync job", []string{"catalog:read"}, nil)
+	gateway := newGateway()
+	gateway.GetErr = errors.New("connection refused")
+
+	_, err := NewAuthenticateAPIKeyUseCase(gateway).Execute(context.Background(), AuthenticateAPIKeyInput{Token: token})
+	if err == nil || errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("expected gateway error to surface, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+// Package create provides use cases for issuing API keys.
+package create
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/apikey"
+)
+
+type CreateAPIKeyUseCase struct {
+	Gateway apikey.APIKeyGateway
+	// Grantable lists the permissions an API key may be given.
+	Grantable []string
+}
+
+type CreateAPIKeyInput struct {
+	Name         string
+	Permissions  []string
+	AllowedCIDRs []string
+}
+
+// CreateAPIKeyOutput is the only place the token is ever returned.
+type CreateAPIKeyOutput struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Token        string    `json:"token"`
+	Permissions  []string  `json:"permissions"`
+	AllowedCIDRs []string  `json:"allowed_cidrs"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func NewCreateAPIKeyUseCase(gateway apikey.APIKeyGateway, grantable []string) *CreateAPIKeyUseCase {
+	return &CreateAPIKeyUseCase{
+		Gateway:   gateway,
+		Grantable: grantable,
+	}
+}
+
+func (uc *CreateAPIKeyUseCase) Execute(ctx context.Context, input CreateAPIKeyInput) (_ *CreateAPIKeyOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "create_api_key")
+	defer func() { end(err) }()
+
+	key, token, err := apikey.NewAPIKey(input.Name, input.Permissions, input.AllowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := key.Validate(uc.Grantable); err != nil {
+		return nil, err
+	}
+
+	key, err = uc.Gateway.CreateAPIKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateAPIKeyOutput{
+		ID:           key.ID.String(),
+		Name:         key.Name,
+		Token:        token,
+		Permissions:  key.Permissions,
+		AllowedCIDRs: key.AllowedCIDRs,
+		CreatedAt:    key.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,85 @@
+// Package retrive provides use cases for listing API keys.
+package retrive
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/apikey"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type ListAPIKeysUseCase struct {
+	Gateway apikey.APIKeyGateway
+}
+
+type ListAPIKeysInput struct {
+	IncludeRevoked bool
+	Page           int
+	PerPage        int
+}
+
+type APIKeyOutput struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Permissions  []string   `json:"permissions"`
+	AllowedCIDRs []string   `json:"allowed_cidrs"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+}
+
+func NewListAPIKeysUseCase(gateway apikey.APIKeyGateway) *ListAPIKeysUseCase {
+	return &ListAPIKeysUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *ListAPIKeysUseCase) Execute(ctx context.Context, input ListAPIKeysInput) (_ *pagination.Pagination[APIKeyOutput], err error) {
+	ctx, end := usecase.Observe(ctx, "list_api_keys")
+	defer func() { end(err) }()
+
+	result, err := uc.Gateway.FindAll(ctx, apikey.SearchAPIKeyQuery{
+		IncludeRevoked: input.IncludeRevoked,
+		Page:           input.Page,
+		PerPage:        input.PerPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]APIKeyOutput, 0, len(result.Items))
+	for _, key := range result.Items {
+		items = append(items, toAPIKeyOutput(key))
+	}
+
+	return &pagination.Pagination[APIKeyOutput]{
+		CurrentPage: result.CurrentPage,
+		PerPage:     result.PerPage,
+		Total:       result.Total,
+		Items:       items,
+	}, nil
+}
+
+func toAPIKeyOutput(key apikey.APIKey) APIKeyOutput {
+	output := APIKeyOutput{
+		ID:           key.ID.String(),
+		Name:         key.Name,
+		Permissions:  key.Permissions,
+		AllowedCIDRs: key.AllowedCIDRs,
+		CreatedAt:    key.CreatedAt,
+	}
+
+	if !key.LastUsedAt.IsZero() {
+		lastUsedAt := key.LastUsedAt
+		output.LastUsedAt = &lastUsedAt
+	}
+
+	if key.IsRevoked() {
+		revokedAt := key.RevokedAt
+		output.RevokedAt = &revokedAt
+	}
+
+	return output
+}
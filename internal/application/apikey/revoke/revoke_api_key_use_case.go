@@ -0,0 +1,43 @@
+// Package revoke provides use cases for revoking API keys.
+package revoke
+
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/apikey"
+)
+
+type RevokeAPIKeyUseCase struct {
+	Gateway apikey.APIKeyGateway
+}
+
+type RevokeAPIKeyInput struct {
+	ID string
+}
+
+func NewRevokeAPIKeyUseCase(gateway apikey.APIKeyGateway) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *RevokeAPIKeyUseCase) Execute(ctx context.Context, input RevokeAPIKeyInput) (err error) {
+	ctx, end := usecase.Observe(ctx, "revoke_api_key")
+	defer func() { end(err) }()
+
+	id, err := apikey.ParseKeyID(input.ID)
+	if err != nil {
+		return err
+	}
+
+	key, err := uc.Gateway.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	key.Revoke()
+
+	_, err = uc.Gateway.UpdateAPIKey(ctx, key)
+	return err
+}
@@ -0,0 +1,47 @@
+// Package record provides use cases for writing audit entries.
+package record
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/audit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type RecordCategoryChangeUseCase struct {
+	Gateway audit.AuditGateway
+	Outbox  audit.OutboxGateway
+	Queue   *RetryQueue
+	Policy  RetryPolicy
+}
+
+func NewRecordCategoryChangeUseCase(gateway audit.AuditGateway, outbox audit.OutboxGateway, queue *RetryQueue, policy RetryPolicy) *RecordCategoryChangeUseCase {
+	return &RecordCategoryChangeUseCase{
+		Gateway: gateway,
+		Outbox:  outbox,
+		Queue:   queue,
+		Policy:  policy,
+	}
+}
+
+// Execute writes the audit entry for event. An entry that cannot be written
+// is held in the outbox, or in Queue when that fails too, for
+// RetryCategoryChangesUseCase; the error is still returned so the caller can
+// report it.
+func (uc *RecordCategoryChangeUseCase) Execute(ctx context.Context, event category.CategoryEvent) (err error) {
+	ctx, end := usecase.Observe(ctx, "record_category_change")
+	defer func() { end(err) }()
+
+	entry := audit.NewCategoryEntry(event)
+	if err := uc.Gateway.Record(ctx, entry); err != nil {
+		park(ctx, uc.Outbox, uc.Queue, audit.PendingEntry{
+			Entry:         *entry,
+			Attempts:      1,
+			NextAttemptAt: time.Now().Add(uc.Policy.Backoff(1)),
+		})
+		return err
+	}
+	return nil
+}
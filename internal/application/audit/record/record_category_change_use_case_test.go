@@ -0,0 +1,215 @@
+package record
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/audit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type AuditGatewayMock struct {
+	Recorded []*audit.Entry
+	Err      error
+}
+
+func (m *AuditGatewayMock) Record(ctx context.Context, entry *audit.Entry) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Recorded = append(m.Recorded, entry)
+	return nil
+}
+
+func (m *AuditGatewayMock) FindByEntity(ctx context.Context, query audit.SearchAuditQuery) (*pagination.Pagination[audit.Entry], error) {
+	return nil, nil
+}
+
+type OutboxGatewayMock struct {
+	Held map[string]audit.PendingEntry
+	Err  error
+}
+
+func NewOutboxGatewayMock() *OutboxGatewayMock {
+	return &OutboxGatewayMock{Held: make(map[string]audit.PendingEntry)}
+}
+
+func (m *OutboxGatewayMock) Hold(ctx context.Context, pending *audit.PendingEntry) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Held[pending.Entry.ID] = *pending
+	return nil
+}
+
+func (m *OutboxGatewayMock) FindDue(ctx context.Context, now time.Time, limit int) ([]audit.PendingEntry, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	var due []audit.PendingEntry
+	for _, pending := range m.Held {
+		if !pending.NextAttemptAt.After(now) && len(due) < limit {
+			due = append(due, pending)
+		}
+	}
+	return due, nil
+}
+
+func (m *OutboxGatewayMock) Release(ctx context.Context, id string) error {
+	delete(m.Held, id)
+	return nil
+}
+
+// immediate retries right away, so tests need not wait for the backoff.
+var immediate = RetryPolicy{MaxAttempts: 3}
+
+func newEvent() category.CategoryEvent {
+	cat, _ := category.NewCategory("Movies", "desc", true)
+	return category.NewCategoryEvent(category.EventCategoryCreated, nil, cat)
+}
+
+func TestRecordCategoryChangeUseCase_HoldsFailedWritesInTheOutbox(t *testing.T) {
+	expectedErr := errors.New("database error")
+	gateway := &AuditGatewayMock{Err: expectedErr}
+	outbox := NewOutboxGatewayMock()
+	queue := NewRetryQueue()
+
+	event := newEvent()
+	err := NewRecordCategoryChangeUseCase(gateway, outbox, queue, immediate).Execute(context.Background(), event)
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected %v, got %v", expectedErr, err)
+	}
+	if len(outbox.Held) != 1 || queue.Len() != 0 {
+		t.Fatalf("expected the entry to be held in the outbox, got %d held and %d queued", len(outbox.Held), queue.Len())
+	}
+
+	gateway.Err = nil
+	output, err := NewRetryCategoryChangesUseCase(gateway, outbox, queue, immediate).Execute(context.Background(), RetryCategoryChangesInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.Recorded != 1 || len(gateway.Recorded) != 1 || gateway.Recorded[0].ID != event.ID {
+		t.Errorf("expected the held entry to be recorded, got %+v", gateway.Recorded)
+	}
+	if len(outbox.Held) != 0 {
+		t.Errorf("expected the entry to be released, got %d held", len(outbox.Held))
+	}
+}
+
+func TestRecordCategoryChangeUseCase_QueuesWhenTheOutboxFails(t *testing.T) {
+	gateway := &AuditGatewayMock{Err: errors.New("database error")}
+	outbox := NewOutboxGatewayMock()
+	outbox.Err = errors.New("database error")
+	queue := NewRetryQueue()
+
+	policy := RetryPolicy{MaxAttempts: 5}
+
+	event := newEvent()
+	NewRecordCategoryChangeUseCase(gateway, outbox, queue, policy).Execute(context.Background(), event)
+	if queue.Len() != 1 {
+		t.Fatalf("expected the entry to be queued in memory, got %d", queue.Len())
+	}
+
+	// The outbox is back but the entry still fails to record, so it moves
+	// there and, being due at once, is tried again from it.
+	outbox.Err = nil
+	retry := NewRetryCategoryChangesUseCase(gateway, outbox, queue, policy)
+
+	if _, err := retry.Execute(context.Background(), RetryCategoryChangesInput{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if queue.Len() != 0 || outbox.Held[event.ID].Attempts != 3 {
+		t.Fatalf("expected the entry to be held in the outbox after its third attempt, got %d queued and %+v", queue.Len(), outbox.Held)
+	}
+}
+
+func TestRetryCategoryChangesUseCase_DropsAfterMaxAttempts(t *testing.T) {
+	gateway := &AuditGatewayMock{Err: errors.New("database error")}
+	outbox := NewOutboxGatewayMock()
+	queue := NewRetryQueue()
+
+	NewRecordCategoryChangeUseCase(gateway, outbox, queue, immediate).Execute(context.Background(), newEvent())
+
+	retry := NewRetryCategoryChangesUseCase(gateway, outbox, queue, immediate)
+
+	output, _ := retry.Execute(context.Background(), RetryCategoryChangesInput{})
+	if len(output.Dropped) != 0 || len(outbox.Held) != 1 {
+		t.Fatalf("expected the entry to stay held after its second attempt, got %+v", output)
+	}
+
+	output, _ = retry.Execute(context.Background(), RetryCategoryChangesInput{})
+	if len(output.Dropped) != 1 || len(outbox.Held) != 0 {
+		t.Errorf("expected the entry to be dropped after its third attempt, got %+v", output)
+	}
+}
+
+func TestRetryCategoryChangesUseCase_WaitsForBackoff(t *testing.T) {
+	gateway := &AuditGatewayMock{Err: errors.New("database error")}
+	outbox := NewOutboxGatewayMock()
+	outbox.Err = errors.New("database error")
+	queue := NewRetryQueue()
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	NewRecordCategoryChangeUseCase(gateway, outbox, queue, policy).Execute(context.Background(), newEvent())
+	gateway.Err = nil
+	outbox.Err = nil
+
+	retry := NewRetryCategoryChangesUseCase(gateway, outbox, queue, policy)
+
+	output, _ := retry.Execute(context.Background(), RetryCategoryChangesInput{})
+	if output.Recorded != 0 || queue.Len() != 1 {
+		t.Fatalf("expected the entry to wait for its backoff, got %+v", output)
+	}
+
+	output, _ = retry.Execute(context.Background(), RetryCategoryChangesInput{Final: true})
+	if output.Recorded != 1 || queue.Len() != 0 {
+		t.Errorf("expected a final run to record the entry, got %+v", output)
+	}
+}
+
+func TestRetryCategoryChangesUseCase_FinalMovesFailuresToTheOutbox(t *testing.T) {
+	gateway := &AuditGatewayMock{Err: errors.New("database error")}
+	outbox := NewOutboxGatewayMock()
+	outbox.Err = errors.New("database error")
+	queue := NewRetryQueue()
+
+	NewRecordCategoryChangeUseCase(gateway, outbox, queue, DefaultRetryPolicy()).Execute(context.Background(), newEvent())
+	outbox.Err = nil
+
+	output, _ := NewRetryCategoryChangesUseCase(gateway, outbox, queue, DefaultRetryPolicy()).
+		Execute(context.Background(), RetryCategoryChangesInput{Final: true})
+
+	if len(output.Dropped) != 0 || queue.Len() != 0 || len(outbox.Held) != 1 {
+		t.Errorf("expected a final run to hold the failing entry in the outbox, got %+v", output)
+	}
+}
+
+func TestRetryCategoryChangesUseCase_FinalDropsWhatCannotBeHeld(t *testing.T) {
+	gateway := &AuditGatewayMock{Err: errors.New("database error")}
+	outbox := NewOutboxGatewayMock()
+	outbox.Err = errors.New("database error")
+	queue := NewRetryQueue()
+
+	NewRecordCategoryChangeUseCase(gateway, outbox, queue, DefaultRetryPolicy()).Execute(context.Background(), newEvent())
+
+	output, _ := NewRetryCategoryChangesUseCase(gateway, outbox, queue, DefaultRetryPolicy()).
+		Execute(context.Background(), RetryCategoryChangesInput{Final: true})
+
+	if len(output.Dropped) != 1 || queue.Len() != 0 {
+		t.Errorf("expected a final run to drop the failing entry, got %+v", output)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		if got := policy.Backoff(attempts); got != want {
+			t.Errorf("Backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package record
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/audit"
+)
+
+// outboxBatchSize bounds the outbox entries retried in one run.
+const outboxBatchSize = 100
+
+type RetryCategoryChangesUseCase struct {
+	Gateway audit.AuditGateway
+	Outbox  audit.OutboxGateway
+	Queue   *RetryQueue
+	Policy  RetryPolicy
+}
+
+type RetryCategoryChangesInput struct {
+	// Final attempts every entry queued in memory, due or not, as before the
+	// process exits. Those that still fail are moved to the outbox, or
+	// dropped when it cannot be written; the outbox itself is left for a
+	// later run.
+	Final bool
+}
+
+type RetryCategoryChangesOutput struct {
+	Recorded int
+	// Dropped holds the entries given up on, so the caller can log them.
+	Dropped []audit.Entry
+}
+
+func NewRetryCategoryChangesUseCase(gateway audit.AuditGateway, outbox audit.OutboxGateway, queue *RetryQueue, policy RetryPolicy) *RetryCategoryChangesUseCase {
+	return &RetryCategoryChangesUseCase{
+		Gateway: gateway,
+		Outbox:  outbox,
+		Queue:   queue,
+		Policy:  policy,
+	}
+}
+
+// Execute writes the entries that are due, first those queued in memory and
+// then those held in the outbox, rescheduling with backoff those that fail
+// again until Policy.MaxAttempts is reached.
+func (uc *RetryCategoryChangesUseCase) Execute(ctx context.Context, input RetryCategoryChangesInput) (_ *RetryCategoryChangesOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "retry_category_changes")
+	defer func() { end(err) }()
+
+	now := time.Now()
+	due := uc.Queue.take(now, input.Final)
+	output := &RetryCategoryChangesOutput{}
+
+	for i, pending := range due {
+		if err := ctx.Err(); err != nil {
+			uc.Queue.hold(due[i:]...)
+			return output, err
+		}
+
+		if err := uc.Gateway.Record(ctx, &pending.Entry); err == nil {
+			output.Recorded++
+			continue
+		}
+
+		pending.Attempts++
+		if pending.Attempts >= uc.Policy.MaxAttempts {
+			output.Dropped = append(output.Dropped, pending.Entry)
+			continue
+		}
+		pending.NextAttemptAt = now.Add(uc.Policy.Backoff(pending.Attempts))
+
+		if input.Final {
+			if err := uc.Outbox.Hold(ctx, &pending); err != nil {
+				output.Dropped = append(output.Dropped, pending.Entry)
+			}
+			continue
+		}
+		park(ctx, uc.Outbox, uc.Queue, pending)
+	}
+
+	if input.Final {
+		return output, nil
+	}
+
+	held, err := uc.Outbox.FindDue(ctx, now, outboxBatchSize)
+	if err != nil {
+		return output, err
+	}
+
+	for _, pending := range held {
+		if err := ctx.Err(); err != nil {
+			return output, err
+		}
+
+		if err := uc.Gateway.Record(ctx, &pending.Entry); err == nil {
+			output.Recorded++
+			if err := uc.Outbox.Release(ctx, pending.Entry.ID); err != nil {
+				return output, err
+			}
+			continue
+		}
+
+		pending.Attempts++
+		if pending.Attempts >= uc.Policy.MaxAttempts {
+			output.Dropped = append(output.Dropped, pending.Entry)
+			if err := uc.Outbox.Release(ctx, pending.Entry.ID); err != nil {
+				return output, err
+			}
+			continue
+		}
+		pending.NextAttemptAt = now.Add(uc.Policy.Backoff(pending.Attempts))
+
+		if err := uc.Outbox.Hold(ctx, &pending); err != nil {
+			return output, err
+		}
+	}
+
+	return output, nil
+}
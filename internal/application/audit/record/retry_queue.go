@@ -0,0 +1,89 @@
+package record
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/audit"
+)
+
+// RetryPolicy spaces the attempts at writing an audit entry, doubling the
+// delay from BaseDelay up to MaxDelay, and gives up after MaxAttempts.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 10,
+		BaseDelay:   time.Second,
+		MaxDelay:    5 * time.Minute,
+	}
+}
+
+// Backoff returns the delay before the next attempt once the given number of
+// attempts has been made.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// RetryQueue holds the audit entries that could be written neither to the
+// audit log nor to the outbox, usually because the database is unreachable.
+// It lives in memory, so the retry use case moves its entries to the outbox
+// once it can, and reports those still queued as dropped on its final run.
+type RetryQueue struct {
+	mu      sync.Mutex
+	entries []audit.PendingEntry
+}
+
+func NewRetryQueue() *RetryQueue {
+	return &RetryQueue{}
+}
+
+func (q *RetryQueue) hold(entries ...audit.PendingEntry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.entries = append(q.entries, entries...)
+}
+
+// take removes and returns the entries due at now, or every entry when all
+// is set.
+func (q *RetryQueue) take(now time.Time, all bool) []audit.PendingEntry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var due, waiting []audit.PendingEntry
+	for _, pending := range q.entries {
+		if all || !pending.NextAttemptAt.After(now) {
+			due = append(due, pending)
+		} else {
+			waiting = append(waiting, pending)
+		}
+	}
+	q.entries = waiting
+	return due
+}
+
+// Len returns the number of entries waiting to be written.
+func (q *RetryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.entries)
+}
+
+// park holds pending in the outbox, or in queue when the outbox cannot be
+// written either.
+func park(ctx context.Context, outbox audit.OutboxGateway, queue *RetryQueue, pending audit.PendingEntry) {
+	if err := outbox.Hold(ctx, &pending); err != nil {
+		queue.hold(pending)
+	}
+}
@@ -0,0 +1,78 @@
+// Package retrive provides use cases for reading the audit trail.
+package retrive
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/audit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type ListCategoryHistoryUseCase struct {
+	Gateway audit.AuditGateway
+}
+
+type ListCategoryHistoryInput struct {
+	CategoryID string
+	Page       int
+	PerPage    int
+}
+
+type HistoryEntryOutput struct {
+	ID         string              `json:"id"`
+	Action     string              `json:"action"`
+	Actor      string              `json:"actor"`
+	RequestID  string              `json:"request_id,omitempty"`
+	CausedBy   string              `json:"caused_by,omitempty"`
+	OccurredAt time.Time           `json:"occurred_at"`
+	Changes    []audit.FieldChange `json:"changes"`
+}
+
+func NewListCategoryHistoryUseCase(gateway audit.AuditGateway) *ListCategoryHistoryUseCase {
+	return &ListCategoryHistoryUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *ListCategoryHistoryUseCase) Execute(ctx context.Context, input ListCategoryHistoryInput) (_ *pagination.Pagination[HistoryEntryOutput], err error) {
+	ctx, end := usecase.Observe(ctx, "list_category_history")
+	defer func() { end(err) }()
+
+	id, err := category.ParseCategoryID(input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := uc.Gateway.FindByEntity(ctx, audit.SearchAuditQuery{
+		EntityType: audit.EntityCategory,
+		EntityID:   id.String(),
+		Page:       input.Page,
+		PerPage:    input.PerPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]HistoryEntryOutput, 0, len(result.Items))
+	for _, entry := range result.Items {
+		items = append(items, HistoryEntryOutput{
+			ID:         entry.ID,
+			Action:     string(entry.Action),
+			Actor:      entry.Actor,
+			RequestID:  entry.RequestID,
+			CausedBy:   entry.CausedBy,
+			OccurredAt: entry.OccurredAt,
+			Changes:    entry.Changes,
+		})
+	}
+
+	return &pagination.Pagination[HistoryEntryOutput]{
+		CurrentPage: result.CurrentPage,
+		PerPage:     result.PerPage,
+		Total:       result.Total,
+		Items:       items,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package retrive
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/audit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type AuditGatewayMock struct {
+	FindByEntityFn func(audit.SearchAuditQuery) (*pagination.Pagination[audit.Entry], error)
+}
+
+func (m *AuditGatewayMock) Record(ctx context.Context, entry *audit.Entry) error {
+	return nil
+}
+
+func (m *AuditGatewayMock) FindByEntity(ctx context.Context, query audit.SearchAuditQuery) (*pagination.Pagination[audit.Entry], error) {
+	return m.FindByEntityFn(query)
+}
+
+func TestListCategoryHistoryUseCase_Execute(t *testing.T) {
+	catID := category.NewCategoryID()
+
+	var receivedQuery audit.SearchAuditQuery
+
+	gateway := &AuditGatewayMock{
+		FindByEntityFn: func(query audit.SearchAuditQuery) (*pagination.Pagination[audit.Entry], error) {
+			receivedQuery = query
+			return &pagination.Pagination[audit.Entry]{
+				CurrentPage: 2,
+				PerPage:     5,
+				Total:       6,
+				Items: []audit.Entry{{
+					ID:         "entry-1",
+					Action:     audit.ActionUpdate,
+					Actor:      "editor@example.com",
+					OccurredAt: time.Now(),
+					Changes:    []audit.FieldChange{{Field: "name", Before: "Movies", After: "Films"}},
+				}},
+			}, nil
+		},
+	}
+
+	useCase := NewListCategoryHistoryUseCase(gateway)
+
+	output, err := useCase.Execute(context.Background(), ListCategoryHistoryInput{
+		CategoryID: catID.String(),
+		Page:       2,
+		PerPage:    5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if receivedQuery.EntityType != audit.EntityCategory || receivedQuery.EntityID != catID.String() {
+		t.Errorf("unexpected query: %+v", receivedQuery)
+	}
+
+	if receivedQuery.Page != 2 || receivedQuery.PerPage != 5 {
+		t.Errorf("invalid pagination mapping: %+v", receivedQuery)
+	}
+
+	if output.Total != 6 || len(output.Items) != 1 {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+
+	if output.Items[0].Action != "update" || output.Items[0].Changes[0].Field != "name" {
+		t.Errorf("unexpected entry: %+v", output.Items[0])
+	}
+}
+
+func TestListCategoryHistoryUseCase_InvalidID(t *testing.T) {
+	useCase := NewListCategoryHistoryUseCase(&AuditGatewayMock{})
+
+	output, err := useCase.Execute(context.Background(), ListCategoryHistoryInput{CategoryID: "invalid-uuid"})
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if output != nil {
+		t.Fatal("expected nil output")
+	}
+}
+
+func TestListCategoryHistoryUseCase_GatewayError(t *testing.T) {
+	expectedErr := errors.New("database error")
+
+	gateway := &AuditGatewayMock{
+		FindByEntityFn: func(query audit.SearchAuditQuery) (*pagination.Pagination[audit.Entry], error) {
+			return nil, expectedErr
+		},
+	}
+
+	useCase := NewListCategoryHistoryUseCase(gateway)
+
+	_, err := useCase.Execute(context.Background(), ListCategoryHistoryInput{CategoryID: category.NewCategoryID().String()})
+
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+// Package batch provides the use case for applying many category changes in
+// one request.
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/create"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/delete"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/update"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type OperationType string
+
+const (
+	OperationCreate     OperationType = "create"
+	OperationUpdate     OperationType = "update"
+	OperationDelete     OperationType = "delete"
+	OperationActivate   OperationType = "activate"
+	OperationDeactivate OperationType = "deactivate"
+)
+
+type ResultStatus string
+
+const (
+	StatusCreated ResultStatus = "created"
+	StatusUpdated ResultStatus = "updated"
+	StatusDeleted ResultStatus = "deleted"
+	StatusFailed  ResultStatus = "failed"
+	// StatusRolledBack marks an operation that succeeded in an atomic batch
+	// which was then rolled back.
+	StatusRolledBack ResultStatus = "rolled_back"
+	// StatusSkipped marks an operation an atomic batch did not reach.
+	StatusSkipped ResultStatus = "skipped"
+)
+
+var (
+	ErrEmptyBatch       = errors.New("batch has no operations")
+	ErrBatchTooLarge    = errors.New("batch has too many operations")
+	ErrInvalidOperation = errors.New("invalid operation")
+)
+
+type BatchCategoriesUseCase struct {
+	Gateway         category.CategoryGateway
+	RevisionGateway category.CategoryRevisionGateway
+	Publisher       category.EventPublisher
+	Transactor      usecase.Transactor
+	MaxSize         int
+}
+
+// Operation is one change. Update changes only the fields that are set;
+// activate and deactivate need only the ID. An empty ParentID makes the
+// category a root one.
+type Operation struct {
+	Type        OperationType
+	ID          string
+	Name        *string
+	Slug        *string
+	Description *string
+	IsActive    *bool
+	ParentID    *string
+}
+
+type BatchCategoriesInput struct {
+	// Atomic runs every operation in one transaction and stops at the first
+	// failure, undoing the others.
+	Atomic     bool
+	Operations []Operation
+}
+
+type OperationResult struct {
+	Index  int           `json:"index"`
+	Type   OperationType `json:"op"`
+	ID     string        `json:"id,omitempty"`
+	Status ResultStatus  `json:"status"`
+	Error  string        `json:"error,omitempty"`
+	// Err is the failure behind Error, for callers that classify it.
+	Err error `json:"-"`
+}
+
+type BatchCategoriesOutput struct {
+	Atomic    bool              `json:"atomic"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []OperationResult `json:"results"`
+}
+
+func NewBatchCategoriesUseCase(
+	gateway category.CategoryGateway,
+	revisionGateway category.CategoryRevisionGateway,
+	publisher category.EventPublisher,
+	transactor usecase.Transactor,
+	maxSize int,
+) *BatchCategoriesUseCase {
+	return &BatchCategoriesUseCase{
+		Gateway:         gateway,
+		RevisionGateway: revisionGateway,
+		Publisher:       publisher,
+		Transactor:      transactor,
+		MaxSize:         maxSize,
+	}
+}
+
+// Execute applies the operations in order and reports each one, through the
+// create, update and delete use cases, so an operation behaves as it does on
+// its own endpoint. Every operation runs in a transaction of its own, or all
+// of them in one when the batch is atomic. Events are published once the
+// changes are stored, so an operation or atomic batch that rolls back
+// publishes none.
+func (uc *BatchCategoriesUseCase) Execute(ctx context.Context, input BatchCategoriesInput) (_ *BatchCategoriesOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "batch_categories")
+	defer func() { end(err) }()
+
+	if len(input.Operations) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if uc.MaxSize > 0 && len(input.Operations) > uc.MaxSize {
+		return nil, fmt.Errorf("%w: %d operations, at most %d allowed", ErrBatchTooLarge, len(input.Operations), uc.MaxSize)
+	}
+
+	results := make([]OperationResult, len(input.Operations))
+	for i, op := range input.Operations {
+		results[i] = OperationResult{Index: i, Type: op.Type, ID: op.ID}
+	}
+
+	// The use cases publish into buffer, which holds the events until the
+	// transaction they belong to has committed.
+	buffer := &eventBuffer{}
+	ops := operations{
+		gateway: uc.Gateway,
+		create:  create.NewCreateCategoryUseCase(uc.Gateway, uc.RevisionGateway, buffer, uc.Transactor),
+		update:  update.NewUpdateCategoryUseCase(uc.Gateway, uc.RevisionGateway, buffer, uc.Transactor),
+		delete:  delete.NewDeleteCategoryUseCase(uc.Gateway, buffer),
+	}
+
+	var events []category.CategoryEvent
+
+	if input.Atomic {
+		err := uc.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			for i, op := range input.Operations {
+				if err := ops.apply(ctx, op, &results[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		events = buffer.take()
+		if err != nil {
+			events = nil
+			for i := range results {
+				switch results[i].Status {
+				case StatusFailed:
+				case "":
+					results[i].Status = StatusSkipped
+				default:
+					results[i].Status = StatusRolledBack
+				}
+			}
+		}
+	} else {
+		for i, op := range input.Operations {
+			err := uc.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				return ops.apply(ctx, op, &results[i])
+			})
+			opEvents := buffer.take()
+			if err == nil {
+				events = append(events, opEvents...)
+			}
+		}
+	}
+
+	for _, event := range events {
+		uc.Publisher.Publish(event)
+	}
+
+	output := &BatchCategoriesOutput{Atomic: input.Atomic, Results: results}
+	for _, result := range results {
+		switch result.Status {
+		case StatusCreated, StatusUpdated, StatusDeleted:
+			output.Succeeded++
+		default:
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
+
+// eventBuffer collects the events the use cases publish.
+type eventBuffer struct {
+	events []category.CategoryEvent
+}
+
+func (b *eventBuffer) Publish(event category.CategoryEvent) {
+	b.events = append(b.events, event)
+}
+
+func (b *eventBuffer) take() []category.CategoryEvent {
+	events := b.events
+	b.events = nil
+	return events
+}
+
+type operations struct {
+	gateway category.CategoryGateway
+	create  *create.CreateCategoryUseCase
+	update  *update.UpdateCategoryUseCase
+	delete  *delete.DeleteCategoryUseCase
+}
+
+// apply runs one operation, recording its outcome in result.
+func (o operations) apply(ctx context.Context, op Operation, result *OperationResult) error {
+	err := o.run(ctx, op, result)
+	if err != nil {
+		result.Status, result.Error, result.Err = StatusFailed, err.Error(), err
+	}
+	return err
+}
+
+func (o operations) run(ctx context.Context, op Operation, result *OperationResult) error {
+	if op.Type == OperationCreate {
+		if op.Name == nil {
+			return fmt.Errorf("%w: create needs a name", ErrInvalidOperation)
+		}
+
+		input := create.CreateCategoryInput{Name: *op.Name, IsActive: true}
+		if op.Description != nil {
+			input.Description = *op.Description
+		}
+		if op.IsActive != nil {
+			input.IsActive = *op.IsActive
+		}
+		if op.Slug != nil {
+			input.Slug = *op.Slug
+		}
+		if op.ParentID != nil {
+			input.ParentID = *op.ParentID
+		}
+
+		output, err := o.create.Execute(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		result.ID, result.Status = output.ID, StatusCreated
+		return nil
+	}
+
+	switch op.Type {
+	case OperationUpdate, OperationDelete, OperationActivate, OperationDeactivate:
+	default:
+		return fmt.Errorf("%w: unknown op %q", ErrInvalidOperation, op.Type)
+	}
+
+	id, err := category.ParseCategoryID(op.ID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid id %q", ErrInvalidOperation, op.ID)
+	}
+
+	if op.Type == OperationDelete {
+		if err := o.delete.Execute(ctx, delete.DeleteCategoryInput{ID: op.ID}); err != nil {
+			return err
+		}
+		result.Status = StatusDeleted
+		return nil
+	}
+
+	// The update use case replaces every field, so the ones the operation
+	// leaves out are taken from the stored category.
+	current, err := o.gateway.GetCategoryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	input := update.UpdateCategoryInput{
+		ID:          op.ID,
+		Name:        current.Name,
+		Description: current.Description,
+		IsActive:    current.IsActive,
+	}
+	switch op.Type {
+	case OperationActivate:
+		input.IsActive = true
+	case OperationDeactivate:
+		input.IsActive = false
+	default:
+		if op.Name != nil {
+			input.Name = *op.Name
+		}
+		if op.Description != nil {
+			input.Description = *op.Description
+		}
+		if op.IsActive != nil {
+			input.IsActive = *op.IsActive
+		}
+		if op.Slug != nil {
+			input.Slug = *op.Slug
+		}
+		input.ParentID = op.ParentID
+	}
+
+	if _, err := o.update.Execute(ctx, input); err != nil {
+		return err
+	}
+
+	result.Status = StatusUpdated
+	return nil
+}
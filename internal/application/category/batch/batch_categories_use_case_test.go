@@ -0,0 +1,389 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type CategoryGatewayMock struct {
+	Categories map[category.CategoryID]category.Category
+	// FailUpdate makes updates of that category fail.
+	FailUpdate *category.CategoryID
+}
+
+func newGateway(cats ...*category.Category) *CategoryGatewayMock {
+	m := &CategoryGatewayMock{Categories: map[category.CategoryID]category.Category{}}
+	for _, cat := range cats {
+		m.Categories[cat.ID] = *cat
+	}
+	return m
+}
+
+func (m *CategoryGatewayMock) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	m.Categories[cat.ID] = *cat
+	return cat, nil
+}
+
+func (m *CategoryGatewayMock) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
+	cat, ok := m.Categories[id]
+	if !ok {
+		return nil, category.ErrCategoryNotFound
+	}
+	return &cat, nil
+}
+
+func (m *CategoryGatewayMock) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	if m.FailUpdate != nil && *m.FailUpdate == cat.ID {
+		return nil, errors.New("database error")
+	}
+	m.Categories[cat.ID] = *cat
+	return cat, nil
+}
+
+func (m *CategoryGatewayMock) DeleteCategory(ctx context.Context, id category.CategoryID) error {
+	delete(m.Categories, id)
+	return nil
+}
+
+func (m *CategoryGatewayMock) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	for _, cat := range m.Categories {
+		if cat.Slug == slug {
+			return &cat, nil
+		}
+	}
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	var children []category.Category
+	for _, cat := range m.Categories {
+		if cat.ParentID != nil && *cat.ParentID == parentID {
+			children = append(children, cat)
+		}
+	}
+	return children, nil
+}
+
+func (m *CategoryGatewayMock) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	return nil, nil
+}
+
+type RevisionGatewayMock struct {
+	Appended []*category.CategoryRevision
+	Err      error
+}
+
+func (m *RevisionGatewayMock) AppendRevision(ctx context.Context, rev *category.CategoryRevision) (*category.CategoryRevision, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	m.Appended = append(m.Appended, rev)
+	return rev, nil
+}
+
+func (m *RevisionGatewayMock) GetRevision(ctx context.Context, id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (m *RevisionGatewayMock) GetRevisionAsOf(ctx context.Context, id category.CategoryID, at time.Time) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (m *RevisionGatewayMock) FindRevisions(ctx context.Context, query category.SearchRevisionQuery) (*pagination.Pagination[category.CategoryRevision], error) {
+	return nil, nil
+}
+
+type EventPublisherMock struct {
+	Events []category.CategoryEvent
+}
+
+func (m *EventPublisherMock) Publish(event category.CategoryEvent) {
+	m.Events = append(m.Events, event)
+}
+
+// TransactorMock restores the gateway's categories when fn fails.
+type TransactorMock struct {
+	Gateway *CategoryGatewayMock
+}
+
+func (m *TransactorMock) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := map[category.CategoryID]category.Category{}
+	for id, cat := range m.Gateway.Categories {
+		saved[id] = cat
+	}
+	if err := fn(ctx); err != nil {
+		m.Gateway.Categories = saved
+		return err
+	}
+	return nil
+}
+
+func newUseCase(gateway *CategoryGatewayMock, maxSize int) (*BatchCategoriesUseCase, *EventPublisherMock) {
+	publisher := &EventPublisherMock{}
+	return NewBatchCategoriesUseCase(gateway, &RevisionGatewayMock{}, publisher, &TransactorMock{Gateway: gateway}, maxSize), publisher
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func statuses(output *BatchCategoriesOutput) []ResultStatus {
+	var got []ResultStatus
+	for _, result := range output.Results {
+		got = append(got, result.Status)
+	}
+	return got
+}
+
+func equalStatuses(a, b []ResultStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBatchAppliesEachOperation(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films", true)
+	series, _ := category.NewCategory("Series", "", true)
+	gateway := newGateway(movies, series)
+	useCase, publisher := newUseCase(gateway, 10)
+
+	output, err := useCase.Execute(context.Background(), BatchCategoriesInput{
+		Operations: []Operation{
+			{Type: OperationCreate, Name: ptr("Documentaries")},
+			{Type: OperationDeactivate, ID: movies.ID.String()},
+			{Type: OperationUpdate, ID: series.ID.String(), Description: ptr("shows")},
+			{Type: OperationDelete, ID: category.NewCategoryID().String()},
+			{Type: "rename", ID: series.ID.String()},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ResultStatus{StatusCreated, StatusUpdated, StatusUpdated, StatusFailed, StatusFailed}
+	if got := statuses(output); !equalStatuses(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if !errors.Is(output.Results[3].Err, category.ErrCategoryNotFound) {
+		t.Errorf("expected not found, got %v", output.Results[3].Err)
+	}
+	if !errors.Is(output.Results[4].Err, ErrInvalidOperation) {
+		t.Errorf("expected invalid operation, got %v", output.Results[4].Err)
+	}
+
+	if gateway.Categories[movies.ID].IsActive {
+		t.Error("expected Movies to be deactivated")
+	}
+	if got := gateway.Categories[series.ID]; got.Name != "Series" || got.Description != "shows" {
+		t.Errorf("expected only the description to change, got %+v", got)
+	}
+	if output.Succeeded != 3 || output.Failed != 2 || len(publisher.Events) != 3 {
+		t.Errorf("unexpected totals %+v with %d events", output, len(publisher.Events))
+	}
+}
+
+func TestBatchAtomicRollsBackOnFailure(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films", true)
+	gateway := newGateway(movies)
+	useCase, publisher := newUseCase(gateway, 10)
+
+	output, err := useCase.Execute(context.Background(), BatchCategoriesInput{
+		Atomic: true,
+		Operations: []Operation{
+			{Type: OperationDelete, ID: movies.ID.String()},
+			{Type: OperationCreate, Name: ptr("ab")},
+			{Type: OperationCreate, Name: ptr("Series")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ResultStatus{StatusRolledBack, StatusFailed, StatusSkipped}
+	if got := statuses(output); !equalStatuses(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if _, ok := gateway.Categories[movies.ID]; !ok || len(gateway.Categories) != 1 {
+		t.Error("expected the batch to be undone")
+	}
+	if len(publisher.Events) != 0 {
+		t.Errorf("expected no events, got %d", len(publisher.Events))
+	}
+}
+
+func TestBatchRejectsEmptyAndOversizedBatches(t *testing.T) {
+	useCase, _ := newUseCase(newGateway(), 1)
+
+	if _, err := useCase.Execute(context.Background(), BatchCategoriesInput{}); !errors.Is(err, ErrEmptyBatch) {
+		t.Errorf("expected ErrEmptyBatch, got %v", err)
+	}
+
+	ops := []Operation{{Type: OperationCreate, Name: ptr("Movies")}, {Type: OperationCreate, Name: ptr("Series")}}
+	if _, err := useCase.Execute(context.Background(), BatchCategoriesInput{Operations: ops}); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("expected ErrBatchTooLarge, got %v", err)
+	}
+}
+
+// chain returns categories nested one below the other, the first one being
+// the root.
+func chain(names ...string) []*category.Category {
+	var cats []*category.Category
+	for i, name := range names {
+		cat, _ := category.NewCategory(name, "", true)
+		if i > 0 {
+			cat.ParentID = &cats[i-1].ID
+		}
+		cats = append(cats, cat)
+	}
+	return cats
+}
+
+func TestBatchEnforcesHierarchyRules(t *testing.T) {
+	cats := chain("Movies", "Action", "Martial Arts", "Kung Fu", "Wuxia")
+	gateway := newGateway(cats...)
+	useCase, _ := newUseCase(gateway, 10)
+
+	output, err := useCase.Execute(context.Background(), BatchCategoriesInput{
+		Operations: []Operation{
+			{Type: OperationUpdate, ID: cats[0].ID.String(), ParentID: ptr(cats[3].ID.String())},
+			{Type: OperationCreate, Name: ptr("Classic Wuxia"), ParentID: ptr(cats[4].ID.String())},
+			{Type: OperationCreate, Name: ptr("Orphans"), ParentID: ptr(category.NewCategoryID().String())},
+			{Type: OperationDelete, ID: cats[3].ID.String()},
+			{Type: OperationUpdate, ID: cats[2].ID.String(), ParentID: ptr("")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ResultStatus{StatusFailed, StatusFailed, StatusFailed, StatusFailed, StatusUpdated}
+	if got := statuses(output); !equalStatuses(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	for i, wantErr := range []error{
+		category.ErrCategoryCycle,
+		category.ErrHierarchyTooDeep,
+		category.ErrParentNotFound,
+		category.ErrCategoryHasChildren,
+	} {
+		if !errors.Is(output.Results[i].Err, wantErr) {
+			t.Errorf("operation %d: expected %v, got %v", i, wantErr, output.Results[i].Err)
+		}
+	}
+
+	if got := gateway.Categories[cats[2].ID]; got.ParentID != nil {
+		t.Errorf("expected Martial Arts to become a root category, got parent %v", got.ParentID)
+	}
+}
+
+func TestBatchDeactivateCascadesToDescendants(t *testing.T) {
+	cats := chain("Movies", "Action", "Martial Arts")
+	cats[2].Deactivate()
+	gateway := newGateway(cats...)
+	useCase, publisher := newUseCase(gateway, 10)
+
+	output, err := useCase.Execute(context.Background(), BatchCategoriesInput{
+		Operations: []Operation{
+			{Type: OperationDeactivate, ID: cats[0].ID.String()},
+			{Type: OperationActivate, ID: cats[1].ID.String()},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ResultStatus{StatusUpdated, StatusFailed}
+	if got := statuses(output); !equalStatuses(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if !errors.Is(output.Results[1].Err, category.ErrParentInactive) {
+		t.Errorf("expected ErrParentInactive, got %v", output.Results[1].Err)
+	}
+
+	for _, cat := range cats {
+		if gateway.Categories[cat.ID].IsActive {
+			t.Errorf("expected %s to be inactive", cat.Name)
+		}
+	}
+
+	// Action was deactivated along with Movies; Martial Arts already was.
+	if len(publisher.Events) != 2 || publisher.Events[0].CategoryID != cats[0].ID || publisher.Events[1].CategoryID != cats[1].ID {
+		t.Fatalf("expected events for Movies then Action, got %+v", publisher.Events)
+	}
+	if cause := publisher.Events[1].CausedBy; cause == nil || *cause != cats[0].ID {
+		t.Errorf("expected Action's event to be caused by Movies, got %v", cause)
+	}
+}
+
+func TestBatchUpdateChangesSlug(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films", true)
+	series, _ := category.NewCategory("Series", "", true)
+	gateway := newGateway(movies, series)
+	useCase, _ := newUseCase(gateway, 10)
+
+	output, err := useCase.Execute(context.Background(), BatchCategoriesInput{
+		Operations: []Operation{
+			{Type: OperationUpdate, ID: movies.ID.String(), Slug: ptr("films")},
+			{Type: OperationUpdate, ID: series.ID.String(), Slug: ptr("films")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ResultStatus{StatusUpdated, StatusFailed}
+	if got := statuses(output); !equalStatuses(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := gateway.Categories[movies.ID].Slug; got != "films" {
+		t.Errorf("expected slug films, got %q", got)
+	}
+
+	var conflict *category.SlugConflictError
+	if !errors.As(output.Results[1].Err, &conflict) {
+		t.Errorf("expected a slug conflict, got %v", output.Results[1].Err)
+	}
+}
+
+func TestBatchRollsBackAFailedOperationOnItsOwn(t *testing.T) {
+	cats := chain("Movies", "Action")
+	gateway := newGateway(cats...)
+	gateway.FailUpdate = &cats[1].ID
+	useCase, publisher := newUseCase(gateway, 10)
+
+	output, err := useCase.Execute(context.Background(), BatchCategoriesInput{
+		Operations: []Operation{
+			{Type: OperationDeactivate, ID: cats[0].ID.String()},
+			{Type: OperationCreate, Name: ptr("Series")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ResultStatus{StatusFailed, StatusCreated}
+	if got := statuses(output); !equalStatuses(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if !gateway.Categories[cats[0].ID].IsActive {
+		t.Error("expected Movies to stay active when its children could not be deactivated")
+	}
+	if len(publisher.Events) != 1 || publisher.Events[0].Type != category.EventCategoryCreated {
+		t.Errorf("expected only the create to be published, got %+v", publisher.Events)
+	}
+}
@@ -2,30 +2,53 @@
 package create
 
 import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
 )
 
 type CreateCategoryUseCase struct {
-	Gateway category.CategoryGateway
+	Gateway         category.CategoryGateway
+	RevisionGateway category.CategoryRevisionGateway
+	Publisher       category.EventPublisher
+	Transactor      usecase.Transactor
 }
 
 type CreateCategoryInput struct {
 	Name        string
 	Description string
 	IsActive    bool
+	// Slug is generated from the name when empty.
+	Slug string
+	// ParentID places the category under another one; empty makes it a
+	// root category.
+	ParentID string
 }
 
 type CreateCategoryOutput struct {
 	ID string
 }
 
-func NewCreateCategoryUseCase(gateway category.CategoryGateway) *CreateCategoryUseCase {
+func NewCreateCategoryUseCase(
+	gateway category.CategoryGateway,
+	revisionGateway category.CategoryRevisionGateway,
+	publisher category.EventPublisher,
+	transactor usecase.Transactor,
+) *CreateCategoryUseCase {
 	return &CreateCategoryUseCase{
-		Gateway: gateway,
+		Gateway:         gateway,
+		RevisionGateway: revisionGateway,
+		Publisher:       publisher,
+		Transactor:      transactor,
 	}
 }
 
-func (uc *CreateCategoryUseCase) Execute(input CreateCategoryInput) (*CreateCategoryOutput, error) {
+func (uc *CreateCategoryUseCase) Execute(ctx context.Context, input CreateCategoryInput) (_ *CreateCategoryOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "create_category")
+	defer func() { end(err) }()
+
 	cat, err := category.NewCategory(
 		input.Name,
 		input.Description,
@@ -35,15 +58,52 @@ func (uc *CreateCategoryUseCase) Execute(input CreateCategoryInput) (*CreateCate
 		return nil, err
 	}
 
+	if input.Slug != "" {
+		cat.Slug = input.Slug
+	}
+
+	if cat.ParentID, err = category.ParseParentID(input.ParentID); err != nil {
+		return nil, err
+	}
+
 	if err := cat.Validate(); err != nil {
 		return nil, err
 	}
 
-	cat, err = uc.Gateway.CreateCategory(cat)
+	if err := category.EnsureNameAvailable(ctx, uc.Gateway, cat); err != nil {
+		return nil, err
+	}
+
+	if err := category.EnsureValidParent(ctx, uc.Gateway, cat); err != nil {
+		return nil, err
+	}
+
+	if input.Slug != "" {
+		err = category.EnsureSlugAvailable(ctx, uc.Gateway, cat)
+	} else {
+		err = category.AssignUniqueSlug(ctx, uc.Gateway, cat)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	// The category and its first revision are stored together, and the
+	// event is only published once both are.
+	var event category.CategoryEvent
+	err = uc.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if cat, err = uc.Gateway.CreateCategory(ctx, cat); err != nil {
+			return err
+		}
+		event = category.NewCategoryEvent(category.EventCategoryCreated, nil, cat).
+			WithMetadata(requestctx.Actor(ctx), requestctx.RequestID(ctx))
+		return category.RecordRevisions(ctx, uc.RevisionGateway, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.Publisher.Publish(event)
+
 	return &CreateCategoryOutput{
 		ID: cat.ID.String(),
 	}, nil
@@ -1,37 +1,93 @@
 package create
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
 )
 
 type CategoryGatewayMock struct {
-	CreateFn func(*category.Category) (*category.Category, error)
+	CreateFn     func(*category.Category) (*category.Category, error)
+	FindByNameFn func(string) (*category.Category, error)
+	FindBySlugFn func(string) (*category.Category, error)
 }
 
-func (m *CategoryGatewayMock) CreateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryGatewayMock) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return m.CreateFn(cat)
 }
 
-func (m *CategoryGatewayMock) GetCategoryByID(id category.CategoryID) (*category.Category, error) {
+func (m *CategoryGatewayMock) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryGatewayMock) UpdateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryGatewayMock) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryGatewayMock) DeleteCategory(id category.CategoryID) error {
+func (m *CategoryGatewayMock) DeleteCategory(ctx context.Context, id category.CategoryID) error {
 	return nil
 }
 
-func (m *CategoryGatewayMock) FindAll(query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+func (m *CategoryGatewayMock) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	if m.FindByNameFn == nil {
+		return nil, category.ErrCategoryNotFound
+	}
+	return m.FindByNameFn(name)
+}
+
+func (m *CategoryGatewayMock) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	if m.FindBySlugFn == nil {
+		return nil, category.ErrCategoryNotFound
+	}
+	return m.FindBySlugFn(slug)
+}
+
+func (m *CategoryGatewayMock) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	return nil, nil
+}
+
+func (m *CategoryGatewayMock) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	return nil, nil
+}
+
+type RevisionGatewayMock struct {
+	Appended []*category.CategoryRevision
+	Err      error
+}
+
+func (m *RevisionGatewayMock) AppendRevision(ctx context.Context, rev *category.CategoryRevision) (*category.CategoryRevision, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	m.Appended = append(m.Appended, rev)
+	return rev, nil
+}
+
+func (m *RevisionGatewayMock) GetRevision(ctx context.Context, id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (m *RevisionGatewayMock) GetRevisionAsOf(ctx context.Context, id category.CategoryID, at time.Time) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (m *RevisionGatewayMock) FindRevisions(ctx context.Context, query category.SearchRevisionQuery) (*pagination.Pagination[category.CategoryRevision], error) {
 	return nil, nil
 }
 
+type EventPublisherMock struct {
+	Events []category.CategoryEvent
+}
+
+func (m *EventPublisherMock) Publish(event category.CategoryEvent) {
+	m.Events = append(m.Events, event)
+}
+
 func TestCreateCategoryUseCaseExecute(t *testing.T) {
 	gateway := &CategoryGatewayMock{
 		CreateFn: func(cat *category.Category) (*category.Category, error) {
@@ -39,7 +95,9 @@ func TestCreateCategoryUseCaseExecute(t *testing.T) {
 		},
 	}
 
-	useCase := NewCreateCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewCreateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
 
 	input := CreateCategoryInput{
 		Name:        "Movies",
@@ -47,7 +105,7 @@ func TestCreateCategoryUseCaseExecute(t *testing.T) {
 		IsActive:    true,
 	}
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(context.Background(), input)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -59,6 +117,61 @@ func TestCreateCategoryUseCaseExecute(t *testing.T) {
 	if output.ID == "" {
 		t.Fatal("expected valid category ID")
 	}
+
+	if len(publisher.Events) != 1 {
+		t.Fatalf("expected 1 published event, got %d", len(publisher.Events))
+	}
+
+	if publisher.Events[0].Type != category.EventCategoryCreated {
+		t.Errorf("expected event type %s, got %s", category.EventCategoryCreated, publisher.Events[0].Type)
+	}
+
+	if publisher.Events[0].CategoryID.String() != output.ID {
+		t.Error("expected event to reference the created category")
+	}
+}
+
+func TestCreateCategoryUseCase_RecordsRevision(t *testing.T) {
+	gateway := &CategoryGatewayMock{
+		CreateFn: func(cat *category.Category) (*category.Category, error) {
+			return cat, nil
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+	revisions := &RevisionGatewayMock{}
+
+	_, err := NewCreateCategoryUseCase(gateway, revisions, publisher, usecase.NoTransaction{}).
+		Execute(context.Background(), CreateCategoryInput{Name: "Movies", IsActive: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(revisions.Appended) != 1 || revisions.Appended[0].EventID != publisher.Events[0].ID {
+		t.Errorf("expected a revision for the published event, got %+v", revisions.Appended)
+	}
+}
+
+func TestCreateCategoryUseCase_FailedRevisionPublishesNothing(t *testing.T) {
+	expectedErr := errors.New("database error")
+
+	gateway := &CategoryGatewayMock{
+		CreateFn: func(cat *category.Category) (*category.Category, error) {
+			return cat, nil
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+
+	_, err := NewCreateCategoryUseCase(gateway, &RevisionGatewayMock{Err: expectedErr}, publisher, usecase.NoTransaction{}).
+		Execute(context.Background(), CreateCategoryInput{Name: "Movies", IsActive: true})
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected %v, got %v", expectedErr, err)
+	}
+
+	if len(publisher.Events) != 0 {
+		t.Errorf("expected no events when the revision is not stored, got %d", len(publisher.Events))
+	}
 }
 
 func TestCreateCategoryUseCase_ValidationError(t *testing.T) {
@@ -68,7 +181,9 @@ func TestCreateCategoryUseCase_ValidationError(t *testing.T) {
 		},
 	}
 
-	useCase := NewCreateCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewCreateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
 
 	input := CreateCategoryInput{
 		Name:        "",
@@ -76,7 +191,7 @@ func TestCreateCategoryUseCase_ValidationError(t *testing.T) {
 		IsActive:    true,
 	}
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected validation error")
@@ -85,6 +200,10 @@ func TestCreateCategoryUseCase_ValidationError(t *testing.T) {
 	if output != nil {
 		t.Fatal("expected nil output on error")
 	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
 }
 
 func TestCreateCategoryUseCase_GatewayError(t *testing.T) {
@@ -96,7 +215,9 @@ func TestCreateCategoryUseCase_GatewayError(t *testing.T) {
 		},
 	}
 
-	useCase := NewCreateCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewCreateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
 
 	input := CreateCategoryInput{
 		Name:        "Movies",
@@ -104,7 +225,7 @@ func TestCreateCategoryUseCase_GatewayError(t *testing.T) {
 		IsActive:    true,
 	}
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected gateway error")
@@ -117,4 +238,78 @@ func TestCreateCategoryUseCase_GatewayError(t *testing.T) {
 	if output != nil {
 		t.Fatal("expected nil output on error")
 	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
+}
+
+func TestCreateCategoryUseCase_NameConflict(t *testing.T) {
+	existing, _ := category.NewCategory("Filmes", "", true)
+
+	gateway := &CategoryGatewayMock{
+		CreateFn: func(cat *category.Category) (*category.Category, error) {
+			t.Fatal("expected the category not to be created")
+			return nil, nil
+		},
+		FindByNameFn: func(name string) (*category.Category, error) {
+			if category.NormalizeName(name) == category.NormalizeName(existing.Name) {
+				return existing, nil
+			}
+			return nil, category.ErrCategoryNotFound
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+
+	useCase := NewCreateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
+
+	_, err := useCase.Execute(context.Background(), CreateCategoryInput{Name: "  FILMES ", IsActive: true})
+
+	var conflict *category.NameConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected a name conflict, got %v", err)
+	}
+
+	if conflict.ExistingID != existing.ID {
+		t.Errorf("expected existing ID %s, got %s", existing.ID, conflict.ExistingID)
+	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
+}
+
+func TestCreateCategoryUseCase_Slug(t *testing.T) {
+	taken, _ := category.NewCategory("Ação", "", true)
+
+	var created *category.Category
+	gateway := &CategoryGatewayMock{
+		CreateFn: func(cat *category.Category) (*category.Category, error) {
+			created = cat
+			return cat, nil
+		},
+		FindBySlugFn: func(slug string) (*category.Category, error) {
+			if slug == "acao" {
+				return taken, nil
+			}
+			return nil, category.ErrCategoryNotFound
+		},
+	}
+
+	useCase := NewCreateCategoryUseCase(gateway, &RevisionGatewayMock{}, &EventPublisherMock{}, usecase.NoTransaction{})
+
+	if _, err := useCase.Execute(context.Background(), CreateCategoryInput{Name: "Acão!", IsActive: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Slug != "acao-2" {
+		t.Errorf("expected a suffixed slug, got %q", created.Slug)
+	}
+
+	_, err := useCase.Execute(context.Background(), CreateCategoryInput{Name: "Adventure", Slug: "acao", IsActive: true})
+
+	var conflict *category.SlugConflictError
+	if !errors.As(err, &conflict) || conflict.ExistingID != taken.ID {
+		t.Errorf("expected a slug conflict with %s, got %v", taken.ID, err)
+	}
 }
@@ -1,27 +1,56 @@
 // Package delete provides use cases for deleting categories in the application.
 package delete
 
-import "github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
 
 type DeleteCategoryUseCase struct {
-	Gateway category.CategoryGateway
+	Gateway   category.CategoryGateway
+	Publisher category.EventPublisher
 }
 
 type DeleteCategoryInput struct {
 	ID string
 }
 
-func NewDeleteCategoryUseCase(gateway category.CategoryGateway) *DeleteCategoryUseCase {
+func NewDeleteCategoryUseCase(gateway category.CategoryGateway, publisher category.EventPublisher) *DeleteCategoryUseCase {
 	return &DeleteCategoryUseCase{
-		Gateway: gateway,
+		Gateway:   gateway,
+		Publisher: publisher,
 	}
 }
 
-func (uc *DeleteCategoryUseCase) Execute(input DeleteCategoryInput) error {
+func (uc *DeleteCategoryUseCase) Execute(ctx context.Context, input DeleteCategoryInput) (err error) {
+	ctx, end := usecase.Observe(ctx, "delete_category")
+	defer func() { end(err) }()
+
 	id, err := category.ParseCategoryID(input.ID)
 	if err != nil {
 		return err
 	}
 
-	return uc.Gateway.DeleteCategory(id)
+	existing, err := uc.Gateway.GetCategoryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := category.EnsureNoChildren(ctx, uc.Gateway, id); err != nil {
+		return err
+	}
+
+	if err := uc.Gateway.DeleteCategory(ctx, id); err != nil {
+		return err
+	}
+
+	uc.Publisher.Publish(
+		category.NewCategoryEvent(category.EventCategoryDeleted, existing, nil).
+			WithMetadata(requestctx.Actor(ctx), requestctx.RequestID(ctx)),
+	)
+
+	return nil
 }
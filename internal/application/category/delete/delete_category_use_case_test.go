@@ -1,37 +1,67 @@
 package delete
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
 )
 
 type CategoryGatewayMock struct {
-	DeleteFn func(category.CategoryID) error
+	GetByIDFn  func(category.CategoryID) (*category.Category, error)
+	DeleteFn   func(category.CategoryID) error
+	ChildrenFn func(category.CategoryID) ([]category.Category, error)
 }
 
-func (m *CategoryGatewayMock) CreateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryGatewayMock) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryGatewayMock) GetCategoryByID(id category.CategoryID) (*category.Category, error) {
-	return nil, nil
+func (m *CategoryGatewayMock) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
+	if m.GetByIDFn == nil {
+		return &category.Category{ID: id, Name: "Movies"}, nil
+	}
+	return m.GetByIDFn(id)
 }
 
-func (m *CategoryGatewayMock) UpdateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryGatewayMock) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryGatewayMock) DeleteCategory(id category.CategoryID) error {
+func (m *CategoryGatewayMock) DeleteCategory(ctx context.Context, id category.CategoryID) error {
 	return m.DeleteFn(id)
 }
 
-func (m *CategoryGatewayMock) FindAll(query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+func (m *CategoryGatewayMock) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	if m.ChildrenFn == nil {
+		return nil, nil
+	}
+	return m.ChildrenFn(parentID)
+}
+
+func (m *CategoryGatewayMock) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
 	return nil, nil
 }
 
+type EventPublisherMock struct {
+	Events []category.CategoryEvent
+}
+
+func (m *EventPublisherMock) Publish(event category.CategoryEvent) {
+	m.Events = append(m.Events, event)
+}
+
 func TestDeleteCategoryUseCaseExecute(t *testing.T) {
 	catID := category.NewCategoryID()
 
@@ -44,13 +74,17 @@ func TestDeleteCategoryUseCaseExecute(t *testing.T) {
 		},
 	}
 
-	useCase := NewDeleteCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewDeleteCategoryUseCase(gateway, publisher)
 
 	input := DeleteCategoryInput{
 		ID: catID.String(),
 	}
 
-	err := useCase.Execute(input)
+	ctx := requestctx.WithRequestID(requestctx.WithActor(context.Background(), "editor@example.com"), "req-1")
+
+	err := useCase.Execute(ctx, input)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -58,6 +92,52 @@ func TestDeleteCategoryUseCaseExecute(t *testing.T) {
 	if receivedID != catID {
 		t.Fatal("expected correct category ID to be passed to gateway")
 	}
+
+	if len(publisher.Events) != 1 {
+		t.Fatalf("expected 1 published event, got %d", len(publisher.Events))
+	}
+
+	event := publisher.Events[0]
+
+	if event.Type != category.EventCategoryDeleted {
+		t.Errorf("expected event type %s, got %s", category.EventCategoryDeleted, event.Type)
+	}
+
+	if event.Before == nil || event.Before.Name != "Movies" {
+		t.Error("expected event to carry the deleted category state")
+	}
+
+	if event.Actor != "editor@example.com" || event.RequestID != "req-1" {
+		t.Errorf("expected request metadata on event, got actor %q request %q", event.Actor, event.RequestID)
+	}
+}
+
+func TestDeleteCategoryUseCase_NotFound(t *testing.T) {
+	expectedErr := errors.New("not found")
+
+	gateway := &CategoryGatewayMock{
+		GetByIDFn: func(id category.CategoryID) (*category.Category, error) {
+			return nil, expectedErr
+		},
+		DeleteFn: func(id category.CategoryID) error {
+			t.Fatal("delete should not be called for a missing category")
+			return nil
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+
+	useCase := NewDeleteCategoryUseCase(gateway, publisher)
+
+	err := useCase.Execute(context.Background(), DeleteCategoryInput{ID: category.NewCategoryID().String()})
+
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
 }
 
 func TestDeleteCategoryUseCase_InvalidID(t *testing.T) {
@@ -67,13 +147,15 @@ func TestDeleteCategoryUseCase_InvalidID(t *testing.T) {
 		},
 	}
 
-	useCase := NewDeleteCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewDeleteCategoryUseCase(gateway, publisher)
 
 	input := DeleteCategoryInput{
 		ID: "invalid-uuid", // 😈
 	}
 
-	err := useCase.Execute(input)
+	err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected error for invalid UUID")
@@ -91,13 +173,15 @@ func TestDeleteCategoryUseCase_GatewayError(t *testing.T) {
 		},
 	}
 
-	useCase := NewDeleteCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewDeleteCategoryUseCase(gateway, publisher)
 
 	input := DeleteCategoryInput{
 		ID: catID.String(),
 	}
 
-	err := useCase.Execute(input)
+	err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected gateway error")
@@ -106,4 +190,36 @@ func TestDeleteCategoryUseCase_GatewayError(t *testing.T) {
 	if !errors.Is(err, expectedErr) {
 		t.Fatalf("unexpected error: %v", err)
 	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
+}
+
+func TestDeleteCategoryUseCase_HasChildren(t *testing.T) {
+	parentID := category.NewCategoryID()
+	deleted := false
+
+	gateway := &CategoryGatewayMock{
+		DeleteFn: func(id category.CategoryID) error {
+			deleted = true
+			return nil
+		},
+		ChildrenFn: func(id category.CategoryID) ([]category.Category, error) {
+			return []category.Category{{ID: category.NewCategoryID(), Name: "Action", ParentID: &parentID}}, nil
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+
+	useCase := NewDeleteCategoryUseCase(gateway, publisher)
+
+	err := useCase.Execute(context.Background(), DeleteCategoryInput{ID: parentID.String()})
+	if !errors.Is(err, category.ErrCategoryHasChildren) {
+		t.Fatalf("expected ErrCategoryHasChildren, got %v", err)
+	}
+
+	if deleted || len(publisher.Events) != 0 {
+		t.Error("expected the category to be kept")
+	}
 }
@@ -0,0 +1,42 @@
+// Package export provides the use case for exporting the category catalog.
+package export
+
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type ExportCategoriesUseCase struct {
+	Streamer category.CategoryStreamer
+}
+
+// ExportCategoriesInput takes the filters of retrive.ListCategoriesInput;
+// an export is never paginated.
+type ExportCategoriesInput struct {
+	Terms     string
+	Sort      string
+	Direction string
+}
+
+func NewExportCategoriesUseCase(streamer category.CategoryStreamer) *ExportCategoriesUseCase {
+	return &ExportCategoriesUseCase{
+		Streamer: streamer,
+	}
+}
+
+// Execute hands every matching category to visit, stopping at the first
+// error visit returns.
+func (uc *ExportCategoriesUseCase) Execute(ctx context.Context, input ExportCategoriesInput, visit func(category.Category) error) (err error) {
+	ctx, end := usecase.Observe(ctx, "export_categories")
+	defer func() { end(err) }()
+
+	query := category.SearchCategoryQuery{
+		Terms:     input.Terms,
+		Sort:      input.Sort,
+		Direction: input.Direction,
+	}
+
+	return uc.Streamer.StreamCategories(ctx, query, visit)
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type CategoryStreamerMock struct {
+	Categories []category.Category
+	Query      category.SearchCategoryQuery
+}
+
+func (m *CategoryStreamerMock) StreamCategories(ctx context.Context, query category.SearchCategoryQuery, visit func(category.Category) error) error {
+	m.Query = query
+	for _, cat := range m.Categories {
+		if err := visit(cat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportCategoriesUseCasePassesFilters(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "", true)
+	series, _ := category.NewCategory("Series", "", true)
+	streamer := &CategoryStreamerMock{Categories: []category.Category{*movies, *series}}
+
+	var names []string
+	err := NewExportCategoriesUseCase(streamer).Execute(
+		context.Background(),
+		ExportCategoriesInput{Terms: "s", Sort: "name", Direction: "desc"},
+		func(cat category.Category) error {
+			names = append(names, cat.Name)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := category.SearchCategoryQuery{Terms: "s", Sort: "name", Direction: "desc"}
+	if streamer.Query != want {
+		t.Errorf("expected query %+v, got %+v", want, streamer.Query)
+	}
+	if len(names) != 2 || names[0] != "Movies" {
+		t.Errorf("unexpected categories %v", names)
+	}
+}
+
+func TestExportCategoriesUseCaseStopsOnVisitError(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "", true)
+	series, _ := category.NewCategory("Series", "", true)
+	streamer := &CategoryStreamerMock{Categories: []category.Category{*movies, *series}}
+
+	failure := errors.New("client went away")
+	visited := 0
+
+	err := NewExportCategoriesUseCase(streamer).Execute(context.Background(), ExportCategoriesInput{}, func(category.Category) error {
+		visited++
+		return failure
+	})
+
+	if !errors.Is(err, failure) || visited != 1 {
+		t.Errorf("expected to stop after the first error, got %v after %d", err, visited)
+	}
+}
@@ -0,0 +1,48 @@
+package hierarchy
+
+import (
+	"context"
+	"slices"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type GetAncestorsUseCase struct {
+	Gateway category.CategoryGateway
+}
+
+type GetAncestorsInput struct {
+	ID string
+}
+
+func NewGetAncestorsUseCase(gateway category.CategoryGateway) *GetAncestorsUseCase {
+	return &GetAncestorsUseCase{
+		Gateway: gateway,
+	}
+}
+
+// Execute returns the categories above the given one as a breadcrumb: the
+// root first and the direct parent last. A root category has none.
+func (uc *GetAncestorsUseCase) Execute(ctx context.Context, input GetAncestorsInput) (_ []category.Category, err error) {
+	ctx, end := usecase.Observe(ctx, "get_category_ancestors")
+	defer func() { end(err) }()
+
+	id, err := category.ParseCategoryID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cat, err := uc.Gateway.GetCategoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := category.Ancestors(ctx, uc.Gateway, cat)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(ancestors)
+	return ancestors, nil
+}
@@ -0,0 +1,42 @@
+// Package hierarchy provides use cases for reading the category tree.
+package hierarchy
+
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type GetChildrenUseCase struct {
+	Gateway category.CategoryGateway
+}
+
+type GetChildrenInput struct {
+	ID string
+}
+
+func NewGetChildrenUseCase(gateway category.CategoryGateway) *GetChildrenUseCase {
+	return &GetChildrenUseCase{
+		Gateway: gateway,
+	}
+}
+
+// Execute returns the categories directly below the given one, ordered by
+// name. It fails with category.ErrCategoryNotFound rather than returning no
+// children for a category that does not exist.
+func (uc *GetChildrenUseCase) Execute(ctx context.Context, input GetChildrenInput) (_ []category.Category, err error) {
+	ctx, end := usecase.Observe(ctx, "get_category_children")
+	defer func() { end(err) }()
+
+	id, err := category.ParseCategoryID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.Gateway.GetCategoryByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return uc.Gateway.FindChildren(ctx, id)
+}
@@ -0,0 +1,60 @@
+package hierarchy
+
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type GetTreeUseCase struct {
+	Streamer category.CategoryStreamer
+}
+
+// TreeNode is a category with the categories directly below it.
+type TreeNode struct {
+	category.Category
+	Children []*TreeNode
+}
+
+func NewGetTreeUseCase(streamer category.CategoryStreamer) *GetTreeUseCase {
+	return &GetTreeUseCase{
+		Streamer: streamer,
+	}
+}
+
+// Execute loads every category in one pass and returns the root categories
+// with their descendants, siblings ordered by name. A category whose parent
+// is missing is returned as a root, so nothing is left out of the tree.
+func (uc *GetTreeUseCase) Execute(ctx context.Context) (_ []*TreeNode, err error) {
+	ctx, end := usecase.Observe(ctx, "get_category_tree")
+	defer func() { end(err) }()
+
+	var nodes []*TreeNode
+	byID := map[category.CategoryID]*TreeNode{}
+
+	err = uc.Streamer.StreamCategories(ctx, category.SearchCategoryQuery{Sort: "name"}, func(cat category.Category) error {
+		node := &TreeNode{Category: cat, Children: []*TreeNode{}}
+		nodes = append(nodes, node)
+		byID[cat.ID] = node
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	roots := []*TreeNode{}
+	for _, node := range nodes {
+		var parent *TreeNode
+		if node.ParentID != nil {
+			parent = byID[*node.ParentID]
+		}
+		if parent != nil {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	return roots, nil
+}
@@ -0,0 +1,147 @@
+package hierarchy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+// CategoryGatewayMock keeps categories in insertion order and also streams
+// them.
+type CategoryGatewayMock struct {
+	Categories []category.Category
+}
+
+func newGateway(cats ...*category.Category) *CategoryGatewayMock {
+	m := &CategoryGatewayMock{}
+	for _, cat := range cats {
+		m.Categories = append(m.Categories, *cat)
+	}
+	return m
+}
+
+func (m *CategoryGatewayMock) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	return nil, nil
+}
+
+func (m *CategoryGatewayMock) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
+	for _, cat := range m.Categories {
+		if cat.ID == id {
+			return &cat, nil
+		}
+	}
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	return nil, nil
+}
+
+func (m *CategoryGatewayMock) DeleteCategory(ctx context.Context, id category.CategoryID) error {
+	return nil
+}
+
+func (m *CategoryGatewayMock) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	var children []category.Category
+	for _, cat := range m.Categories {
+		if cat.ParentID != nil && *cat.ParentID == parentID {
+			children = append(children, cat)
+		}
+	}
+	return children, nil
+}
+
+func (m *CategoryGatewayMock) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	return nil, nil
+}
+
+func (m *CategoryGatewayMock) StreamCategories(ctx context.Context, query category.SearchCategoryQuery, visit func(category.Category) error) error {
+	for _, cat := range m.Categories {
+		if err := visit(cat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newChild(name string, parent *category.Category) *category.Category {
+	cat, _ := category.NewCategory(name, "", true)
+	cat.ParentID = &parent.ID
+	return cat
+}
+
+func TestGetAncestorsUseCaseReturnsBreadcrumb(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "", true)
+	action := newChild("Action", movies)
+	martialArts := newChild("Martial Arts", action)
+	gateway := newGateway(movies, action, martialArts)
+
+	ancestors, err := NewGetAncestorsUseCase(gateway).Execute(context.Background(), GetAncestorsInput{ID: martialArts.ID.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ancestors) != 2 || ancestors[0].ID != movies.ID || ancestors[1].ID != action.ID {
+		t.Errorf("expected Movies then Action, got %+v", ancestors)
+	}
+
+	ancestors, err = NewGetAncestorsUseCase(gateway).Execute(context.Background(), GetAncestorsInput{ID: movies.ID.String()})
+	if err != nil || len(ancestors) != 0 {
+		t.Errorf("expected no ancestors for a root category, got %+v, %v", ancestors, err)
+	}
+}
+
+func TestGetChildrenUseCaseNeedsExistingCategory(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "", true)
+	action := newChild("Action", movies)
+	gateway := newGateway(movies, action)
+
+	children, err := NewGetChildrenUseCase(gateway).Execute(context.Background(), GetChildrenInput{ID: movies.ID.String()})
+	if err != nil || len(children) != 1 || children[0].ID != action.ID {
+		t.Errorf("expected Action, got %+v, %v", children, err)
+	}
+
+	_, err = NewGetChildrenUseCase(gateway).Execute(context.Background(), GetChildrenInput{ID: category.NewCategoryID().String()})
+	if !errors.Is(err, category.ErrCategoryNotFound) {
+		t.Errorf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestGetTreeUseCaseNestsCategories(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "", true)
+	series, _ := category.NewCategory("Series", "", true)
+	action := newChild("Action", movies)
+	martialArts := newChild("Martial Arts", action)
+	missing, _ := category.NewCategory("Missing", "", true)
+	orphan := newChild("Orphan", missing)
+
+	// Children may come before their parents in the stream.
+	gateway := newGateway(martialArts, action, movies, orphan, series)
+
+	roots, err := NewGetTreeUseCase(gateway).Execute(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(roots) != 3 || roots[0].ID != movies.ID || roots[1].ID != orphan.ID || roots[2].ID != series.ID {
+		t.Fatalf("expected Movies, Orphan and Series as roots, got %+v", roots)
+	}
+
+	actionNode := roots[0].Children
+	if len(actionNode) != 1 || actionNode[0].ID != action.ID {
+		t.Fatalf("expected Action below Movies, got %+v", actionNode)
+	}
+	if leaves := actionNode[0].Children; len(leaves) != 1 || leaves[0].ID != martialArts.ID || len(leaves[0].Children) != 0 {
+		t.Errorf("expected Martial Arts below Action, got %+v", leaves)
+	}
+}
@@ -0,0 +1,239 @@
+// Package importing provides the use case for loading many categories at once.
+package importing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type Mode string
+
+const (
+	// ModeAllOrNothing creates no category unless every row is valid, and
+	// writes them in one transaction.
+	ModeAllOrNothing Mode = "all_or_nothing"
+	// ModeBestEffort creates every valid row and reports the others.
+	ModeBestEffort Mode = "best_effort"
+)
+
+type RowStatus string
+
+const (
+	RowCreated RowStatus = "created"
+	RowSkipped RowStatus = "skipped"
+	RowError   RowStatus = "error"
+)
+
+var ErrUnknownMode = errors.New("unknown import mode")
+
+var ErrTooManyRows = errors.New("too many rows to import")
+
+type ImportCategoriesUseCase struct {
+	Gateway         category.CategoryGateway
+	RevisionGateway category.CategoryRevisionGateway
+	Publisher       category.EventPublisher
+	Transactor      usecase.Transactor
+	// MaxRows caps the rows of one import; zero means no limit.
+	MaxRows int
+}
+
+// Row is one category to import. Err reports a row the caller could not
+// decode; it is reported as an error without being validated.
+type Row struct {
+	Line        int
+	Name        string
+	Description string
+	IsActive    bool
+	Err         error
+}
+
+type ImportCategoriesInput struct {
+	Mode Mode
+	Rows []Row
+}
+
+type RowResult struct {
+	Line   int       `json:"line"`
+	Name   string    `json:"name"`
+	Status RowStatus `json:"status"`
+	ID     string    `json:"id,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+type ImportCategoriesOutput struct {
+	Mode    Mode        `json:"mode"`
+	Created int         `json:"created"`
+	Skipped int         `json:"skipped"`
+	Failed  int         `json:"failed"`
+	Rows    []RowResult `json:"rows"`
+}
+
+func NewImportCategoriesUseCase(
+	gateway category.CategoryGateway,
+	revisionGateway category.CategoryRevisionGateway,
+	publisher category.EventPublisher,
+	transactor usecase.Transactor,
+	maxRows int,
+) *ImportCategoriesUseCase {
+	return &ImportCategoriesUseCase{
+		Gateway:         gateway,
+		RevisionGateway: revisionGateway,
+		Publisher:       publisher,
+		Transactor:      transactor,
+		MaxRows:         maxRows,
+	}
+}
+
+// Execute validates every row with Category.Validate and skips rows whose
+// name, ignoring case and surrounding spaces, already exists or appears
+// earlier in the input. Events are published only for categories that were
+// stored.
+func (uc *ImportCategoriesUseCase) Execute(ctx context.Context, input ImportCategoriesInput) (_ *ImportCategoriesOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "import_categories")
+	defer func() { end(err) }()
+
+	if input.Mode != ModeAllOrNothing && input.Mode != ModeBestEffort {
+		return nil, fmt.Errorf("%w %q", ErrUnknownMode, input.Mode)
+	}
+	if uc.MaxRows > 0 && len(input.Rows) > uc.MaxRows {
+		return nil, fmt.Errorf("%w: %d rows, at most %d allowed", ErrTooManyRows, len(input.Rows), uc.MaxRows)
+	}
+
+	results := make([]RowResult, len(input.Rows))
+	pending := make([]*category.Category, len(input.Rows))
+	firstLine := map[string]int{}
+	invalid := false
+
+	for i, row := range input.Rows {
+		results[i] = RowResult{Line: row.Line, Name: row.Name}
+
+		if row.Err != nil {
+			results[i].Status, results[i].Reason = RowError, row.Err.Error()
+			invalid = true
+			continue
+		}
+
+		cat, err := category.NewCategory(row.Name, row.Description, row.IsActive)
+		if err == nil {
+			err = cat.Validate()
+		}
+		if err != nil {
+			results[i].Status, results[i].Reason = RowError, err.Error()
+			invalid = true
+			continue
+		}
+
+		key := category.NormalizeName(row.Name)
+		if line, seen := firstLine[key]; seen {
+			results[i].Status, results[i].Reason = RowSkipped, fmt.Sprintf("duplicate of line %d", line)
+			continue
+		}
+		firstLine[key] = row.Line
+
+		existing, err := uc.Gateway.FindCategoryByName(ctx, strings.TrimSpace(row.Name))
+		if err == nil {
+			results[i].Status, results[i].Reason = RowSkipped, "category already exists"
+			results[i].ID = existing.ID.String()
+			continue
+		}
+		if !errors.Is(err, category.ErrCategoryNotFound) {
+			return nil, err
+		}
+
+		pending[i] = cat
+	}
+
+	var created []category.CategoryEvent
+
+	switch {
+	case input.Mode == ModeAllOrNothing && invalid:
+		markPending(results, pending, "not imported: other rows have errors")
+	case input.Mode == ModeAllOrNothing:
+		err := uc.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			for i, cat := range pending {
+				if cat == nil {
+					continue
+				}
+				event, err := uc.create(ctx, cat)
+				if err != nil {
+					results[i].Status, results[i].Reason = RowError, err.Error()
+					pending[i] = nil
+					return err
+				}
+				results[i].Status, results[i].ID = RowCreated, cat.ID.String()
+				created = append(created, event)
+			}
+			return nil
+		})
+		if err != nil {
+			created = nil
+			markPending(results, pending, "not imported: the import was rolled back")
+		}
+	default:
+		for i, cat := range pending {
+			if cat == nil {
+				continue
+			}
+			var event category.CategoryEvent
+			err := uc.Transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+				event, err = uc.create(ctx, cat)
+				return err
+			})
+			if err != nil {
+				results[i].Status, results[i].Reason = RowError, err.Error()
+				continue
+			}
+			results[i].Status, results[i].ID = RowCreated, cat.ID.String()
+			created = append(created, event)
+		}
+	}
+
+	for _, event := range created {
+		uc.Publisher.Publish(event)
+	}
+
+	output := &ImportCategoriesOutput{Mode: input.Mode, Rows: results}
+	for _, result := range results {
+		switch result.Status {
+		case RowCreated:
+			output.Created++
+		case RowSkipped:
+			output.Skipped++
+		case RowError:
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
+
+// markPending reports the rows that were valid but not stored as skipped.
+func markPending(results []RowResult, pending []*category.Category, reason string) {
+	for i, cat := range pending {
+		if cat != nil {
+			results[i].Status, results[i].Reason, results[i].ID = RowSkipped, reason, ""
+		}
+	}
+}
+
+// create gives cat a free slug when it is written, so rows written earlier
+// in the same import are taken into account, and stores its first revision.
+// The event is returned to be published once the transaction commits.
+func (uc *ImportCategoriesUseCase) create(ctx context.Context, cat *category.Category) (category.CategoryEvent, error) {
+	if err := category.AssignUniqueSlug(ctx, uc.Gateway, cat); err != nil {
+		return category.CategoryEvent{}, err
+	}
+	if _, err := uc.Gateway.CreateCategory(ctx, cat); err != nil {
+		return category.CategoryEvent{}, err
+	}
+
+	event := category.NewCategoryEvent(category.EventCategoryCreated, nil, cat).
+		WithMetadata(requestctx.Actor(ctx), requestctx.RequestID(ctx))
+	return event, category.RecordRevisions(ctx, uc.RevisionGateway, event)
+}
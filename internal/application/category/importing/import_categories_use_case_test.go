@@ -0,0 +1,243 @@
+package importing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type CategoryGatewayMock struct {
+	Existing map[string]*category.Category
+	Created  []*category.Category
+	CreateFn func(*category.Category) error
+}
+
+func (m *CategoryGatewayMock) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	if m.CreateFn != nil {
+		if err := m.CreateFn(cat); err != nil {
+			return nil, err
+		}
+	}
+	m.Created = append(m.Created, cat)
+	return cat, nil
+}
+
+func (m *CategoryGatewayMock) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	return cat, nil
+}
+
+func (m *CategoryGatewayMock) DeleteCategory(ctx context.Context, id category.CategoryID) error {
+	return nil
+}
+
+func (m *CategoryGatewayMock) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	if cat, ok := m.Existing[strings.ToLower(name)]; ok {
+		return cat, nil
+	}
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	return nil, nil
+}
+
+func (m *CategoryGatewayMock) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	return nil, nil
+}
+
+type RevisionGatewayMock struct {
+	Appended []*category.CategoryRevision
+	Err      error
+}
+
+func (m *RevisionGatewayMock) AppendRevision(ctx context.Context, rev *category.CategoryRevision) (*category.CategoryRevision, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	m.Appended = append(m.Appended, rev)
+	return rev, nil
+}
+
+func (m *RevisionGatewayMock) GetRevision(ctx context.Context, id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (m *RevisionGatewayMock) GetRevisionAsOf(ctx context.Context, id category.CategoryID, at time.Time) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (m *RevisionGatewayMock) FindRevisions(ctx context.Context, query category.SearchRevisionQuery) (*pagination.Pagination[category.CategoryRevision], error) {
+	return nil, nil
+}
+
+type EventPublisherMock struct {
+	Events []category.CategoryEvent
+}
+
+func (m *EventPublisherMock) Publish(event category.CategoryEvent) {
+	m.Events = append(m.Events, event)
+}
+
+// TransactorMock discards the gateway's writes when fn fails, as a rolled
+// back transaction would.
+type TransactorMock struct {
+	Gateway    *CategoryGatewayMock
+	RolledBack bool
+}
+
+func (m *TransactorMock) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	before := len(m.Gateway.Created)
+	if err := fn(ctx); err != nil {
+		m.Gateway.Created = m.Gateway.Created[:before]
+		m.RolledBack = true
+		return err
+	}
+	return nil
+}
+
+func newUseCase(gateway *CategoryGatewayMock) (*ImportCategoriesUseCase, *EventPublisherMock, *TransactorMock) {
+	publisher := &EventPublisherMock{}
+	transactor := &TransactorMock{Gateway: gateway}
+	return NewImportCategoriesUseCase(gateway, &RevisionGatewayMock{}, publisher, transactor, 0), publisher, transactor
+}
+
+func statuses(output *ImportCategoriesOutput) []RowStatus {
+	var got []RowStatus
+	for _, row := range output.Rows {
+		got = append(got, row.Status)
+	}
+	return got
+}
+
+func equalStatuses(a, b []RowStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestImportBestEffortReportsEveryRow(t *testing.T) {
+	existing, _ := category.NewCategory("Series", "", true)
+	gateway := &CategoryGatewayMock{Existing: map[string]*category.Category{"series": existing}}
+	useCase, publisher, _ := newUseCase(gateway)
+
+	output, err := useCase.Execute(context.Background(), ImportCategoriesInput{
+		Mode: ModeBestEffort,
+		Rows: []Row{
+			{Line: 2, Name: "Movies", IsActive: true},
+			{Line: 3, Name: "ab", IsActive: true},
+			{Line: 4, Name: " movies ", IsActive: true},
+			{Line: 5, Name: "series", IsActive: true},
+			{Line: 6, Err: errors.New("is_active: invalid boolean")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []RowStatus{RowCreated, RowError, RowSkipped, RowSkipped, RowError}
+	if got := statuses(output); !equalStatuses(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if output.Rows[2].Reason != "duplicate of line 2" {
+		t.Errorf("unexpected reason %q", output.Rows[2].Reason)
+	}
+	if output.Rows[3].ID != existing.ID.String() {
+		t.Errorf("expected the existing category's ID, got %q", output.Rows[3].ID)
+	}
+	if output.Created != 1 || output.Skipped != 2 || output.Failed != 2 {
+		t.Errorf("unexpected totals %+v", output)
+	}
+	if len(publisher.Events) != 1 {
+		t.Errorf("expected 1 event, got %d", len(publisher.Events))
+	}
+}
+
+func TestImportAllOrNothingCreatesNothingWhenARowIsInvalid(t *testing.T) {
+	gateway := &CategoryGatewayMock{}
+	useCase, publisher, _ := newUseCase(gateway)
+
+	output, err := useCase.Execute(context.Background(), ImportCategoriesInput{
+		Mode: ModeAllOrNothing,
+		Rows: []Row{
+			{Line: 2, Name: "Movies", IsActive: true},
+			{Line: 3, Name: "", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(gateway.Created) != 0 || len(publisher.Events) != 0 {
+		t.Fatal("expected nothing to be created")
+	}
+	if got := statuses(output); !equalStatuses(got, []RowStatus{RowSkipped, RowError}) {
+		t.Errorf("unexpected statuses %v", got)
+	}
+}
+
+func TestImportAllOrNothingRollsBackOnWriteFailure(t *testing.T) {
+	gateway := &CategoryGatewayMock{
+		CreateFn: func(cat *category.Category) error {
+			if cat.Name == "Series" {
+				return errors.New("connection lost")
+			}
+			return nil
+		},
+	}
+	useCase, publisher, transactor := newUseCase(gateway)
+
+	output, err := useCase.Execute(context.Background(), ImportCategoriesInput{
+		Mode: ModeAllOrNothing,
+		Rows: []Row{
+			{Line: 2, Name: "Movies", IsActive: true},
+			{Line: 3, Name: "Series", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !transactor.RolledBack || len(gateway.Created) != 0 || len(publisher.Events) != 0 {
+		t.Fatal("expected the import to be rolled back")
+	}
+	if got := statuses(output); !equalStatuses(got, []RowStatus{RowSkipped, RowError}) {
+		t.Errorf("unexpected statuses %v", got)
+	}
+	if output.Rows[0].ID != "" {
+		t.Error("expected no ID for a rolled back row")
+	}
+}
+
+func TestImportRejectsTooManyRowsAndUnknownMode(t *testing.T) {
+	useCase, _, _ := newUseCase(&CategoryGatewayMock{})
+	useCase.MaxRows = 1
+
+	rows := []Row{{Line: 2, Name: "Movies"}, {Line: 3, Name: "Series"}}
+
+	if _, err := useCase.Execute(context.Background(), ImportCategoriesInput{Mode: ModeBestEffort, Rows: rows}); !errors.Is(err, ErrTooManyRows) {
+		t.Errorf("expected ErrTooManyRows, got %v", err)
+	}
+	if _, err := useCase.Execute(context.Background(), ImportCategoriesInput{Mode: "sometimes", Rows: rows[:1]}); !errors.Is(err, ErrUnknownMode) {
+		t.Errorf("expected ErrUnknownMode, got %v", err)
+	}
+}
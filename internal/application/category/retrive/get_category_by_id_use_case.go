@@ -1,7 +1,12 @@
 // Package retrive provides use cases for retrieving category information by ID.
 package retrive
 
-import "github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
 
 type GetCategoryByIDUseCase struct {
 	Gateway category.CategoryGateway
@@ -17,11 +22,14 @@ func NewGetCategoryByIDUseCase(gateway category.CategoryGateway) *GetCategoryByI
 	}
 }
 
-func (uc *GetCategoryByIDUseCase) Execute(input GetCategoryByIDInput) (*category.Category, error) {
+func (uc *GetCategoryByIDUseCase) Execute(ctx context.Context, input GetCategoryByIDInput) (_ *category.Category, err error) {
+	ctx, end := usecase.Observe(ctx, "get_category_by_id")
+	defer func() { end(err) }()
+
 	id, err := category.ParseCategoryID(input.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return uc.Gateway.GetCategoryByID(id)
+	return uc.Gateway.GetCategoryByID(ctx, id)
 }
@@ -2,6 +2,7 @@
 package retrive
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -13,23 +14,35 @@ type CategoryGatewayMock struct {
 	GetByIDFn func(category.CategoryID) (*category.Category, error)
 }
 
-func (m *CategoryGatewayMock) CreateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryGatewayMock) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryGatewayMock) GetCategoryByID(id category.CategoryID) (*category.Category, error) {
+func (m *CategoryGatewayMock) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
 	return m.GetByIDFn(id)
 }
 
-func (m *CategoryGatewayMock) UpdateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryGatewayMock) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryGatewayMock) DeleteCategory(id category.CategoryID) error {
+func (m *CategoryGatewayMock) DeleteCategory(ctx context.Context, id category.CategoryID) error {
 	return nil
 }
 
-func (m *CategoryGatewayMock) FindAll(query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+func (m *CategoryGatewayMock) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	return nil, nil
+}
+
+func (m *CategoryGatewayMock) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
 	return nil, nil
 }
 
@@ -55,7 +68,7 @@ func TestGetCategoryByIDUseCase_Execute(t *testing.T) {
 		ID: expectedCategory.ID.String(),
 	}
 
-	result, err := useCase.Execute(input)
+	result, err := useCase.Execute(context.Background(), input)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -78,7 +91,7 @@ func TestGetCategoryByIDUseCase_InvalidID(t *testing.T) {
 		ID: "invalid-uuid",
 	}
 
-	result, err := useCase.Execute(input)
+	result, err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected error")
@@ -106,7 +119,7 @@ func TestGetCategoryByIDUseCase_GatewayError(t *testing.T) {
 		ID: catID.String(),
 	}
 
-	result, err := useCase.Execute(input)
+	result, err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected gateway error")
@@ -0,0 +1,31 @@
+package retrive
+
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type GetCategoryBySlugUseCase struct {
+	Gateway category.CategoryGateway
+}
+
+type GetCategoryBySlugInput struct {
+	Slug string
+}
+
+func NewGetCategoryBySlugUseCase(gateway category.CategoryGateway) *GetCategoryBySlugUseCase {
+	return &GetCategoryBySlugUseCase{
+		Gateway: gateway,
+	}
+}
+
+// Execute resolves current slugs and aliases alike; a category whose Slug
+// differs from the input was found through an alias.
+func (uc *GetCategoryBySlugUseCase) Execute(ctx context.Context, input GetCategoryBySlugInput) (_ *category.Category, err error) {
+	ctx, end := usecase.Observe(ctx, "get_category_by_slug")
+	defer func() { end(err) }()
+
+	return uc.Gateway.FindCategoryBySlug(ctx, input.Slug)
+}
@@ -1,6 +1,9 @@
 package retrive
 
 import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
 )
@@ -23,7 +26,10 @@ func NewListCategoriesUseCase(gateway category.CategoryGateway) *ListCategoriesU
 	}
 }
 
-func (uc *ListCategoriesUseCase) Execute(input ListCategoriesInput) (*pagination.Pagination[category.Category], error) {
+func (uc *ListCategoriesUseCase) Execute(ctx context.Context, input ListCategoriesInput) (_ *pagination.Pagination[category.Category], err error) {
+	ctx, end := usecase.Observe(ctx, "list_categories")
+	defer func() { end(err) }()
+
 	query := category.SearchCategoryQuery{
 		Page:      input.Page,
 		PerPage:   input.PerPage,
@@ -32,5 +38,5 @@ func (uc *ListCategoriesUseCase) Execute(input ListCategoriesInput) (*pagination
 		Direction: input.Direction,
 	}
 
-	return uc.Gateway.FindAll(query)
+	return uc.Gateway.FindAll(ctx, query)
 }
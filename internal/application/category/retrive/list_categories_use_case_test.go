@@ -1,6 +1,7 @@
 package retrive
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -12,23 +13,35 @@ type CategoryListGatewayMock struct {
 	FindAllFn func(category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error)
 }
 
-func (m *CategoryListGatewayMock) CreateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryListGatewayMock) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryListGatewayMock) GetCategoryByID(id category.CategoryID) (*category.Category, error) {
+func (m *CategoryListGatewayMock) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryListGatewayMock) UpdateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryListGatewayMock) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryListGatewayMock) DeleteCategory(id category.CategoryID) error {
+func (m *CategoryListGatewayMock) DeleteCategory(ctx context.Context, id category.CategoryID) error {
 	return nil
 }
 
-func (m *CategoryListGatewayMock) FindAll(query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+func (m *CategoryListGatewayMock) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryListGatewayMock) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryListGatewayMock) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	return nil, nil
+}
+
+func (m *CategoryListGatewayMock) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
 	return m.FindAllFn(query)
 }
 
@@ -64,7 +77,7 @@ func TestListCategoriesUseCase_Execute(t *testing.T) {
 		Direction: "asc",
 	}
 
-	result, err := useCase.Execute(input)
+	result, err := useCase.Execute(context.Background(), input)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -114,7 +127,7 @@ func TestListCategoriesUseCase_GatewayError(t *testing.T) {
 		PerPage: 10,
 	}
 
-	result, err := useCase.Execute(input)
+	result, err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected gateway error")
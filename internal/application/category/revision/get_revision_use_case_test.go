@@ -0,0 +1,91 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+func TestGetRevisionUseCase_ByNumber(t *testing.T) {
+	catID := category.NewCategoryID()
+	parentID := category.NewCategoryID()
+
+	revisions := &RevisionGatewayMock{
+		GetFn: func(id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+			return &category.CategoryRevision{CategoryID: id, Revision: revision, Name: "Movies", Slug: "movies", ParentID: &parentID}, nil
+		},
+	}
+
+	useCase := NewGetRevisionUseCase(revisions)
+
+	output, err := useCase.Execute(context.Background(), GetRevisionInput{CategoryID: catID.String(), Revision: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.Revision != 3 || output.CategoryID != catID.String() {
+		t.Errorf("unexpected output: %+v", output)
+	}
+
+	if output.Slug != "movies" || output.ParentID == nil || *output.ParentID != parentID.String() {
+		t.Errorf("expected the revision's slug and parent, got %+v", output)
+	}
+}
+
+func TestGetRevisionUseCase_AsOfTimestamp(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var receivedAt time.Time
+
+	revisions := &RevisionGatewayMock{
+		GetAsOfFn: func(id category.CategoryID, t time.Time) (*category.CategoryRevision, error) {
+			receivedAt = t
+			return &category.CategoryRevision{CategoryID: id, Revision: 2}, nil
+		},
+	}
+
+	useCase := NewGetRevisionUseCase(revisions)
+
+	output, err := useCase.Execute(context.Background(), GetRevisionInput{CategoryID: category.NewCategoryID().String(), At: at})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !receivedAt.Equal(at) || output.Revision != 2 {
+		t.Errorf("expected lookup as of %v, got %v (revision %d)", at, receivedAt, output.Revision)
+	}
+}
+
+func TestGetRevisionUseCase_RequiresSelector(t *testing.T) {
+	useCase := NewGetRevisionUseCase(&RevisionGatewayMock{})
+
+	_, err := useCase.Execute(context.Background(), GetRevisionInput{CategoryID: category.NewCategoryID().String()})
+
+	if !errors.Is(err, ErrRevisionSelectorRequired) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRecordRevisions_SkipsDeletes(t *testing.T) {
+	cat, _ := category.NewCategory("Movies", "desc", true)
+	revisions := &RevisionGatewayMock{}
+
+	err := category.RecordRevisions(context.Background(), revisions,
+		category.NewCategoryEvent(category.EventCategoryCreated, nil, cat),
+		category.NewCategoryEvent(category.EventCategoryDeleted, cat, nil),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(revisions.Appended) != 1 {
+		t.Fatalf("expected only the creation to be snapshotted, got %d", len(revisions.Appended))
+	}
+
+	if revisions.Appended[0].Name != "Movies" {
+		t.Error("expected snapshot of the created state")
+	}
+}
@@ -0,0 +1,56 @@
+package revision
+
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type ListRevisionsUseCase struct {
+	Gateway category.CategoryRevisionGateway
+}
+
+type ListRevisionsInput struct {
+	CategoryID string
+	Page       int
+	PerPage    int
+}
+
+func NewListRevisionsUseCase(gateway category.CategoryRevisionGateway) *ListRevisionsUseCase {
+	return &ListRevisionsUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *ListRevisionsUseCase) Execute(ctx context.Context, input ListRevisionsInput) (_ *pagination.Pagination[RevisionOutput], err error) {
+	ctx, end := usecase.Observe(ctx, "list_revisions")
+	defer func() { end(err) }()
+
+	id, err := category.ParseCategoryID(input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := uc.Gateway.FindRevisions(ctx, category.SearchRevisionQuery{
+		CategoryID: id,
+		Page:       input.Page,
+		PerPage:    input.PerPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]RevisionOutput, 0, len(result.Items))
+	for _, rev := range result.Items {
+		items = append(items, toRevisionOutput(rev))
+	}
+
+	return &pagination.Pagination[RevisionOutput]{
+		CurrentPage: result.CurrentPage,
+		PerPage:     result.PerPage,
+		Total:       result.Total,
+		Items:       items,
+	}, nil
+}
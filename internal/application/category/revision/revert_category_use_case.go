@@ -0,0 +1,119 @@
+package revision
+
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type RevertCategoryUseCase struct {
+	Gateway         category.CategoryGateway
+	RevisionGateway category.CategoryRevisionGateway
+	Publisher       category.EventPublisher
+	Transactor      usecase.Transactor
+}
+
+type RevertCategoryInput struct {
+	CategoryID string
+	Revision   int
+}
+
+type RevertCategoryOutput struct {
+	ID string
+}
+
+func NewRevertCategoryUseCase(
+	gateway category.CategoryGateway,
+	revisionGateway category.CategoryRevisionGateway,
+	publisher category.EventPublisher,
+	transactor usecase.Transactor,
+) *RevertCategoryUseCase {
+	return &RevertCategoryUseCase{
+		Gateway:         gateway,
+		RevisionGateway: revisionGateway,
+		Publisher:       publisher,
+		Transactor:      transactor,
+	}
+}
+
+// Execute applies an old revision as a regular update, so the result is
+// validated like any edit and is itself recorded as a new revision.
+func (uc *RevertCategoryUseCase) Execute(ctx context.Context, input RevertCategoryInput) (_ *RevertCategoryOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "revert_category")
+	defer func() { end(err) }()
+
+	id, err := category.ParseCategoryID(input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := uc.RevisionGateway.GetRevision(ctx, id, input.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	cat, err := uc.Gateway.GetCategoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *cat
+
+	cat.Update(target.Name, target.Description, target.IsActive)
+	cat.MoveTo(target.ParentID)
+
+	slugChanged := target.Slug != "" && target.Slug != cat.Slug
+	if slugChanged {
+		cat.ChangeSlug(target.Slug)
+	}
+
+	if err := cat.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := category.EnsureNameAvailable(ctx, uc.Gateway, cat); err != nil {
+		return nil, err
+	}
+
+	if slugChanged {
+		if err := category.EnsureSlugAvailable(ctx, uc.Gateway, cat); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := category.EnsureValidParent(ctx, uc.Gateway, cat); err != nil {
+		return nil, err
+	}
+
+	var events []category.CategoryEvent
+	err = uc.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if cat, err = uc.Gateway.UpdateCategory(ctx, cat); err != nil {
+			return err
+		}
+		events = []category.CategoryEvent{category.NewCategoryEvent(category.EventCategoryUpdated, &before, cat)}
+		if before.IsActive && !cat.IsActive {
+			cascaded, err := category.DeactivateDescendants(ctx, uc.Gateway, cat.ID)
+			if err != nil {
+				return err
+			}
+			events = append(events, cascaded...)
+		}
+		for i := range events {
+			events[i] = events[i].WithMetadata(requestctx.Actor(ctx), requestctx.RequestID(ctx))
+		}
+		return category.RecordRevisions(ctx, uc.RevisionGateway, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		uc.Publisher.Publish(event)
+	}
+
+	return &RevertCategoryOutput{
+		ID: cat.ID.String(),
+	}, nil
+}
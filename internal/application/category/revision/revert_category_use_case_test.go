@@ -0,0 +1,233 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type CategoryGatewayMock struct {
+	GetByIDFn func(category.CategoryID) (*category.Category, error)
+	UpdateFn  func(*category.Category) (*category.Category, error)
+}
+
+func (m *CategoryGatewayMock) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	return nil, nil
+}
+
+func (m *CategoryGatewayMock) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
+	return m.GetByIDFn(id)
+}
+
+func (m *CategoryGatewayMock) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	return m.UpdateFn(cat)
+}
+
+func (m *CategoryGatewayMock) DeleteCategory(ctx context.Context, id category.CategoryID) error {
+	return nil
+}
+
+func (m *CategoryGatewayMock) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	return nil, nil
+}
+
+func (m *CategoryGatewayMock) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	return nil, nil
+}
+
+type RevisionGatewayMock struct {
+	Appended  []*category.CategoryRevision
+	GetFn     func(category.CategoryID, int) (*category.CategoryRevision, error)
+	GetAsOfFn func(category.CategoryID, time.Time) (*category.CategoryRevision, error)
+}
+
+func (m *RevisionGatewayMock) AppendRevision(ctx context.Context, rev *category.CategoryRevision) (*category.CategoryRevision, error) {
+	rev.Revision = len(m.Appended) + 1
+	m.Appended = append(m.Appended, rev)
+	return rev, nil
+}
+
+func (m *RevisionGatewayMock) GetRevision(ctx context.Context, id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+	return m.GetFn(id, revision)
+}
+
+func (m *RevisionGatewayMock) GetRevisionAsOf(ctx context.Context, id category.CategoryID, at time.Time) (*category.CategoryRevision, error) {
+	return m.GetAsOfFn(id, at)
+}
+
+func (m *RevisionGatewayMock) FindRevisions(ctx context.Context, query category.SearchRevisionQuery) (*pagination.Pagination[category.CategoryRevision], error) {
+	return nil, nil
+}
+
+type EventPublisherMock struct {
+	Events []category.CategoryEvent
+}
+
+func (m *EventPublisherMock) Publish(event category.CategoryEvent) {
+	m.Events = append(m.Events, event)
+}
+
+func TestRevertCategoryUseCase_Execute(t *testing.T) {
+	current, _ := category.NewCategory("Films", "new description", false)
+
+	gateway := &CategoryGatewayMock{
+		GetByIDFn: func(id category.CategoryID) (*category.Category, error) {
+			return current, nil
+		},
+		UpdateFn: func(cat *category.Category) (*category.Category, error) {
+			return cat, nil
+		},
+	}
+
+	revisions := &RevisionGatewayMock{
+		GetFn: func(id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+			return &category.CategoryRevision{
+				CategoryID:  id,
+				Revision:    revision,
+				Name:        "Movies",
+				Description: "old description",
+				IsActive:    true,
+			}, nil
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+
+	useCase := NewRevertCategoryUseCase(gateway, revisions, publisher, usecase.NoTransaction{})
+
+	output, err := useCase.Execute(context.Background(), RevertCategoryInput{
+		CategoryID: current.ID.String(),
+		Revision:   1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.ID != current.ID.String() {
+		t.Fatal("expected same category ID")
+	}
+
+	if current.Name != "Movies" || current.Description != "old description" || !current.IsActive {
+		t.Errorf("expected category to match revision 1, got %+v", current)
+	}
+
+	if !current.DeletedAt.IsZero() {
+		t.Error("expected reactivated category to clear DeletedAt")
+	}
+
+	if len(publisher.Events) != 1 || publisher.Events[0].Type != category.EventCategoryUpdated {
+		t.Fatal("expected revert to publish an update event")
+	}
+
+	if publisher.Events[0].Before.Name != "Films" {
+		t.Error("expected event to carry the state before the revert")
+	}
+}
+
+func TestRevertCategoryUseCase_ValidationError(t *testing.T) {
+	current, _ := category.NewCategory("Films", "desc", true)
+
+	gateway := &CategoryGatewayMock{
+		GetByIDFn: func(id category.CategoryID) (*category.Category, error) {
+			return current, nil
+		},
+		UpdateFn: func(cat *category.Category) (*category.Category, error) {
+			t.Fatal("invalid revert should not be persisted")
+			return nil, nil
+		},
+	}
+
+	revisions := &RevisionGatewayMock{
+		GetFn: func(id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+			return &category.CategoryRevision{CategoryID: id, Revision: revision, Name: "Go", IsActive: true}, nil
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+
+	useCase := NewRevertCategoryUseCase(gateway, revisions, publisher, usecase.NoTransaction{})
+
+	_, err := useCase.Execute(context.Background(), RevertCategoryInput{CategoryID: current.ID.String(), Revision: 1})
+
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
+}
+
+func TestRevertCategoryUseCase_RevisionNotFound(t *testing.T) {
+	revisions := &RevisionGatewayMock{
+		GetFn: func(id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+			return nil, category.ErrRevisionNotFound
+		},
+	}
+
+	useCase := NewRevertCategoryUseCase(&CategoryGatewayMock{}, revisions, &EventPublisherMock{}, usecase.NoTransaction{})
+
+	_, err := useCase.Execute(context.Background(), RevertCategoryInput{CategoryID: category.NewCategoryID().String(), Revision: 9})
+
+	if !errors.Is(err, category.ErrRevisionNotFound) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRevertCategoryUseCase_RestoresSlugAndParent(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "", true)
+	current, _ := category.NewCategory("Action", "desc", true)
+	current.ChangeSlug("action-films")
+
+	gateway := &CategoryGatewayMock{
+		GetByIDFn: func(id category.CategoryID) (*category.Category, error) {
+			if id == movies.ID {
+				return movies, nil
+			}
+			return current, nil
+		},
+		UpdateFn: func(cat *category.Category) (*category.Category, error) {
+			return cat, nil
+		},
+	}
+
+	revisions := &RevisionGatewayMock{
+		GetFn: func(id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+			return &category.CategoryRevision{
+				CategoryID:  id,
+				Revision:    revision,
+				Name:        "Action",
+				Slug:        "action",
+				ParentID:    &movies.ID,
+				Description: "desc",
+				IsActive:    true,
+			}, nil
+		},
+	}
+
+	useCase := NewRevertCategoryUseCase(gateway, revisions, &EventPublisherMock{}, usecase.NoTransaction{})
+
+	if _, err := useCase.Execute(context.Background(), RevertCategoryInput{CategoryID: current.ID.String(), Revision: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if current.Slug != "action" {
+		t.Errorf("expected slug action, got %q", current.Slug)
+	}
+	if current.ParentID == nil || *current.ParentID != movies.ID {
+		t.Errorf("expected the category to move back under Movies, got %v", current.ParentID)
+	}
+}
@@ -1,10 +1,19 @@
 // Package update provides use cases for updating categories in the admin catalog.
 package update
 
-import "github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
 
 type UpdateCategoryUseCase struct {
-	Gateway category.CategoryGateway
+	Gateway         category.CategoryGateway
+	RevisionGateway category.CategoryRevisionGateway
+	Publisher       category.EventPublisher
+	Transactor      usecase.Transactor
 }
 
 type UpdateCategoryInput struct {
@@ -12,39 +21,110 @@ type UpdateCategoryInput struct {
 	Name        string
 	Description string
 	IsActive    bool
+	// Slug replaces the current slug, which keeps resolving as an alias;
+	// empty leaves it unchanged.
+	Slug string
+	// ParentID moves the category: nil leaves it where it is, empty makes
+	// it a root category. Deactivating a category also deactivates every
+	// category below it.
+	ParentID *string
 }
 
 type UpdateCategoryOutput struct {
 	ID category.CategoryID
 }
 
-func NewUpdateCategoryUseCase(gateway category.CategoryGateway) *UpdateCategoryUseCase {
+func NewUpdateCategoryUseCase(
+	gateway category.CategoryGateway,
+	revisionGateway category.CategoryRevisionGateway,
+	publisher category.EventPublisher,
+	transactor usecase.Transactor,
+) *UpdateCategoryUseCase {
 	return &UpdateCategoryUseCase{
-		Gateway: gateway,
+		Gateway:         gateway,
+		RevisionGateway: revisionGateway,
+		Publisher:       publisher,
+		Transactor:      transactor,
 	}
 }
 
-func (uc *UpdateCategoryUseCase) Execute(input UpdateCategoryInput) (*UpdateCategoryOutput, error) {
+func (uc *UpdateCategoryUseCase) Execute(ctx context.Context, input UpdateCategoryInput) (_ *UpdateCategoryOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "update_category")
+	defer func() { end(err) }()
+
 	id, err := category.ParseCategoryID(input.ID)
 	if err != nil {
 		return nil, err
 	}
-	cat, err := uc.Gateway.GetCategoryByID(id)
+	cat, err := uc.Gateway.GetCategoryByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	before := *cat
+
 	cat.Update(input.Name, input.Description, input.IsActive)
 
+	slugChanged := input.Slug != "" && input.Slug != cat.Slug
+	if slugChanged {
+		cat.ChangeSlug(input.Slug)
+	}
+
+	if input.ParentID != nil {
+		parentID, err := category.ParseParentID(*input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		cat.MoveTo(parentID)
+	}
+
 	if err := cat.Validate(); err != nil {
 		return nil, err
 	}
 
-	cat, err = uc.Gateway.UpdateCategory(cat)
+	if err := category.EnsureNameAvailable(ctx, uc.Gateway, cat); err != nil {
+		return nil, err
+	}
+
+	if slugChanged {
+		if err := category.EnsureSlugAvailable(ctx, uc.Gateway, cat); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := category.EnsureValidParent(ctx, uc.Gateway, cat); err != nil {
+		return nil, err
+	}
+
+	// The category is written before its descendants, in one transaction
+	// with their revisions, and events are only published once all are
+	// stored.
+	var events []category.CategoryEvent
+	err = uc.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if cat, err = uc.Gateway.UpdateCategory(ctx, cat); err != nil {
+			return err
+		}
+		events = []category.CategoryEvent{category.NewCategoryEvent(category.EventCategoryUpdated, &before, cat)}
+		if before.IsActive && !cat.IsActive {
+			cascaded, err := category.DeactivateDescendants(ctx, uc.Gateway, cat.ID)
+			if err != nil {
+				return err
+			}
+			events = append(events, cascaded...)
+		}
+		for i := range events {
+			events[i] = events[i].WithMetadata(requestctx.Actor(ctx), requestctx.RequestID(ctx))
+		}
+		return category.RecordRevisions(ctx, uc.RevisionGateway, events...)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	for _, event := range events {
+		uc.Publisher.Publish(event)
+	}
+
 	return &UpdateCategoryOutput{
 		ID: cat.ID,
 	}, nil
@@ -1,38 +1,94 @@
 package update
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
 )
 
 type CategoryGatewayMock struct {
-	GetByIDFn func(category.CategoryID) (*category.Category, error)
-	UpdateFn  func(*category.Category) (*category.Category, error)
+	GetByIDFn    func(category.CategoryID) (*category.Category, error)
+	UpdateFn     func(*category.Category) (*category.Category, error)
+	FindByNameFn func(string) (*category.Category, error)
+	ChildrenFn   func(category.CategoryID) ([]category.Category, error)
 }
 
-func (m *CategoryGatewayMock) CreateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryGatewayMock) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return nil, nil
 }
 
-func (m *CategoryGatewayMock) GetCategoryByID(id category.CategoryID) (*category.Category, error) {
+func (m *CategoryGatewayMock) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
 	return m.GetByIDFn(id)
 }
 
-func (m *CategoryGatewayMock) UpdateCategory(cat *category.Category) (*category.Category, error) {
+func (m *CategoryGatewayMock) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
 	return m.UpdateFn(cat)
 }
 
-func (m *CategoryGatewayMock) DeleteCategory(id category.CategoryID) error {
+func (m *CategoryGatewayMock) DeleteCategory(ctx context.Context, id category.CategoryID) error {
 	return nil
 }
 
-func (m *CategoryGatewayMock) FindAll(query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+func (m *CategoryGatewayMock) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	if m.FindByNameFn == nil {
+		return nil, category.ErrCategoryNotFound
+	}
+	return m.FindByNameFn(name)
+}
+
+func (m *CategoryGatewayMock) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (m *CategoryGatewayMock) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	if m.ChildrenFn == nil {
+		return nil, nil
+	}
+	return m.ChildrenFn(parentID)
+}
+
+func (m *CategoryGatewayMock) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	return nil, nil
+}
+
+type RevisionGatewayMock struct {
+	Appended []*category.CategoryRevision
+	Err      error
+}
+
+func (m *RevisionGatewayMock) AppendRevision(ctx context.Context, rev *category.CategoryRevision) (*category.CategoryRevision, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	m.Appended = append(m.Appended, rev)
+	return rev, nil
+}
+
+func (m *RevisionGatewayMock) GetRevision(ctx context.Context, id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (m *RevisionGatewayMock) GetRevisionAsOf(ctx context.Context, id category.CategoryID, at time.Time) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (m *RevisionGatewayMock) FindRevisions(ctx context.Context, query category.SearchRevisionQuery) (*pagination.Pagination[category.CategoryRevision], error) {
 	return nil, nil
 }
 
+type EventPublisherMock struct {
+	Events []category.CategoryEvent
+}
+
+func (m *EventPublisherMock) Publish(event category.CategoryEvent) {
+	m.Events = append(m.Events, event)
+}
+
 func TestUpdateCategoryUseCase_Execute(t *testing.T) {
 	existingCategory, _ := category.NewCategory(
 		"Movies",
@@ -49,7 +105,9 @@ func TestUpdateCategoryUseCase_Execute(t *testing.T) {
 		},
 	}
 
-	useCase := NewUpdateCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewUpdateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
 
 	input := UpdateCategoryInput{
 		ID:          existingCategory.ID.String(),
@@ -58,7 +116,7 @@ func TestUpdateCategoryUseCase_Execute(t *testing.T) {
 		IsActive:    true,
 	}
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(context.Background(), input)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -78,12 +136,32 @@ func TestUpdateCategoryUseCase_Execute(t *testing.T) {
 	if existingCategory.Description != input.Description {
 		t.Errorf("expected description %s, got %s", input.Description, existingCategory.Description)
 	}
+
+	if len(publisher.Events) != 1 {
+		t.Fatalf("expected 1 published event, got %d", len(publisher.Events))
+	}
+
+	event := publisher.Events[0]
+
+	if event.Type != category.EventCategoryUpdated {
+		t.Errorf("expected event type %s, got %s", category.EventCategoryUpdated, event.Type)
+	}
+
+	if event.Before == nil || event.Before.Name != "Movies" {
+		t.Error("expected event to carry the state before the update")
+	}
+
+	if event.Category.Name != input.Name {
+		t.Error("expected event to carry the state after the update")
+	}
 }
 
 func TestUpdateCategoryUseCase_InvalidID(t *testing.T) {
 	gateway := &CategoryGatewayMock{}
 
-	useCase := NewUpdateCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewUpdateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
 
 	input := UpdateCategoryInput{
 		ID:          "invalid-uuid", // 😈
@@ -92,7 +170,7 @@ func TestUpdateCategoryUseCase_InvalidID(t *testing.T) {
 		IsActive:    true,
 	}
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected error for invalid ID")
@@ -101,6 +179,10 @@ func TestUpdateCategoryUseCase_InvalidID(t *testing.T) {
 	if output != nil {
 		t.Fatal("expected nil output")
 	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
 }
 
 func TestUpdateCategoryUseCase_GetByIDError(t *testing.T) {
@@ -114,7 +196,9 @@ func TestUpdateCategoryUseCase_GetByIDError(t *testing.T) {
 		},
 	}
 
-	useCase := NewUpdateCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewUpdateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
 
 	input := UpdateCategoryInput{
 		ID:          existingID.String(),
@@ -123,7 +207,7 @@ func TestUpdateCategoryUseCase_GetByIDError(t *testing.T) {
 		IsActive:    true,
 	}
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected gateway error")
@@ -136,6 +220,10 @@ func TestUpdateCategoryUseCase_GetByIDError(t *testing.T) {
 	if output != nil {
 		t.Fatal("expected nil output")
 	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
 }
 
 func TestUpdateCategoryUseCase_ValidationError(t *testing.T) {
@@ -154,7 +242,9 @@ func TestUpdateCategoryUseCase_ValidationError(t *testing.T) {
 		},
 	}
 
-	useCase := NewUpdateCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewUpdateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
 
 	input := UpdateCategoryInput{
 		ID:          existingCategory.ID.String(),
@@ -163,7 +253,7 @@ func TestUpdateCategoryUseCase_ValidationError(t *testing.T) {
 		IsActive:    true,
 	}
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected validation error")
@@ -172,6 +262,10 @@ func TestUpdateCategoryUseCase_ValidationError(t *testing.T) {
 	if output != nil {
 		t.Fatal("expected nil output")
 	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
 }
 
 func TestUpdateCategoryUseCase_UpdateError(t *testing.T) {
@@ -192,7 +286,9 @@ func TestUpdateCategoryUseCase_UpdateError(t *testing.T) {
 		},
 	}
 
-	useCase := NewUpdateCategoryUseCase(gateway)
+	publisher := &EventPublisherMock{}
+
+	useCase := NewUpdateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
 
 	input := UpdateCategoryInput{
 		ID:          existingCategory.ID.String(),
@@ -201,7 +297,7 @@ func TestUpdateCategoryUseCase_UpdateError(t *testing.T) {
 		IsActive:    true,
 	}
 
-	output, err := useCase.Execute(input)
+	output, err := useCase.Execute(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("expected update error")
@@ -214,4 +310,209 @@ func TestUpdateCategoryUseCase_UpdateError(t *testing.T) {
 	if output != nil {
 		t.Fatal("expected nil output")
 	}
+
+	if len(publisher.Events) != 0 {
+		t.Fatal("expected no published events on error")
+	}
+}
+
+func TestUpdateCategoryUseCase_NameConflict(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "description", true)
+	series, _ := category.NewCategory("Series", "description", true)
+
+	gateway := &CategoryGatewayMock{
+		GetByIDFn: func(id category.CategoryID) (*category.Category, error) {
+			return movies, nil
+		},
+		UpdateFn: func(cat *category.Category) (*category.Category, error) {
+			return cat, nil
+		},
+		FindByNameFn: func(name string) (*category.Category, error) {
+			switch category.NormalizeName(name) {
+			case "movies":
+				return movies, nil
+			case "series":
+				return series, nil
+			}
+			return nil, category.ErrCategoryNotFound
+		},
+	}
+
+	useCase := NewUpdateCategoryUseCase(gateway, &RevisionGatewayMock{}, &EventPublisherMock{}, usecase.NoTransaction{})
+
+	_, err := useCase.Execute(context.Background(), UpdateCategoryInput{ID: movies.ID.String(), Name: "series", IsActive: true})
+	if !errors.Is(err, category.ErrNameConflict) {
+		t.Fatalf("expected a name conflict, got %v", err)
+	}
+
+	// Keeping its own name, in another case, is not a conflict.
+	if _, err := useCase.Execute(context.Background(), UpdateCategoryInput{ID: movies.ID.String(), Name: "MOVIES", IsActive: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateCategoryUseCase_Slug(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "description", true)
+
+	gateway := &CategoryGatewayMock{
+		GetByIDFn: func(id category.CategoryID) (*category.Category, error) {
+			return movies, nil
+		},
+		UpdateFn: func(cat *category.Category) (*category.Category, error) {
+			return cat, nil
+		},
+	}
+
+	useCase := NewUpdateCategoryUseCase(gateway, &RevisionGatewayMock{}, &EventPublisherMock{}, usecase.NoTransaction{})
+
+	// Renaming keeps the slug, so existing links stay canonical.
+	if _, err := useCase.Execute(context.Background(), UpdateCategoryInput{ID: movies.ID.String(), Name: "Films", IsActive: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if movies.Slug != "movies" {
+		t.Errorf("expected the slug to be kept, got %q", movies.Slug)
+	}
+
+	if _, err := useCase.Execute(context.Background(), UpdateCategoryInput{ID: movies.ID.String(), Name: "Films", Slug: "films", IsActive: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if movies.Slug != "films" {
+		t.Errorf("expected slug films, got %q", movies.Slug)
+	}
+
+	_, err := useCase.Execute(context.Background(), UpdateCategoryInput{ID: movies.ID.String(), Name: "Films", Slug: "Films!", IsActive: true})
+	if err == nil {
+		t.Error("expected an invalid slug to be rejected")
+	}
+}
+
+func TestUpdateCategoryUseCase_DeactivateCascades(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films", true)
+	action, _ := category.NewCategory("Action", "", true)
+	action.ParentID = &movies.ID
+
+	var updated []string
+
+	gateway := &CategoryGatewayMock{
+		GetByIDFn: func(id category.CategoryID) (*category.Category, error) {
+			return movies, nil
+		},
+		UpdateFn: func(cat *category.Category) (*category.Category, error) {
+			updated = append(updated, cat.Name)
+			if cat.IsActive {
+				t.Errorf("expected %s to be written inactive", cat.Name)
+			}
+			return cat, nil
+		},
+		ChildrenFn: func(id category.CategoryID) ([]category.Category, error) {
+			if id == movies.ID {
+				return []category.Category{*action}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+	revisions := &RevisionGatewayMock{}
+
+	useCase := NewUpdateCategoryUseCase(gateway, revisions, publisher, usecase.NoTransaction{})
+
+	_, err := useCase.Execute(context.Background(), UpdateCategoryInput{
+		ID:          movies.ID.String(),
+		Name:        "Movies",
+		Description: "films",
+		IsActive:    false,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(updated) != 2 || updated[0] != "Movies" || updated[1] != "Action" {
+		t.Errorf("expected Movies to be written before Action is deactivated, got %v", updated)
+	}
+
+	if len(publisher.Events) != 2 || publisher.Events[0].CategoryID != movies.ID || publisher.Events[1].CategoryID != action.ID {
+		t.Fatalf("expected an event for Movies and one for Action, got %+v", publisher.Events)
+	}
+
+	if len(revisions.Appended) != 2 || revisions.Appended[0].EventID != publisher.Events[0].ID || revisions.Appended[1].EventID != publisher.Events[1].ID {
+		t.Errorf("expected a revision for each published event, got %+v", revisions.Appended)
+	}
+}
+
+func TestUpdateCategoryUseCase_FailedUpdateDoesNotCascade(t *testing.T) {
+	expectedErr := errors.New("database error")
+
+	movies, _ := category.NewCategory("Movies", "films", true)
+
+	gateway := &CategoryGatewayMock{
+		GetByIDFn: func(id category.CategoryID) (*category.Category, error) {
+			return movies, nil
+		},
+		UpdateFn: func(cat *category.Category) (*category.Category, error) {
+			return nil, expectedErr
+		},
+		ChildrenFn: func(id category.CategoryID) ([]category.Category, error) {
+			t.Error("expected descendants to be left alone when the category is not written")
+			return nil, nil
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+
+	useCase := NewUpdateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, usecase.NoTransaction{})
+
+	_, err := useCase.Execute(context.Background(), UpdateCategoryInput{ID: movies.ID.String(), Name: "Movies", IsActive: false})
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected %v, got %v", expectedErr, err)
+	}
+
+	if len(publisher.Events) != 0 {
+		t.Errorf("expected no events, got %+v", publisher.Events)
+	}
+}
+
+// TransactorMock records whether fn ran inside a transaction.
+type TransactorMock struct {
+	Calls int
+}
+
+func (m *TransactorMock) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	return fn(ctx)
+}
+
+func TestUpdateCategoryUseCase_FailedCascadePublishesNothing(t *testing.T) {
+	expectedErr := errors.New("database error")
+
+	movies, _ := category.NewCategory("Movies", "films", true)
+
+	gateway := &CategoryGatewayMock{
+		GetByIDFn: func(id category.CategoryID) (*category.Category, error) {
+			return movies, nil
+		},
+		UpdateFn: func(cat *category.Category) (*category.Category, error) {
+			return cat, nil
+		},
+		ChildrenFn: func(id category.CategoryID) ([]category.Category, error) {
+			return nil, expectedErr
+		},
+	}
+
+	publisher := &EventPublisherMock{}
+	transactor := &TransactorMock{}
+
+	useCase := NewUpdateCategoryUseCase(gateway, &RevisionGatewayMock{}, publisher, transactor)
+
+	_, err := useCase.Execute(context.Background(), UpdateCategoryInput{ID: movies.ID.String(), Name: "Movies", IsActive: false})
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected %v, got %v", expectedErr, err)
+	}
+
+	if transactor.Calls != 1 {
+		t.Errorf("expected the update and the cascade to share one transaction, got %d", transactor.Calls)
+	}
+	if len(publisher.Events) != 0 {
+		t.Errorf("expected a rolled back update to publish nothing, got %+v", publisher.Events)
+	}
 }
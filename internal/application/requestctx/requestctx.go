@@ -0,0 +1,86 @@
+// Package requestctx carries per-request metadata (who is acting, which request) through use cases.
+package requestctx
+
+import "context"
+
+type contextKey int
+
+const (
+	actorKey contextKey = iota
+	requestIDKey
+	principalKey
+	clientIPKey
+)
+
+const AnonymousActor = "anonymous"
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// Actor returns the actor stored in ctx, or AnonymousActor when none is set.
+func Actor(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// PrincipalKind keeps user subjects and API key ids apart, so a token can
+// never pass for a key by choosing its subject.
+type PrincipalKind string
+
+const (
+	PrincipalUser   PrincipalKind = "user"
+	PrincipalAPIKey PrincipalKind = "apikey"
+)
+
+// Principal is the authenticated caller of a request. Users carry roles
+// that map to permissions; machine clients carry permissions directly.
+type Principal struct {
+	// Kind is PrincipalUser when empty.
+	Kind PrincipalKind
+	// Subject is the token subject of a user or the id of an API key.
+	Subject     string
+	Roles       []string
+	Permissions []string
+}
+
+// ID names the principal by kind and subject, e.g. "user:alice" or
+// "apikey:<id>".
+func (p Principal) ID() string {
+	kind := p.Kind
+	if kind == "" {
+		kind = PrincipalUser
+	}
+	return string(kind) + ":" + p.Subject
+}
+
+// WithPrincipal stores the principal and makes its ID the actor.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey, principal)
+	return WithActor(ctx, principal.ID())
+}
+
+func CurrentPrincipal(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(Principal)
+	return principal, ok
+}
+
+func WithClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPKey, clientIP)
+}
+
+func ClientIP(ctx context.Context) string {
+	clientIP, _ := ctx.Value(clientIPKey).(string)
+	return clientIP
+}
@@ -0,0 +1,64 @@
+// Package usecase lets infrastructure observe use case executions (timing,
+// errors, tracing) without use cases depending on any particular tool.
+package usecase
+
+import (
+	"context"
+	"sync"
+)
+
+// Observer is told when a use case starts and returns the function to call
+// with its outcome. It may derive a new context, e.g. to carry a span.
+type Observer interface {
+	Start(ctx context.Context, name string) (context.Context, func(err error))
+}
+
+var (
+	mu        sync.RWMutex
+	observers []Observer
+)
+
+// AddObserver registers o for every later use case execution. It is meant
+// to be called while the application is being wired.
+func AddObserver(o Observer) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	observers = append(observers, o)
+}
+
+// ResetObservers removes every registered observer.
+func ResetObservers() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	observers = nil
+}
+
+// Observe notifies the registered observers that the use case name started.
+// Use cases call it first thing and defer the returned function:
+//
+//	ctx, end := usecase.Observe(ctx, "create_category")
+//	defer func() { end(err) }()
+func Observe(ctx context.Context, name string) (context.Context, func(err error)) {
+	mu.RLock()
+	current := observers
+	mu.RUnlock()
+
+	if len(current) == 0 {
+		return ctx, func(error) {}
+	}
+
+	ends := make([]func(error), 0, len(current))
+	for _, o := range current {
+		var end func(error)
+		ctx, end = o.Start(ctx, name)
+		ends = append(ends, end)
+	}
+
+	return ctx, func(err error) {
+		for i := len(ends) - 1; i >= 0; i-- {
+			ends[i](err)
+		}
+	}
+}
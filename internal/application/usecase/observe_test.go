@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type contextKey string
+
+type recordingObserver struct {
+	label  string
+	events *[]string
+}
+
+func (o recordingObserver) Start(ctx context.Context, name string) (context.Context, func(error)) {
+	*o.events = append(*o.events, o.label+" start "+name)
+	ctx = context.WithValue(ctx, contextKey(o.label), true)
+
+	return ctx, func(err error) {
+		outcome := "ok"
+		if err != nil {
+			outcome = err.Error()
+		}
+		*o.events = append(*o.events, o.label+" end "+outcome)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	t.Cleanup(ResetObservers)
+
+	var events []string
+	AddObserver(recordingObserver{label: "metrics", events: &events})
+	AddObserver(recordingObserver{label: "tracing", events: &events})
+
+	ctx, end := Observe(context.Background(), "create_category")
+	if ctx.Value(contextKey("metrics")) == nil || ctx.Value(contextKey("tracing")) == nil {
+		t.Error("expected observers to derive the context in turn")
+	}
+	end(errors.New("boom"))
+
+	expected := []string{
+		"metrics start create_category",
+		"tracing start create_category",
+		"tracing end boom",
+		"metrics end boom",
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("expected %q at %d, got %q", expected[i], i, events[i])
+		}
+	}
+}
+
+func TestObserve_WithoutObservers(t *testing.T) {
+	ResetObservers()
+
+	ctx := context.Background()
+	observed, end := Observe(ctx, "create_category")
+	end(nil)
+
+	if observed != ctx {
+		t.Error("expected context to be returned unchanged")
+	}
+}
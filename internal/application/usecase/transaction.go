@@ -0,0 +1,17 @@
+package usecase
+
+import "context"
+
+// Transactor runs fn in a single transaction. Gateways join it through the
+// ctx passed to fn, and an error returned by fn rolls it back.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// NoTransaction runs fn directly, for gateways that cannot join a
+// transaction; a failure leaves earlier writes in place.
+type NoTransaction struct{}
+
+func (NoTransaction) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
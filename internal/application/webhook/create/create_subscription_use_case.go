@@ -0,0 +1,63 @@
+// Package create provides use cases for creating webhook subscriptions.
+package create
+
+import (
+	"context"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type CreateSubscriptionUseCase struct {
+	Gateway webhook.SubscriptionGateway
+}
+
+type CreateSubscriptionInput struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+	IsActive   bool
+}
+
+type CreateSubscriptionOutput struct {
+	ID string
+}
+
+func NewCreateSubscriptionUseCase(gateway webhook.SubscriptionGateway) *CreateSubscriptionUseCase {
+	return &CreateSubscriptionUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *CreateSubscriptionUseCase) Execute(ctx context.Context, input CreateSubscriptionInput) (_ *CreateSubscriptionOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "create_subscription")
+	defer func() { end(err) }()
+
+	eventTypes := make([]category.EventType, 0, len(input.EventTypes))
+	for _, t := range input.EventTypes {
+		eventTypes = append(eventTypes, category.EventType(t))
+	}
+
+	subscription, err := webhook.NewSubscription(
+		input.URL,
+		eventTypes,
+		input.Secret,
+		input.IsActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscription, err = uc.Gateway.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateSubscriptionOutput{
+		ID: subscription.ID.String(),
+	}, nil
+}
@@ -0,0 +1,117 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type SubscriptionGatewayMock struct {
+	CreateFn func(*webhook.Subscription) (*webhook.Subscription, error)
+}
+
+func (m *SubscriptionGatewayMock) CreateSubscription(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	return m.CreateFn(sub)
+}
+
+func (m *SubscriptionGatewayMock) GetSubscriptionByID(ctx context.Context, id webhook.SubscriptionID) (*webhook.Subscription, error) {
+	return nil, nil
+}
+
+func (m *SubscriptionGatewayMock) UpdateSubscription(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	return nil, nil
+}
+
+func (m *SubscriptionGatewayMock) DeleteSubscription(ctx context.Context, id webhook.SubscriptionID) error {
+	return nil
+}
+
+func (m *SubscriptionGatewayMock) FindAll(ctx context.Context, query webhook.SearchSubscriptionQuery) (*pagination.Pagination[webhook.Subscription], error) {
+	return nil, nil
+}
+
+func (m *SubscriptionGatewayMock) FindActiveByEventType(ctx context.Context, eventType category.EventType) ([]webhook.Subscription, error) {
+	return nil, nil
+}
+
+func TestCreateSubscriptionUseCaseExecute(t *testing.T) {
+	var created *webhook.Subscription
+
+	gateway := &SubscriptionGatewayMock{
+		CreateFn: func(sub *webhook.Subscription) (*webhook.Subscription, error) {
+			created = sub
+			return sub, nil
+		},
+	}
+
+	useCase := NewCreateSubscriptionUseCase(gateway)
+
+	output, err := useCase.Execute(context.Background(), CreateSubscriptionInput{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{"category.created", "category.deleted"},
+		Secret:     "0123456789abcdef",
+		IsActive:   true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.ID == "" {
+		t.Fatal("expected subscription ID")
+	}
+
+	if len(created.EventTypes) != 2 || created.EventTypes[1] != category.EventCategoryDeleted {
+		t.Errorf("unexpected event types: %v", created.EventTypes)
+	}
+}
+
+func TestCreateSubscriptionUseCase_ValidationError(t *testing.T) {
+	gateway := &SubscriptionGatewayMock{
+		CreateFn: func(sub *webhook.Subscription) (*webhook.Subscription, error) {
+			t.Fatal("gateway should not be called for invalid input")
+			return nil, nil
+		},
+	}
+
+	useCase := NewCreateSubscriptionUseCase(gateway)
+
+	output, err := useCase.Execute(context.Background(), CreateSubscriptionInput{
+		URL:        "not-a-url",
+		EventTypes: []string{"category.created"},
+		Secret:     "0123456789abcdef",
+	})
+
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	if output != nil {
+		t.Fatal("expected nil output on error")
+	}
+}
+
+func TestCreateSubscriptionUseCase_GatewayError(t *testing.T) {
+	expectedErr := errors.New("database error")
+
+	gateway := &SubscriptionGatewayMock{
+		CreateFn: func(sub *webhook.Subscription) (*webhook.Subscription, error) {
+			return nil, expectedErr
+		},
+	}
+
+	useCase := NewCreateSubscriptionUseCase(gateway)
+
+	_, err := useCase.Execute(context.Background(), CreateSubscriptionInput{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{"category.created"},
+		Secret:     "0123456789abcdef",
+	})
+
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+// Package delete provides use cases for deleting webhook subscriptions.
+package delete
+
+import (
+	"context"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type DeleteSubscriptionUseCase struct {
+	Gateway webhook.SubscriptionGateway
+}
+
+type DeleteSubscriptionInput struct {
+	ID string
+}
+
+func NewDeleteSubscriptionUseCase(gateway webhook.SubscriptionGateway) *DeleteSubscriptionUseCase {
+	return &DeleteSubscriptionUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *DeleteSubscriptionUseCase) Execute(ctx context.Context, input DeleteSubscriptionInput) (err error) {
+	ctx, end := usecase.Observe(ctx, "delete_subscription")
+	defer func() { end(err) }()
+
+	id, err := webhook.ParseSubscriptionID(input.ID)
+	if err != nil {
+		return err
+	}
+
+	return uc.Gateway.DeleteSubscription(ctx, id)
+}
@@ -0,0 +1,67 @@
+// Package deliver provides use cases for queueing, sending and replaying webhook deliveries.
+package deliver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type EnqueueDeliveriesUseCase struct {
+	SubscriptionGateway webhook.SubscriptionGateway
+	DeliveryGateway     webhook.DeliveryGateway
+}
+
+type EnqueueDeliveriesOutput struct {
+	DeliveryIDs []string
+}
+
+func NewEnqueueDeliveriesUseCase(
+	subscriptionGateway webhook.SubscriptionGateway,
+	deliveryGateway webhook.DeliveryGateway,
+) *EnqueueDeliveriesUseCase {
+	return &EnqueueDeliveriesUseCase{
+		SubscriptionGateway: subscriptionGateway,
+		DeliveryGateway:     deliveryGateway,
+	}
+}
+
+func (uc *EnqueueDeliveriesUseCase) Execute(ctx context.Context, event category.CategoryEvent) (_ *EnqueueDeliveriesOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "enqueue_deliveries")
+	defer func() { end(err) }()
+
+	subscriptions, err := uc.SubscriptionGateway.FindActiveByEventType(ctx, event.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &EnqueueDeliveriesOutput{}
+	if len(subscriptions) == 0 {
+		return output, nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event.Type) {
+			continue
+		}
+
+		delivery := webhook.NewDelivery(subscription.ID, event.ID, event.Type, payload)
+
+		delivery, err = uc.DeliveryGateway.CreateDelivery(ctx, delivery)
+		if err != nil {
+			return nil, err
+		}
+
+		output.DeliveryIDs = append(output.DeliveryIDs, delivery.ID.String())
+	}
+
+	return output, nil
+}
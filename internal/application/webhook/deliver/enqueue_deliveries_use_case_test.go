@@ -0,0 +1,149 @@
+package deliver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type SubscriptionGatewayMock struct {
+	GetByIDFn    func(webhook.SubscriptionID) (*webhook.Subscription, error)
+	FindActiveFn func(category.EventType) ([]webhook.Subscription, error)
+}
+
+func (m *SubscriptionGatewayMock) CreateSubscription(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	return nil, nil
+}
+
+func (m *SubscriptionGatewayMock) GetSubscriptionByID(ctx context.Context, id webhook.SubscriptionID) (*webhook.Subscription, error) {
+	return m.GetByIDFn(id)
+}
+
+func (m *SubscriptionGatewayMock) UpdateSubscription(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	return nil, nil
+}
+
+func (m *SubscriptionGatewayMock) DeleteSubscription(ctx context.Context, id webhook.SubscriptionID) error {
+	return nil
+}
+
+func (m *SubscriptionGatewayMock) FindAll(ctx context.Context, query webhook.SearchSubscriptionQuery) (*pagination.Pagination[webhook.Subscription], error) {
+	return nil, nil
+}
+
+func (m *SubscriptionGatewayMock) FindActiveByEventType(ctx context.Context, eventType category.EventType) ([]webhook.Subscription, error) {
+	return m.FindActiveFn(eventType)
+}
+
+type DeliveryGatewayMock struct {
+	Created    []*webhook.Delivery
+	Updated    []*webhook.Delivery
+	GetByIDFn  func(webhook.DeliveryID) (*webhook.Delivery, error)
+	ClaimDueFn func(time.Time, time.Duration, int) ([]webhook.Delivery, error)
+}
+
+func (m *DeliveryGatewayMock) CreateDelivery(ctx context.Context, d *webhook.Delivery) (*webhook.Delivery, error) {
+	m.Created = append(m.Created, d)
+	return d, nil
+}
+
+func (m *DeliveryGatewayMock) GetDeliveryByID(ctx context.Context, id webhook.DeliveryID) (*webhook.Delivery, error) {
+	return m.GetByIDFn(id)
+}
+
+func (m *DeliveryGatewayMock) UpdateDelivery(ctx context.Context, d *webhook.Delivery) (*webhook.Delivery, error) {
+	m.Updated = append(m.Updated, d)
+	return d, nil
+}
+
+func (m *DeliveryGatewayMock) FindBySubscription(ctx context.Context, query webhook.SearchDeliveryQuery) (*pagination.Pagination[webhook.Delivery], error) {
+	return nil, nil
+}
+
+func (m *DeliveryGatewayMock) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhook.Delivery, error) {
+	return m.ClaimDueFn(now, lease, limit)
+}
+
+type SenderMock struct {
+	SendFn func(*webhook.Subscription, *webhook.Delivery) (webhook.SendResult, error)
+}
+
+func (m *SenderMock) Send(ctx context.Context, sub *webhook.Subscription, d *webhook.Delivery) (webhook.SendResult, error) {
+	return m.SendFn(sub, d)
+}
+
+func TestEnqueueDeliveriesUseCaseExecute(t *testing.T) {
+	cat, _ := category.NewCategory("Movies", "desc", true)
+	event := category.NewCategoryEvent(category.EventCategoryCreated, nil, cat)
+
+	matching, _ := webhook.NewSubscription("https://a.example.com", []category.EventType{category.EventCategoryCreated}, "0123456789abcdef", true)
+	other, _ := webhook.NewSubscription("https://b.example.com", []category.EventType{category.EventCategoryDeleted}, "0123456789abcdef", true)
+
+	subscriptions := &SubscriptionGatewayMock{
+		FindActiveFn: func(eventType category.EventType) ([]webhook.Subscription, error) {
+			return []webhook.Subscription{*matching, *other}, nil
+		},
+	}
+	deliveries := &DeliveryGatewayMock{}
+
+	useCase := NewEnqueueDeliveriesUseCase(subscriptions, deliveries)
+
+	output, err := useCase.Execute(context.Background(), event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(output.DeliveryIDs) != 1 || len(deliveries.Created) != 1 {
+		t.Fatalf("expected exactly one delivery, got %d", len(deliveries.Created))
+	}
+
+	created := deliveries.Created[0]
+
+	if created.SubscriptionID != matching.ID {
+		t.Error("expected delivery for the matching subscription")
+	}
+
+	if created.Status != webhook.DeliveryPending {
+		t.Errorf("expected pending delivery, got %s", created.Status)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(created.Payload, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+
+	if payload["id"] != event.ID || payload["type"] != "category.created" {
+		t.Errorf("unexpected payload envelope: %v", payload)
+	}
+
+	data := payload["data"].(map[string]any)
+	if data["id"] != cat.ID.String() || data["name"] != "Movies" || data["slug"] != "movies" {
+		t.Errorf("unexpected payload data: %v", data)
+	}
+}
+
+func TestEnqueueDeliveriesUseCase_GatewayError(t *testing.T) {
+	expectedErr := errors.New("database error")
+
+	cat, _ := category.NewCategory("Movies", "desc", true)
+
+	subscriptions := &SubscriptionGatewayMock{
+		FindActiveFn: func(eventType category.EventType) ([]webhook.Subscription, error) {
+			return nil, expectedErr
+		},
+	}
+
+	useCase := NewEnqueueDeliveriesUseCase(subscriptions, &DeliveryGatewayMock{})
+
+	_, err := useCase.Execute(context.Background(), category.NewCategoryEvent(category.EventCategoryCreated, nil, cat))
+
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
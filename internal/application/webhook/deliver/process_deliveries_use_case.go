@@ -0,0 +1,117 @@
+package deliver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+const defaultBatchSize = 50
+
+// claimLease hides a claimed delivery from other workers. It outlasts the
+// sender's timeout, so a delivery is only claimed again when its worker
+// stopped before recording the outcome.
+const claimLease = 5 * time.Minute
+
+type ProcessDeliveriesUseCase struct {
+	SubscriptionGateway webhook.SubscriptionGateway
+	DeliveryGateway     webhook.DeliveryGateway
+	Sender              webhook.Sender
+	Policy              webhook.RetryPolicy
+}
+
+type ProcessDeliveriesInput struct {
+	Limit int
+}
+
+type ProcessDeliveriesOutput struct {
+	Succeeded int
+	Failed    int
+}
+
+func NewProcessDeliveriesUseCase(
+	subscriptionGateway webhook.SubscriptionGateway,
+	deliveryGateway webhook.DeliveryGateway,
+	sender webhook.Sender,
+	policy webhook.RetryPolicy,
+) *ProcessDeliveriesUseCase {
+	return &ProcessDeliveriesUseCase{
+		SubscriptionGateway: subscriptionGateway,
+		DeliveryGateway:     deliveryGateway,
+		Sender:              sender,
+		Policy:              policy,
+	}
+}
+
+func (uc *ProcessDeliveriesUseCase) Execute(ctx context.Context, input ProcessDeliveriesInput) (_ *ProcessDeliveriesOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "process_deliveries")
+	defer func() { end(err) }()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultBatchSize
+	}
+
+	deliveries, err := uc.DeliveryGateway.ClaimDue(ctx, time.Now().UTC(), claimLease, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ProcessDeliveriesOutput{}
+
+	for i := range deliveries {
+		if err := ctx.Err(); err != nil {
+			return output, err
+		}
+
+		delivery := &deliveries[i]
+
+		if err := attempt(ctx, uc.SubscriptionGateway, uc.DeliveryGateway, uc.Sender, uc.Policy, delivery); err != nil {
+			return output, err
+		}
+
+		if delivery.Status == webhook.DeliverySucceeded {
+			output.Succeeded++
+		} else {
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
+
+// attempt sends a single delivery and persists its outcome. A missing or
+// inactive subscription fails the delivery for good instead of retrying it.
+func attempt(
+	ctx context.Context,
+	subscriptions webhook.SubscriptionGateway,
+	deliveries webhook.DeliveryGateway,
+	sender webhook.Sender,
+	policy webhook.RetryPolicy,
+	delivery *webhook.Delivery,
+) error {
+	subscription, err := subscriptions.GetSubscriptionByID(ctx, delivery.SubscriptionID)
+
+	switch {
+	case err != nil:
+		delivery.Abandon(fmt.Sprintf("subscription unavailable: %v", err))
+	case !subscription.IsActive:
+		delivery.Abandon("subscription is inactive")
+	default:
+		result, sendErr := sender.Send(ctx, subscription, delivery)
+		switch {
+		case sendErr != nil:
+			delivery.RecordFailure(result.StatusCode, sendErr.Error(), policy)
+		case result.StatusCode < 200 || result.StatusCode > 299:
+			delivery.RecordFailure(result.StatusCode, fmt.Sprintf("unexpected response status %d", result.StatusCode), policy)
+		default:
+			delivery.RecordSuccess(result.StatusCode)
+		}
+	}
+
+	_, err = deliveries.UpdateDelivery(ctx, delivery)
+	return err
+}
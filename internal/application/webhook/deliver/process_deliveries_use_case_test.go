@@ -0,0 +1,124 @@
+package deliver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+func newDueDelivery(subscription *webhook.Subscription) webhook.Delivery {
+	return *webhook.NewDelivery(subscription.ID, "event-id", category.EventCategoryCreated, []byte(`{}`))
+}
+
+func TestProcessDeliveriesUseCaseExecute(t *testing.T) {
+	subscription, _ := webhook.NewSubscription("https://a.example.com", []category.EventType{category.EventCategoryCreated}, "0123456789abcdef", true)
+
+	subscriptions := &SubscriptionGatewayMock{
+		GetByIDFn: func(id webhook.SubscriptionID) (*webhook.Subscription, error) {
+			return subscription, nil
+		},
+	}
+
+	deliveries := &DeliveryGatewayMock{
+		ClaimDueFn: func(now time.Time, lease time.Duration, limit int) ([]webhook.Delivery, error) {
+			return []webhook.Delivery{newDueDelivery(subscription), newDueDelivery(subscription)}, nil
+		},
+	}
+
+	calls := 0
+	sender := &SenderMock{
+		SendFn: func(sub *webhook.Subscription, d *webhook.Delivery) (webhook.SendResult, error) {
+			calls++
+			if calls == 1 {
+				return webhook.SendResult{StatusCode: 200}, nil
+			}
+			return webhook.SendResult{StatusCode: 503}, nil
+		},
+	}
+
+	policy := webhook.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	useCase := NewProcessDeliveriesUseCase(subscriptions, deliveries, sender, policy)
+
+	output, err := useCase.Execute(context.Background(), ProcessDeliveriesInput{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.Succeeded != 1 || output.Failed != 1 {
+		t.Fatalf("expected 1 succeeded and 1 failed, got %+v", output)
+	}
+
+	if len(deliveries.Updated) != 2 {
+		t.Fatalf("expected both deliveries to be persisted, got %d", len(deliveries.Updated))
+	}
+
+	retried := deliveries.Updated[1]
+
+	if retried.Status != webhook.DeliveryPending || retried.NextAttemptAt.IsZero() {
+		t.Error("expected failed delivery to be rescheduled")
+	}
+
+	if retried.ResponseStatus != 503 {
+		t.Errorf("expected response status 503, got %d", retried.ResponseStatus)
+	}
+}
+
+func TestProcessDeliveriesUseCase_InactiveSubscriptionAbandons(t *testing.T) {
+	subscription, _ := webhook.NewSubscription("https://a.example.com", []category.EventType{category.EventCategoryCreated}, "0123456789abcdef", false)
+
+	subscriptions := &SubscriptionGatewayMock{
+		GetByIDFn: func(id webhook.SubscriptionID) (*webhook.Subscription, error) {
+			return subscription, nil
+		},
+	}
+
+	deliveries := &DeliveryGatewayMock{
+		ClaimDueFn: func(now time.Time, lease time.Duration, limit int) ([]webhook.Delivery, error) {
+			return []webhook.Delivery{newDueDelivery(subscription)}, nil
+		},
+	}
+
+	sender := &SenderMock{
+		SendFn: func(sub *webhook.Subscription, d *webhook.Delivery) (webhook.SendResult, error) {
+			t.Fatal("sender should not be called for inactive subscriptions")
+			return webhook.SendResult{}, nil
+		},
+	}
+
+	useCase := NewProcessDeliveriesUseCase(subscriptions, deliveries, sender, webhook.DefaultRetryPolicy())
+
+	if _, err := useCase.Execute(context.Background(), ProcessDeliveriesInput{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if deliveries.Updated[0].Status != webhook.DeliveryFailed {
+		t.Errorf("expected failed delivery, got %s", deliveries.Updated[0].Status)
+	}
+}
+
+func TestProcessDeliveriesUseCase_GatewayError(t *testing.T) {
+	expectedErr := errors.New("database error")
+
+	deliveries := &DeliveryGatewayMock{
+		ClaimDueFn: func(now time.Time, lease time.Duration, limit int) ([]webhook.Delivery, error) {
+			return nil, expectedErr
+		},
+	}
+
+	useCase := NewProcessDeliveriesUseCase(&SubscriptionGatewayMock{}, deliveries, &SenderMock{}, webhook.DefaultRetryPolicy())
+
+	output, err := useCase.Execute(context.Background(), ProcessDeliveriesInput{})
+
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output != nil {
+		t.Fatal("expected nil output on error")
+	}
+}
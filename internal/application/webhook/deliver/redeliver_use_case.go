@@ -0,0 +1,63 @@
+package deliver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/retrive"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+var ErrDeliveryNotInSubscription = errors.New("delivery does not belong to subscription")
+
+// RedeliverUseCase puts a finished delivery back in the queue; the delivery
+// worker sends it on its next run.
+type RedeliverUseCase struct {
+	DeliveryGateway webhook.DeliveryGateway
+}
+
+type RedeliverInput struct {
+	SubscriptionID string
+	DeliveryID     string
+}
+
+func NewRedeliverUseCase(deliveryGateway webhook.DeliveryGateway) *RedeliverUseCase {
+	return &RedeliverUseCase{DeliveryGateway: deliveryGateway}
+}
+
+func (uc *RedeliverUseCase) Execute(ctx context.Context, input RedeliverInput) (_ *retrive.DeliveryOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "redeliver")
+	defer func() { end(err) }()
+
+	subscriptionID, err := webhook.ParseSubscriptionID(input.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryID, err := webhook.ParseDeliveryID(input.DeliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := uc.DeliveryGateway.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.SubscriptionID != subscriptionID {
+		return nil, ErrDeliveryNotInSubscription
+	}
+
+	if err := delivery.Redeliver(); err != nil {
+		return nil, err
+	}
+
+	delivery, err = uc.DeliveryGateway.UpdateDelivery(ctx, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	output := retrive.ToDeliveryOutput(*delivery)
+	return &output, nil
+}
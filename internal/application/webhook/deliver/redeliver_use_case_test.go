@@ -0,0 +1,91 @@
+package deliver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+func TestRedeliverUseCaseExecute(t *testing.T) {
+	subscription, _ := webhook.NewSubscription("https://a.example.com", []category.EventType{category.EventCategoryCreated}, "0123456789abcdef", true)
+
+	delivery := newDueDelivery(subscription)
+	delivery.Abandon("receiver was down")
+
+	deliveries := &DeliveryGatewayMock{
+		GetByIDFn: func(id webhook.DeliveryID) (*webhook.Delivery, error) {
+			return &delivery, nil
+		},
+	}
+
+	useCase := NewRedeliverUseCase(deliveries)
+
+	output, err := useCase.Execute(context.Background(), RedeliverInput{
+		SubscriptionID: subscription.ID.String(),
+		DeliveryID:     delivery.ID.String(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.Status != string(webhook.DeliveryPending) {
+		t.Errorf("expected the delivery to be queued again, got %s", output.Status)
+	}
+
+	if output.Attempts != 1 {
+		t.Errorf("expected no attempt before the worker picks it up, got %d", output.Attempts)
+	}
+
+	if len(deliveries.Updated) != 1 || deliveries.Updated[0].NextAttemptAt.IsZero() {
+		t.Fatal("expected the delivery to be persisted as due")
+	}
+}
+
+func TestRedeliverUseCase_PendingDelivery(t *testing.T) {
+	subscription, _ := webhook.NewSubscription("https://a.example.com", []category.EventType{category.EventCategoryCreated}, "0123456789abcdef", true)
+	delivery := newDueDelivery(subscription)
+
+	deliveries := &DeliveryGatewayMock{
+		GetByIDFn: func(id webhook.DeliveryID) (*webhook.Delivery, error) {
+			return &delivery, nil
+		},
+	}
+
+	_, err := NewRedeliverUseCase(deliveries).Execute(context.Background(), RedeliverInput{
+		SubscriptionID: subscription.ID.String(),
+		DeliveryID:     delivery.ID.String(),
+	})
+
+	if !errors.Is(err, webhook.ErrDeliveryPending) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deliveries.Updated) != 0 {
+		t.Error("expected a pending delivery to be left alone")
+	}
+}
+
+func TestRedeliverUseCase_DeliveryFromAnotherSubscription(t *testing.T) {
+	subscription, _ := webhook.NewSubscription("https://a.example.com", []category.EventType{category.EventCategoryCreated}, "0123456789abcdef", true)
+	delivery := newDueDelivery(subscription)
+
+	deliveries := &DeliveryGatewayMock{
+		GetByIDFn: func(id webhook.DeliveryID) (*webhook.Delivery, error) {
+			return &delivery, nil
+		},
+	}
+
+	useCase := NewRedeliverUseCase(deliveries)
+
+	_, err := useCase.Execute(context.Background(), RedeliverInput{
+		SubscriptionID: webhook.NewSubscriptionID().String(),
+		DeliveryID:     delivery.ID.String(),
+	})
+
+	if !errors.Is(err, ErrDeliveryNotInSubscription) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
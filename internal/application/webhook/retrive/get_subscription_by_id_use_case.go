@@ -0,0 +1,69 @@
+// Package retrive provides use cases for retrieving webhook subscriptions and their deliveries.
+package retrive
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type GetSubscriptionByIDUseCase struct {
+	Gateway webhook.SubscriptionGateway
+}
+
+type GetSubscriptionByIDInput struct {
+	ID string
+}
+
+type SubscriptionOutput struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func NewGetSubscriptionByIDUseCase(gateway webhook.SubscriptionGateway) *GetSubscriptionByIDUseCase {
+	return &GetSubscriptionByIDUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *GetSubscriptionByIDUseCase) Execute(ctx context.Context, input GetSubscriptionByIDInput) (_ *SubscriptionOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "get_subscription_by_id")
+	defer func() { end(err) }()
+
+	id, err := webhook.ParseSubscriptionID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := uc.Gateway.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toSubscriptionOutput(*subscription)
+	return &output, nil
+}
+
+// toSubscriptionOutput deliberately leaves the secret out: it is write-only
+// once the subscription has been created.
+func toSubscriptionOutput(subscription webhook.Subscription) SubscriptionOutput {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, t := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	return SubscriptionOutput{
+		ID:         subscription.ID.String(),
+		URL:        subscription.URL,
+		EventTypes: eventTypes,
+		IsActive:   subscription.IsActive,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
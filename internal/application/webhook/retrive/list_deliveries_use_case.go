@@ -0,0 +1,96 @@
+package retrive
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type ListDeliveriesUseCase struct {
+	Gateway webhook.DeliveryGateway
+}
+
+type ListDeliveriesInput struct {
+	SubscriptionID string
+	Status         string
+	Page           int
+	PerPage        int
+}
+
+type DeliveryOutput struct {
+	ID             string     `json:"id"`
+	SubscriptionID string     `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func NewListDeliveriesUseCase(gateway webhook.DeliveryGateway) *ListDeliveriesUseCase {
+	return &ListDeliveriesUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *ListDeliveriesUseCase) Execute(ctx context.Context, input ListDeliveriesInput) (_ *pagination.Pagination[DeliveryOutput], err error) {
+	ctx, end := usecase.Observe(ctx, "list_deliveries")
+	defer func() { end(err) }()
+
+	id, err := webhook.ParseSubscriptionID(input.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := uc.Gateway.FindBySubscription(ctx, webhook.SearchDeliveryQuery{
+		SubscriptionID: id,
+		Status:         webhook.DeliveryStatus(input.Status),
+		Page:           input.Page,
+		PerPage:        input.PerPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]DeliveryOutput, 0, len(result.Items))
+	for _, delivery := range result.Items {
+		items = append(items, ToDeliveryOutput(delivery))
+	}
+
+	return &pagination.Pagination[DeliveryOutput]{
+		CurrentPage: result.CurrentPage,
+		PerPage:     result.PerPage,
+		Total:       result.Total,
+		Items:       items,
+	}, nil
+}
+
+func ToDeliveryOutput(delivery webhook.Delivery) DeliveryOutput {
+	return DeliveryOutput{
+		ID:             delivery.ID.String(),
+		SubscriptionID: delivery.SubscriptionID.String(),
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  optionalTime(delivery.NextAttemptAt),
+		LastAttemptAt:  optionalTime(delivery.LastAttemptAt),
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
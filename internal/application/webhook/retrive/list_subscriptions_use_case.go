@@ -0,0 +1,48 @@
+package retrive
+
+import (
+	"context"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type ListSubscriptionsUseCase struct {
+	Gateway webhook.SubscriptionGateway
+}
+
+type ListSubscriptionsInput struct {
+	Page    int
+	PerPage int
+}
+
+func NewListSubscriptionsUseCase(gateway webhook.SubscriptionGateway) *ListSubscriptionsUseCase {
+	return &ListSubscriptionsUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *ListSubscriptionsUseCase) Execute(ctx context.Context, input ListSubscriptionsInput) (_ *pagination.Pagination[SubscriptionOutput], err error) {
+	ctx, end := usecase.Observe(ctx, "list_subscriptions")
+	defer func() { end(err) }()
+
+	result, err := uc.Gateway.FindAll(ctx, webhook.SearchSubscriptionQuery{
+		Page:    input.Page,
+		PerPage: input.PerPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]SubscriptionOutput, 0, len(result.Items))
+	for _, subscription := range result.Items {
+		items = append(items, toSubscriptionOutput(subscription))
+	}
+
+	return &pagination.Pagination[SubscriptionOutput]{
+		CurrentPage: result.CurrentPage,
+		PerPage:     result.PerPage,
+		Total:       result.Total,
+		Items:       items,
+	}, nil
+}
@@ -0,0 +1,66 @@
+// Package update provides use cases for updating webhook subscriptions.
+package update
+
+import (
+	"context"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type UpdateSubscriptionUseCase struct {
+	Gateway webhook.SubscriptionGateway
+}
+
+type UpdateSubscriptionInput struct {
+	ID         string
+	URL        string
+	EventTypes []string
+	Secret     string
+	IsActive   bool
+}
+
+type UpdateSubscriptionOutput struct {
+	ID string
+}
+
+func NewUpdateSubscriptionUseCase(gateway webhook.SubscriptionGateway) *UpdateSubscriptionUseCase {
+	return &UpdateSubscriptionUseCase{
+		Gateway: gateway,
+	}
+}
+
+func (uc *UpdateSubscriptionUseCase) Execute(ctx context.Context, input UpdateSubscriptionInput) (_ *UpdateSubscriptionOutput, err error) {
+	ctx, end := usecase.Observe(ctx, "update_subscription")
+	defer func() { end(err) }()
+
+	id, err := webhook.ParseSubscriptionID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := uc.Gateway.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes := make([]category.EventType, 0, len(input.EventTypes))
+	for _, t := range input.EventTypes {
+		eventTypes = append(eventTypes, category.EventType(t))
+	}
+
+	subscription.Update(input.URL, eventTypes, input.Secret, input.IsActive)
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscription, err = uc.Gateway.UpdateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateSubscriptionOutput{
+		ID: subscription.ID.String(),
+	}, nil
+}
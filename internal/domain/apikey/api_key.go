@@ -0,0 +1,178 @@
+// Package apikey provides domain logic for API keys used by machine clients.
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/validation"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrMalformedToken = errors.New("malformed api key")
+)
+
+const (
+	secretBytes = 32
+	saltBytes   = 16
+)
+
+// APIKey grants a fixed set of permissions to whoever presents its token.
+// Only a salted hash of the secret is kept; the token is shown once, when
+// the key is created.
+type APIKey struct {
+	ID           KeyID
+	Name         string
+	Salt         string
+	Hash         string
+	Permissions  []string
+	AllowedCIDRs []string
+	CreatedAt    time.Time
+	LastUsedAt   time.Time
+	RevokedAt    time.Time
+}
+
+// NewAPIKey creates a key and returns it together with its token, which has
+// the form "<key id>.<secret>".
+func NewAPIKey(name string, permissions, allowedCIDRs []string) (*APIKey, string, error) {
+	secret, err := randomHex(secretBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	salt, err := randomHex(saltBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &APIKey{
+		ID:           NewKeyID(),
+		Name:         strings.TrimSpace(name),
+		Salt:         salt,
+		Hash:         hashSecret(salt, secret),
+		Permissions:  permissions,
+		AllowedCIDRs: allowedCIDRs,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	return key, key.ID.String() + "." + secret, nil
+}
+
+// ParseToken splits a token into the key ID and the secret to verify.
+func ParseToken(token string) (KeyID, string, error) {
+	rawID, secret, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || secret == "" {
+		return KeyID{}, "", ErrMalformedToken
+	}
+
+	id, err := ParseKeyID(rawID)
+	if err != nil {
+		return KeyID{}, "", ErrMalformedToken
+	}
+
+	return id, secret, nil
+}
+
+func (k *APIKey) Verify(secret string) bool {
+	candidate := hashSecret(k.Salt, secret)
+	return subtle.ConstantTimeCompare([]byte(candidate), []byte(k.Hash)) == 1
+}
+
+func (k *APIKey) Revoke() {
+	if k.IsRevoked() {
+		return
+	}
+	k.RevokedAt = time.Now().UTC()
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+// AllowsAddress reports whether the key may be used from addr. Keys without
+// IP ranges can be used from anywhere.
+func (k *APIKey) AllowsAddress(addr netip.Addr) bool {
+	if len(k.AllowedCIDRs) == 0 {
+		return true
+	}
+
+	addr = addr.Unmap()
+
+	for _, cidr := range k.AllowedCIDRs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the key against the permissions an API key may be granted.
+func (k *APIKey) Validate(grantable []string) error {
+	var errs []error
+
+	if k.Name == "" {
+		errs = append(errs, errors.New(
+			"api key validation error: name is required",
+		))
+	}
+
+	if len(k.Permissions) == 0 {
+		errs = append(errs, errors.New(
+			"api key validation error: at least one permission is required",
+		))
+	}
+
+	for _, permission := range k.Permissions {
+		if !contains(grantable, permission) {
+			errs = append(errs, fmt.Errorf(
+				"api key validation error: unknown permission %q", permission,
+			))
+		}
+	}
+
+	for _, cidr := range k.AllowedCIDRs {
+		if _, err := netip.ParsePrefix(cidr); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"api key validation error: invalid IP range %q", cidr,
+			))
+		}
+	}
+
+	if len(errs) > 0 {
+		return validation.ValidationErrors{Errs: errs}
+	}
+
+	return nil
+}
+
+func hashSecret(salt, secret string) string {
+	sum := sha256.Sum256([]byte(salt + secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package apikey
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+var grantable = []string{"catalog:read", "catalog:write"}
+
+func TestNewAPIKey_TokenVerifiesAgainstStoredHash(t *testing.T) {
+	key, token, err := NewAPIKey("sync job", []string{"catalog:read"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(key.Hash, token) || key.Salt == "" {
+		t.Fatal("expected only a salted hash of the secret to be kept")
+	}
+
+	id, secret, err := ParseToken(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if id != key.ID {
+		t.Errorf("expected token to carry key ID %s, got %s", key.ID, id)
+	}
+	if !key.Verify(secret) {
+		t.Error("expected secret to verify")
+	}
+	if key.Verify(secret + "x") {
+		t.Error("expected altered secret to be rejected")
+	}
+
+	other, _, _ := NewAPIKey("sync job", []string{"catalog:read"}, nil)
+	if other.Salt == key.Salt {
+		t.Error("expected every key to get its own salt")
+	}
+}
+
+func TestParseToken_Malformed(t *testing.T) {
+	for _, token := range []string{"", "no-separator", "not-a-uuid.secret", "0190a0c8-7b3e-7000-8000-000000000000."} {
+		if _, _, err := ParseToken(token); !errors.Is(err, ErrMalformedToken) {
+			t.Errorf("expected ErrMalformedToken for %q, got %v", token, err)
+		}
+	}
+}
+
+func TestAPIKey_AllowsAddress(t *testing.T) {
+	key, _, _ := NewAPIKey("sync job", []string{"catalog:read"}, []string{"10.0.0.0/8", "2001:db8::/32"})
+
+	tests := map[string]bool{
+		"10.1.2.3":        true,
+		"::ffff:10.1.2.3": true,
+		"2001:db8::1":     true,
+		"192.168.0.1":     false,
+		"2001:db9::1":     false,
+	}
+
+	for raw, want := range tests {
+		if got := key.AllowsAddress(netip.MustParseAddr(raw)); got != want {
+			t.Errorf("AllowsAddress(%s) = %v, want %v", raw, got, want)
+		}
+	}
+
+	unrestricted, _, _ := NewAPIKey("sync job", []string{"catalog:read"}, nil)
+	if !unrestricted.AllowsAddress(netip.MustParseAddr("192.168.0.1")) {
+		t.Error("expected key without ranges to be usable from anywhere")
+	}
+}
+
+func TestAPIKey_Validate(t *testing.T) {
+	valid, _, _ := NewAPIKey("sync job", []string{"catalog:read"}, []string{"10.0.0.0/8"})
+	if err := valid.Validate(grantable); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid, _, _ := NewAPIKey(" ", []string{"catalog:delete"}, []string{"10.0.0.300/8"})
+	err := invalid.Validate(grantable)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	for _, expected := range []string{"name is required", `unknown permission "catalog:delete"`, "invalid IP range"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in %q", expected, err.Error())
+		}
+	}
+}
+
+func TestAPIKey_Revoke(t *testing.T) {
+	key, _, _ := NewAPIKey("sync job", []string{"catalog:read"}, nil)
+	key.Revoke()
+
+	if !key.IsRevoked() {
+		t.Fatal("expected key to be revoked")
+	}
+
+	revokedAt := key.RevokedAt
+	key.Revoke()
+	if key.RevokedAt != revokedAt {
+		t.Error("expected revoking twice to keep the first revocation time")
+	}
+}
@@ -0,0 +1,22 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type APIKeyGateway interface {
+	CreateAPIKey(ctx context.Context, key *APIKey) (*APIKey, error)
+	GetAPIKeyByID(ctx context.Context, id KeyID) (*APIKey, error)
+	UpdateAPIKey(ctx context.Context, key *APIKey) (*APIKey, error)
+	RecordUse(ctx context.Context, id KeyID, usedAt time.Time) error
+	FindAll(ctx context.Context, query SearchAPIKeyQuery) (*pagination.Pagination[APIKey], error)
+}
+
+type SearchAPIKeyQuery struct {
+	IncludeRevoked bool
+	Page           int
+	PerPage        int
+}
@@ -0,0 +1,22 @@
+package apikey
+
+import "github.com/gofrs/uuid/v5"
+
+type KeyID uuid.UUID
+
+func NewKeyID() KeyID {
+	id, err := uuid.NewV7()
+	if err != nil {
+		panic(err)
+	}
+	return KeyID(id)
+}
+
+func ParseKeyID(value string) (KeyID, error) {
+	id, err := uuid.FromString(value)
+	return KeyID(id), err
+}
+
+func (id KeyID) String() string {
+	return uuid.UUID(id).String()
+}
@@ -0,0 +1,109 @@
+// Package audit provides domain logic for recording who changed what, and when.
+package audit
+
+import (
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+const EntityCategory = "category"
+
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type Entry struct {
+	ID         string
+	EntityType string
+	EntityID   string
+	Action     Action
+	Actor      string
+	RequestID  string
+	// CausedBy is the ID of the entity whose change led to this one, for
+	// changes made as a consequence of another, such as a cascade.
+	CausedBy   string
+	OccurredAt time.Time
+	Changes    []FieldChange
+}
+
+var eventActions = map[category.EventType]Action{
+	category.EventCategoryCreated: ActionCreate,
+	category.EventCategoryUpdated: ActionUpdate,
+	category.EventCategoryDeleted: ActionDelete,
+}
+
+// NewCategoryEntry builds the audit entry for a category event. The event ID
+// is reused as the entry ID so recording the same event twice is detectable.
+func NewCategoryEntry(event category.CategoryEvent) *Entry {
+	entry := &Entry{
+		ID:         event.ID,
+		EntityType: EntityCategory,
+		EntityID:   event.CategoryID.String(),
+		Action:     eventActions[event.Type],
+		Actor:      event.Actor,
+		RequestID:  event.RequestID,
+		OccurredAt: event.OccurredAt,
+		Changes:    DiffCategory(event.Before, event.Category),
+	}
+
+	if event.CausedBy != nil {
+		entry.CausedBy = event.CausedBy.String()
+	}
+
+	return entry
+}
+
+// DiffCategory lists the fields that differ between two states of a category.
+// A nil state stands for "did not exist", so every field shows up on create
+// and delete.
+func DiffCategory(before, after *category.Category) []FieldChange {
+	beforeFields := categoryFields(before)
+	afterFields := categoryFields(after)
+
+	var changes []FieldChange
+	for _, field := range categoryFieldOrder {
+		b, a := beforeFields[field], afterFields[field]
+		if b == a {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+	}
+
+	return changes
+}
+
+var categoryFieldOrder = []string{"name", "slug", "parent_id", "description", "is_active", "deleted_at"}
+
+func categoryFields(cat *category.Category) map[string]any {
+	if cat == nil {
+		return map[string]any{}
+	}
+
+	fields := map[string]any{
+		"name":        cat.Name,
+		"slug":        cat.Slug,
+		"description": cat.Description,
+		"is_active":   cat.IsActive,
+	}
+
+	if cat.ParentID != nil {
+		fields["parent_id"] = cat.ParentID.String()
+	}
+
+	if !cat.DeletedAt.IsZero() {
+		fields["deleted_at"] = cat.DeletedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return fields
+}
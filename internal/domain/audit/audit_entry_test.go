@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+func TestDiffCategory_Create(t *testing.T) {
+	cat, _ := category.NewCategory("Movies", "desc", true)
+
+	changes := DiffCategory(nil, cat)
+
+	if len(changes) != 4 {
+		t.Fatalf("expected 4 changes, got %d: %+v", len(changes), changes)
+	}
+
+	if changes[0].Field != "name" || changes[0].Before != nil || changes[0].After != "Movies" {
+		t.Errorf("unexpected name change: %+v", changes[0])
+	}
+}
+
+func TestDiffCategory_Update(t *testing.T) {
+	cat, _ := category.NewCategory("Movies", "desc", true)
+	before := *cat
+
+	cat.Update("Films", "desc", false)
+
+	changes := DiffCategory(&before, cat)
+
+	fields := map[string]FieldChange{}
+	for _, change := range changes {
+		fields[change.Field] = change
+	}
+
+	if len(fields) != 3 {
+		t.Fatalf("expected name, is_active and deleted_at to change, got %+v", changes)
+	}
+
+	if fields["name"].Before != "Movies" || fields["name"].After != "Films" {
+		t.Errorf("unexpected name change: %+v", fields["name"])
+	}
+
+	if fields["is_active"].Before != true || fields["is_active"].After != false {
+		t.Errorf("unexpected is_active change: %+v", fields["is_active"])
+	}
+
+	if _, ok := fields["description"]; ok {
+		t.Error("unchanged description should not be listed")
+	}
+
+	if fields["deleted_at"].Before != nil || fields["deleted_at"].After == nil {
+		t.Errorf("unexpected deleted_at change: %+v", fields["deleted_at"])
+	}
+}
+
+func TestDiffCategory_SlugChange(t *testing.T) {
+	cat, _ := category.NewCategory("Movies", "desc", true)
+	before := *cat
+
+	cat.ChangeSlug("films")
+
+	changes := DiffCategory(&before, cat)
+
+	if len(changes) != 1 || changes[0].Field != "slug" || changes[0].Before != "movies" || changes[0].After != "films" {
+		t.Errorf("expected only the slug to change, got %+v", changes)
+	}
+}
+
+func TestDiffCategory_Move(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "", true)
+	cat, _ := category.NewCategory("Action", "desc", true)
+	before := *cat
+
+	cat.MoveTo(&movies.ID)
+
+	changes := DiffCategory(&before, cat)
+
+	if len(changes) != 1 || changes[0].Field != "parent_id" || changes[0].Before != nil || changes[0].After != movies.ID.String() {
+		t.Errorf("expected only the parent to change, got %+v", changes)
+	}
+}
+
+func TestNewCategoryEntry_CausedBy(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "", true)
+	cat, _ := category.NewCategory("Action", "desc", true)
+	before := *cat
+	cat.Deactivate()
+
+	entry := NewCategoryEntry(category.NewCategoryEvent(category.EventCategoryUpdated, &before, cat).WithCause(movies.ID))
+
+	if entry.CausedBy != movies.ID.String() {
+		t.Errorf("expected the entry to name the cascading category, got %q", entry.CausedBy)
+	}
+}
+
+func TestNewCategoryEntry(t *testing.T) {
+	cat, _ := category.NewCategory("Movies", "desc", true)
+
+	event := category.NewCategoryEvent(category.EventCategoryDeleted, cat, nil).
+		WithMetadata("editor@example.com", "req-1")
+
+	entry := NewCategoryEntry(event)
+
+	if entry.ID != event.ID {
+		t.Error("expected entry ID to reuse the event ID")
+	}
+
+	if entry.Action != ActionDelete {
+		t.Errorf("expected delete action, got %s", entry.Action)
+	}
+
+	if entry.EntityType != EntityCategory || entry.EntityID != cat.ID.String() {
+		t.Errorf("unexpected entity %s/%s", entry.EntityType, entry.EntityID)
+	}
+
+	if entry.Actor != "editor@example.com" || entry.RequestID != "req-1" {
+		t.Errorf("unexpected metadata: actor %q request %q", entry.Actor, entry.RequestID)
+	}
+
+	for _, change := range entry.Changes {
+		if change.After != nil {
+			t.Errorf("expected every field to be cleared on delete, got %+v", change)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type AuditGateway interface {
+	// Record stores entry. Recording an entry whose ID is already stored
+	// does nothing, so a write that is retried is never duplicated.
+	Record(ctx context.Context, entry *Entry) error
+	FindByEntity(ctx context.Context, query SearchAuditQuery) (*pagination.Pagination[Entry], error)
+}
+
+// OutboxGateway keeps the entries whose write to the audit log failed, so
+// they outlive the process until they are recorded.
+type OutboxGateway interface {
+	// Hold stores pending, replacing the one held for the same entry.
+	Hold(ctx context.Context, pending *PendingEntry) error
+	// FindDue returns up to limit entries whose next attempt is due at now,
+	// the earliest first.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]PendingEntry, error)
+	// Release removes the entry with the given ID.
+	Release(ctx context.Context, id string) error
+}
+
+// PendingEntry is an entry waiting for another attempt at being recorded.
+type PendingEntry struct {
+	Entry         Entry
+	Attempts      int
+	NextAttemptAt time.Time
+}
+
+type SearchAuditQuery struct {
+	EntityType string
+	EntityID   string
+	Page       int
+	PerPage    int
+}
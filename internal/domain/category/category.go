@@ -9,22 +9,32 @@
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/validation"
 )
 
+var ErrCategoryNotFound = errors.New("category not found")
+
 type Category struct {
 	ID          CategoryID
 	Name        string
+	Slug        string
+	ParentID    *CategoryID
 	Description string
 	IsActive    bool
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   time.Time
+	// Version counts the changes applied to the category. Gateways that
+	// support optimistic concurrency use it; zero means "not tracked".
+	Version int `json:"-"`
 }
 
 func NewCategory(name, description string, isActive bool) (*Category, error) {
 	now := time.Now().UTC()
 
+	id := NewCategoryID()
+
 	category := &Category{
-		ID:          NewCategoryID(),
+		ID:          id,
 		Name:        name,
+		Slug:        defaultSlug(name, id),
 		Description: description,
 		IsActive:    isActive,
 		CreatedAt:   now,
@@ -83,6 +93,10 @@ func (c *Category) Validate() error {
 		))
 	}
 
+	if err := validateSlug(c.Slug); err != nil {
+		errs = append(errs, err)
+	}
+
 	if len(errs) > 0 {
 		return validation.ValidationErrors{Errs: errs}
 	}
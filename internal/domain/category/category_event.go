@@ -0,0 +1,143 @@
+package category
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type EventType string
+
+const (
+	EventCategoryCreated EventType = "category.created"
+	EventCategoryUpdated EventType = "category.updated"
+	EventCategoryDeleted EventType = "category.deleted"
+)
+
+func EventTypes() []EventType {
+	return []EventType{
+		EventCategoryCreated,
+		EventCategoryUpdated,
+		EventCategoryDeleted,
+	}
+}
+
+func (t EventType) IsValid() bool {
+	for _, known := range EventTypes() {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// CategoryEvent describes a change to a category. Before is nil for
+// creations and Category (the state after the change) is nil for deletions.
+type CategoryEvent struct {
+	ID         string
+	Type       EventType
+	CategoryID CategoryID
+	OccurredAt time.Time
+	Before     *Category
+	Category   *Category
+	Actor      string
+	RequestID  string
+	// CausedBy is the category whose change this one followed from, such
+	// as the parent whose deactivation deactivated this category.
+	CausedBy *CategoryID
+}
+
+func NewCategoryEvent(eventType EventType, before, after *Category) CategoryEvent {
+	id, err := uuid.NewV7()
+	if err != nil {
+		panic(err)
+	}
+
+	event := CategoryEvent{
+		ID:         id.String(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Before:     before,
+		Category:   after,
+	}
+
+	if after != nil {
+		event.CategoryID = after.ID
+	} else if before != nil {
+		event.CategoryID = before.ID
+	}
+
+	return event
+}
+
+func (e CategoryEvent) WithMetadata(actor, requestID string) CategoryEvent {
+	e.Actor = actor
+	e.RequestID = requestID
+	return e
+}
+
+// WithCause records the category whose change led to this event.
+func (e CategoryEvent) WithCause(id CategoryID) CategoryEvent {
+	e.CausedBy = &id
+	return e
+}
+
+type eventPayload struct {
+	ID         string       `json:"id"`
+	Type       EventType    `json:"type"`
+	OccurredAt time.Time    `json:"occurred_at"`
+	Data       categoryData `json:"data"`
+}
+
+// categoryData always carries parent_id, null for root categories, so that
+// a move to the root shows up in the payload.
+type categoryData struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name,omitempty"`
+	Slug        string     `json:"slug,omitempty"`
+	ParentID    *string    `json:"parent_id"`
+	Description string     `json:"description,omitempty"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+// MarshalJSON renders the event in the envelope shared by every outbound
+// channel (webhooks, server-sent events). Deleted events only carry the ID.
+func (e CategoryEvent) MarshalJSON() ([]byte, error) {
+	data := categoryData{ID: e.CategoryID.String()}
+
+	if cat := e.Category; cat != nil {
+		data.Name = cat.Name
+		data.Slug = cat.Slug
+		if cat.ParentID != nil {
+			parentID := cat.ParentID.String()
+			data.ParentID = &parentID
+		}
+		data.Description = cat.Description
+		data.IsActive = cat.IsActive
+		data.CreatedAt = optionalTime(cat.CreatedAt)
+		data.UpdatedAt = optionalTime(cat.UpdatedAt)
+		data.DeletedAt = optionalTime(cat.DeletedAt)
+	}
+
+	return json.Marshal(eventPayload{
+		ID:         e.ID,
+		Type:       e.Type,
+		OccurredAt: e.OccurredAt,
+		Data:       data,
+	})
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+type EventPublisher interface {
+	Publish(event CategoryEvent)
+}
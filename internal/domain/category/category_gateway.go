@@ -1,13 +1,33 @@
 package category
 
-import "github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
 
 type CategoryGateway interface {
-	CreateCategory(category *Category) (*Category, error)
-	GetCategoryByID(id CategoryID) (*Category, error)
-	UpdateCategory(category *Category) (*Category, error)
-	DeleteCategory(id CategoryID) error
-	FindAll(query SearchCategoryQuery) (*pagination.Pagination[Category], error)
+	CreateCategory(ctx context.Context, category *Category) (*Category, error)
+	GetCategoryByID(ctx context.Context, id CategoryID) (*Category, error)
+	UpdateCategory(ctx context.Context, category *Category) (*Category, error)
+	DeleteCategory(ctx context.Context, id CategoryID) error
+	// FindCategoryByName compares names by NormalizeName and returns
+	// ErrCategoryNotFound when no category has the name.
+	FindCategoryByName(ctx context.Context, name string) (*Category, error)
+	// FindCategoryBySlug resolves a current slug or an alias left by a
+	// slug change, and returns ErrCategoryNotFound for anything else.
+	FindCategoryBySlug(ctx context.Context, slug string) (*Category, error)
+	// FindChildren returns the categories whose parent is parentID, ordered
+	// by name.
+	FindChildren(ctx context.Context, parentID CategoryID) ([]Category, error)
+	FindAll(ctx context.Context, query SearchCategoryQuery) (*pagination.Pagination[Category], error)
+}
+
+// CategoryStreamer visits every category matching a query, in order,
+// without loading them all at once. Page and PerPage are ignored.
+type CategoryStreamer interface {
+	StreamCategories(ctx context.Context, query SearchCategoryQuery, visit func(Category) error) error
 }
 
 type SearchCategoryQuery struct {
@@ -17,3 +37,16 @@ type SearchCategoryQuery struct {
 	Sort      string
 	Direction string
 }
+
+type CategoryRevisionGateway interface {
+	AppendRevision(ctx context.Context, revision *CategoryRevision) (*CategoryRevision, error)
+	GetRevision(ctx context.Context, id CategoryID, revision int) (*CategoryRevision, error)
+	GetRevisionAsOf(ctx context.Context, id CategoryID, at time.Time) (*CategoryRevision, error)
+	FindRevisions(ctx context.Context, query SearchRevisionQuery) (*pagination.Pagination[CategoryRevision], error)
+}
+
+type SearchRevisionQuery struct {
+	CategoryID CategoryID
+	Page       int
+	PerPage    int
+}
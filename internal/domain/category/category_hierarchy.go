@@ -0,0 +1,206 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/validation"
+)
+
+// MaxDepth is the number of levels a category tree may have; a root
+// category is at level 1.
+const MaxDepth = 5
+
+// Hierarchy rules. An active category never sits below an inactive one:
+// a category can only be active while its parent is, deactivating a category
+// deactivates everything below it, and activating one leaves its descendants
+// as they are. A category with children cannot be deleted.
+var (
+	ErrParentNotFound      = errors.New("parent category not found")
+	ErrCategoryCycle       = errors.New("category cannot be its own ancestor")
+	ErrHierarchyTooDeep    = fmt.Errorf("category tree cannot be more than %d levels deep", MaxDepth)
+	ErrParentInactive      = errors.New("category cannot be active under an inactive parent")
+	ErrCategoryHasChildren = errors.New("category has children")
+)
+
+// MoveTo sets the parent; nil makes the category a root.
+func (c *Category) MoveTo(parentID *CategoryID) {
+	c.ParentID = parentID
+	c.UpdatedAt = time.Now()
+}
+
+// ParseParentID reads an optional parent reference; "" means no parent.
+func ParseParentID(value string) (*CategoryID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := ParseCategoryID(value)
+	if err != nil {
+		return nil, validation.ValidationErrors{Errs: []error{
+			fmt.Errorf("category validation error: parent_id %q is not a valid category id", value),
+		}}
+	}
+	return &id, nil
+}
+
+// Ancestors returns the categories above cat, from its parent up to the
+// root. It stops with ErrCategoryCycle or ErrHierarchyTooDeep instead of
+// following a chain that loops or runs past MaxDepth.
+func Ancestors(ctx context.Context, gateway CategoryGateway, cat *Category) ([]Category, error) {
+	var chain []Category
+
+	for parentID := cat.ParentID; parentID != nil; {
+		if *parentID == cat.ID {
+			return nil, ErrCategoryCycle
+		}
+		if len(chain) == MaxDepth {
+			return nil, ErrHierarchyTooDeep
+		}
+
+		parent, err := gateway.GetCategoryByID(ctx, *parentID)
+		if errors.Is(err, ErrCategoryNotFound) && len(chain) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrParentNotFound, parentID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		chain = append(chain, *parent)
+		parentID = parent.ParentID
+	}
+
+	return chain, nil
+}
+
+// Descendants returns every category below id, level by level.
+func Descendants(ctx context.Context, gateway CategoryGateway, id CategoryID) ([]Category, error) {
+	var descendants []Category
+
+	level := []CategoryID{id}
+	for depth := 0; len(level) > 0; depth++ {
+		if depth == MaxDepth {
+			return nil, ErrHierarchyTooDeep
+		}
+
+		var next []CategoryID
+		for _, parentID := range level {
+			children, err := gateway.FindChildren(ctx, parentID)
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range children {
+				descendants = append(descendants, child)
+				next = append(next, child.ID)
+			}
+		}
+		level = next
+	}
+
+	return descendants, nil
+}
+
+// EnsureValidParent checks cat against the hierarchy rules: its parent must
+// exist, must not be cat or below it, must leave the tree at most MaxDepth
+// levels deep and must be active when cat is. Violations are
+// validation.ValidationErrors.
+func EnsureValidParent(ctx context.Context, gateway CategoryGateway, cat *Category) error {
+	if cat.ParentID == nil {
+		return nil
+	}
+
+	ancestors, err := Ancestors(ctx, gateway, cat)
+	if err != nil {
+		return hierarchyError(err)
+	}
+
+	height, err := subtreeHeight(ctx, gateway, cat.ID)
+	if err != nil {
+		return hierarchyError(err)
+	}
+
+	if len(ancestors)+1+height > MaxDepth {
+		return hierarchyError(ErrHierarchyTooDeep)
+	}
+	if cat.IsActive && !ancestors[0].IsActive {
+		return hierarchyError(ErrParentInactive)
+	}
+
+	return nil
+}
+
+// subtreeHeight counts the levels below id, failing with ErrCategoryCycle
+// when id shows up among them.
+func subtreeHeight(ctx context.Context, gateway CategoryGateway, id CategoryID) (int, error) {
+	descendants, err := Descendants(ctx, gateway, id)
+	if err != nil {
+		return 0, err
+	}
+
+	levels := map[CategoryID]int{id: 0}
+	height := 0
+	for _, descendant := range descendants {
+		if descendant.ID == id {
+			return 0, ErrCategoryCycle
+		}
+		level := levels[*descendant.ParentID] + 1
+		levels[descendant.ID] = level
+		height = max(height, level)
+	}
+
+	return height, nil
+}
+
+// hierarchyError reports rule violations as validation errors and passes
+// anything else, such as a failing gateway, through.
+func hierarchyError(err error) error {
+	for _, rule := range []error{ErrParentNotFound, ErrCategoryCycle, ErrHierarchyTooDeep, ErrParentInactive} {
+		if errors.Is(err, rule) {
+			return validation.ValidationErrors{Errs: []error{err}}
+		}
+	}
+	return err
+}
+
+// DeactivateDescendants deactivates the active categories below id, deepest
+// first, so that no active category is left under an inactive one even if
+// a write fails halfway. It returns an event for each category it changed,
+// caused by id, including when it stops on an error.
+func DeactivateDescendants(ctx context.Context, gateway CategoryGateway, id CategoryID) ([]CategoryEvent, error) {
+	descendants, err := Descendants(ctx, gateway, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []CategoryEvent
+	for i := len(descendants) - 1; i >= 0; i-- {
+		cat := descendants[i]
+		if !cat.IsActive {
+			continue
+		}
+
+		before := cat
+		cat.Deactivate()
+		if _, err := gateway.UpdateCategory(ctx, &cat); err != nil {
+			return events, err
+		}
+		events = append(events, NewCategoryEvent(EventCategoryUpdated, &before, &cat).WithCause(id))
+	}
+
+	return events, nil
+}
+
+// EnsureNoChildren returns ErrCategoryHasChildren when categories sit below
+// id.
+func EnsureNoChildren(ctx context.Context, gateway CategoryGateway, id CategoryID) error {
+	children, err := gateway.FindChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return fmt.Errorf("%w: %d categories below %s", ErrCategoryHasChildren, len(children), id)
+	}
+	return nil
+}
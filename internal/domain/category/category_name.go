@@ -0,0 +1,49 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNameConflict is matched by every NameConflictError.
+var ErrNameConflict = errors.New("category name already in use")
+
+// NameConflictError reports the category that already has a name.
+type NameConflictError struct {
+	Name       string
+	ExistingID CategoryID
+}
+
+func (e *NameConflictError) Error() string {
+	return fmt.Sprintf("category name %q is already used by category %s", e.Name, e.ExistingID)
+}
+
+func (e *NameConflictError) Unwrap() error {
+	return ErrNameConflict
+}
+
+// NormalizeName is the form in which names must be unique: trimmed,
+// lowercased and with inner runs of whitespace collapsed to one space, so
+// "Filmes", " filmes " and "FILMES" are the same name.
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// EnsureNameAvailable returns a NameConflictError when a category other than
+// cat already has its name.
+func EnsureNameAvailable(ctx context.Context, gateway CategoryGateway, cat *Category) error {
+	existing, err := gateway.FindCategoryByName(ctx, cat.Name)
+	if errors.Is(err, ErrCategoryNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if existing.ID != cat.ID {
+		return &NameConflictError{Name: cat.Name, ExistingID: existing.ID}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrRevisionNotFound = errors.New("category revision not found")
+
+// CategoryRevision is an immutable snapshot of a category taken after each
+// change. Revision numbers start at 1 and grow by one per category.
+type CategoryRevision struct {
+	CategoryID  CategoryID
+	Revision    int
+	EventID     string
+	Name        string
+	Slug        string
+	ParentID    *CategoryID
+	Description string
+	IsActive    bool
+	DeletedAt   time.Time
+	Actor       string
+	RecordedAt  time.Time
+}
+
+func NewCategoryRevision(cat *Category, eventID, actor string, recordedAt time.Time) *CategoryRevision {
+	return &CategoryRevision{
+		CategoryID:  cat.ID,
+		EventID:     eventID,
+		Name:        cat.Name,
+		Slug:        cat.Slug,
+		ParentID:    cat.ParentID,
+		Description: cat.Description,
+		IsActive:    cat.IsActive,
+		DeletedAt:   cat.DeletedAt,
+		Actor:       actor,
+		RecordedAt:  recordedAt,
+	}
+}
+
+// RecordRevisions snapshots the state each event left behind. Use cases call
+// it inside the transaction that made the changes, so a change is never
+// stored without its revision. Deletions leave nothing to snapshot, and the
+// earlier revisions are kept.
+func RecordRevisions(ctx context.Context, revisions CategoryRevisionGateway, events ...CategoryEvent) error {
+	for _, event := range events {
+		if event.Category == nil {
+			continue
+		}
+
+		revision := NewCategoryRevision(event.Category, event.ID, event.Actor, event.OccurredAt)
+		if _, err := revisions.AppendRevision(ctx, revision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
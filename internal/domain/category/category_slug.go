@@ -0,0 +1,145 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength bounds slugs, including the suffix added to make a
+// generated one unique.
+const MaxSlugLength = 100
+
+// maxSlugAttempts bounds the suffixes tried by AssignUniqueSlug.
+const maxSlugAttempts = 100
+
+// ErrSlugConflict is matched by every SlugConflictError.
+var ErrSlugConflict = errors.New("category slug already in use")
+
+// SlugConflictError reports the category that already resolves a slug,
+// either as its slug or as an alias kept from an earlier one.
+type SlugConflictError struct {
+	Slug       string
+	ExistingID CategoryID
+}
+
+func (e *SlugConflictError) Error() string {
+	return fmt.Sprintf("category slug %q is already used by category %s", e.Slug, e.ExistingID)
+}
+
+func (e *SlugConflictError) Unwrap() error {
+	return ErrSlugConflict
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// transliterations covers the letters that do not decompose into an ASCII
+// letter and combining marks.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th",
+}
+
+// Slugify turns a name into a slug: accents are dropped ("Ação" becomes
+// "acao"), other runs of characters become single hyphens and the result is
+// cut to leave room for a uniqueness suffix. It returns "" when nothing in
+// the name can be transliterated.
+func Slugify(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+
+	for _, r := range norm.NFD.String(strings.ToLower(name)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		s, ok := transliterations[r]
+		switch {
+		case ok:
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			s = string(r)
+		default:
+			pendingHyphen = true
+			continue
+		}
+
+		if pendingHyphen && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		pendingHyphen = false
+		b.WriteString(s)
+	}
+
+	slug := b.String()
+	if limit := MaxSlugLength - 10; len(slug) > limit {
+		slug = strings.TrimRight(slug[:limit], "-")
+	}
+	return slug
+}
+
+// defaultSlug is the slug a new category starts with; names with nothing to
+// transliterate fall back to the start of the ID.
+func defaultSlug(name string, id CategoryID) string {
+	if slug := Slugify(name); slug != "" {
+		return slug
+	}
+	return id.String()[:8]
+}
+
+// ChangeSlug replaces the slug. Gateways keep the previous one as an alias.
+func (c *Category) ChangeSlug(slug string) {
+	c.Slug = slug
+	c.UpdatedAt = time.Now()
+}
+
+func validateSlug(slug string) error {
+	if len(slug) > MaxSlugLength || !slugPattern.MatchString(slug) {
+		return fmt.Errorf(
+			"category validation error: slug must be at most %d lowercase letters, digits and single hyphens",
+			MaxSlugLength,
+		)
+	}
+	return nil
+}
+
+// EnsureSlugAvailable returns a SlugConflictError when a category other than
+// cat resolves its slug.
+func EnsureSlugAvailable(ctx context.Context, gateway CategoryGateway, cat *Category) error {
+	existing, err := gateway.FindCategoryBySlug(ctx, cat.Slug)
+	if errors.Is(err, ErrCategoryNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if existing.ID != cat.ID {
+		return &SlugConflictError{Slug: cat.Slug, ExistingID: existing.ID}
+	}
+	return nil
+}
+
+// AssignUniqueSlug gives cat the first free slug among its name's slug and
+// that slug followed by -2, -3 and so on.
+func AssignUniqueSlug(ctx context.Context, gateway CategoryGateway, cat *Category) error {
+	base := defaultSlug(cat.Name, cat.ID)
+
+	for attempt := 1; attempt <= maxSlugAttempts; attempt++ {
+		cat.Slug = base
+		if attempt > 1 {
+			cat.Slug = fmt.Sprintf("%s-%d", base, attempt)
+		}
+
+		err := EnsureSlugAvailable(ctx, gateway, cat)
+		if !errors.Is(err, ErrSlugConflict) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w: no free slug for %q", ErrSlugConflict, cat.Name)
+}
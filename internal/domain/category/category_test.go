@@ -1,6 +1,7 @@
 package category
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -228,3 +229,129 @@ func TestCategoryDeactivate(t *testing.T) {
 		t.Error("UpdatedAt timestamp is invalid")
 	}
 }
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Filmes", "filmes"},
+		{"  FILMES ", "filmes"},
+		{"Filmes  de\tAção", "filmes de ação"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeName(tt.name); got != tt.want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNameConflictErrorMatchesSentinel(t *testing.T) {
+	id := NewCategoryID()
+	var err error = &NameConflictError{Name: "Filmes", ExistingID: id}
+
+	if !errors.Is(err, ErrNameConflict) {
+		t.Error("expected the error to match ErrNameConflict")
+	}
+
+	var conflict *NameConflictError
+	if !errors.As(err, &conflict) || conflict.ExistingID != id {
+		t.Errorf("expected the existing ID %s, got %v", id, err)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Ação e Aventura", "acao-e-aventura"},
+		{"  Sci-Fi & Fantasy!  ", "sci-fi-fantasy"},
+		{"Straße", "strasse"},
+		{"Anos 80", "anos-80"},
+		{"日本", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.name); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNewCategorySlug(t *testing.T) {
+	cat, _ := NewCategory("Documentários", "", true)
+	if cat.Slug != "documentarios" {
+		t.Errorf("expected slug documentarios, got %q", cat.Slug)
+	}
+
+	cat, _ = NewCategory("日本", "", true)
+	if cat.Slug != cat.ID.String()[:8] {
+		t.Errorf("expected the slug to fall back to the ID, got %q", cat.Slug)
+	}
+}
+
+func TestCategoryValidateSlug(t *testing.T) {
+	for _, slug := range []string{"", "Movies", "movies--2", "-movies", "filmes/acao"} {
+		cat, _ := NewCategory("Movies", "", true)
+		cat.Slug = slug
+
+		var errs validation.ValidationErrors
+		if err := cat.Validate(); !errors.As(err, &errs) {
+			t.Errorf("expected slug %q to be invalid, got %v", slug, err)
+		}
+	}
+}
+
+func TestCategoryEventMarshalJSONCarriesParent(t *testing.T) {
+	movies, _ := NewCategory("Movies", "", true)
+	cat, _ := NewCategory("Action", "", true)
+	before := *cat
+	cat.MoveTo(&movies.ID)
+
+	for _, tc := range []struct {
+		event CategoryEvent
+		want  any
+	}{
+		{NewCategoryEvent(EventCategoryUpdated, &before, cat), movies.ID.String()},
+		{NewCategoryEvent(EventCategoryUpdated, cat, &before), nil},
+	} {
+		raw, err := json.Marshal(tc.event)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var payload struct {
+			Data map[string]any `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			t.Fatal(err)
+		}
+		parentID, ok := payload.Data["parent_id"]
+		if !ok || parentID != tc.want {
+			t.Errorf("expected parent_id %v, got %v", tc.want, payload.Data)
+		}
+	}
+}
+
+func TestCategoryEventMarshalJSONCarriesSlug(t *testing.T) {
+	cat, _ := NewCategory("Ação", "", true)
+	before := *cat
+	cat.ChangeSlug("action")
+
+	raw, err := json.Marshal(NewCategoryEvent(EventCategoryUpdated, &before, cat))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Data["slug"] != "action" {
+		t.Errorf("expected the new slug in the payload, got %v", payload.Data)
+	}
+}
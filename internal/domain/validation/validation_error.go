@@ -10,3 +10,8 @@ type ValidationErrors struct {
 func (v ValidationErrors) Error() string {
 	return errors.Join(v.Errs...).Error()
 }
+
+// Unwrap lets errors.Is and errors.As look at each validation error.
+func (v ValidationErrors) Unwrap() []error {
+	return v.Errs
+}
@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+// ErrDeliveryPending is returned when redelivering a delivery that is still
+// waiting for an attempt.
+var ErrDeliveryPending = errors.New("delivery is still pending")
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+type Delivery struct {
+	ID             DeliveryID
+	SubscriptionID SubscriptionID
+	EventID        string
+	EventType      category.EventType
+	Payload        []byte
+	Status         DeliveryStatus
+	// Attempts counts every attempt, across redeliveries.
+	Attempts int
+	// AttemptOffset is the number of attempts made before the last
+	// redelivery; the retry policy only counts the attempts after it.
+	AttemptOffset  int
+	ResponseStatus int
+	LastError      string
+	NextAttemptAt  time.Time
+	LastAttemptAt  time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewDelivery(subscriptionID SubscriptionID, eventID string, eventType category.EventType, payload []byte) *Delivery {
+	now := time.Now().UTC()
+
+	return &Delivery{
+		ID:             NewDeliveryID(),
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         DeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func (d *Delivery) RecordSuccess(responseStatus int) {
+	now := time.Now().UTC()
+
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.ResponseStatus = responseStatus
+	d.LastError = ""
+	d.LastAttemptAt = now
+	d.NextAttemptAt = time.Time{}
+	d.UpdatedAt = now
+}
+
+func (d *Delivery) RecordFailure(responseStatus int, reason string, policy RetryPolicy) {
+	now := time.Now().UTC()
+
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.LastError = reason
+	d.LastAttemptAt = now
+	d.UpdatedAt = now
+
+	scheduled := d.Attempts - d.AttemptOffset
+	if scheduled >= policy.MaxAttempts {
+		d.Status = DeliveryFailed
+		d.NextAttemptAt = time.Time{}
+		return
+	}
+
+	d.Status = DeliveryPending
+	d.NextAttemptAt = now.Add(policy.Backoff(scheduled))
+}
+
+func (d *Delivery) Abandon(reason string) {
+	now := time.Now().UTC()
+
+	d.Attempts++
+	d.Status = DeliveryFailed
+	d.ResponseStatus = 0
+	d.LastError = reason
+	d.LastAttemptAt = now
+	d.NextAttemptAt = time.Time{}
+	d.UpdatedAt = now
+}
+
+// Redeliver makes a succeeded or failed delivery due now with a fresh retry
+// schedule, keeping the attempts already made. A pending delivery may be in
+// the hands of a worker, so it is left alone.
+func (d *Delivery) Redeliver() error {
+	if d.Status == DeliveryPending {
+		return ErrDeliveryPending
+	}
+
+	now := time.Now().UTC()
+
+	d.Status = DeliveryPending
+	d.AttemptOffset = d.Attempts
+	d.NextAttemptAt = now
+	d.UpdatedAt = now
+	return nil
+}
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 8,
+		BaseDelay:   30 * time.Second,
+		MaxDelay:    6 * time.Hour,
+	}
+}
+
+// Backoff returns the delay before the next attempt once the given number of
+// attempts has been made, doubling from BaseDelay and capped at MaxDelay.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
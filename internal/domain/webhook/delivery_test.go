@@ -0,0 +1,160 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{30, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Backoff(tt.attempts); got != tt.expected {
+			t.Errorf("attempt %d: expected %v, got %v", tt.attempts, tt.expected, got)
+		}
+	}
+}
+
+func TestDeliveryRecordFailure_SchedulesRetryUntilCap(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	delivery := NewDelivery(NewSubscriptionID(), "event-id", category.EventCategoryCreated, []byte(`{}`))
+
+	before := time.Now()
+
+	delivery.RecordFailure(500, "boom", policy)
+
+	if delivery.Status != DeliveryPending {
+		t.Fatalf("expected pending after first failure, got %s", delivery.Status)
+	}
+
+	if delivery.NextAttemptAt.Before(before.Add(time.Minute)) {
+		t.Error("expected next attempt to be scheduled after the base delay")
+	}
+
+	delivery.RecordFailure(500, "boom", policy)
+	delivery.RecordFailure(502, "bad gateway", policy)
+
+	if delivery.Status != DeliveryFailed {
+		t.Fatalf("expected failed after max attempts, got %s", delivery.Status)
+	}
+
+	if delivery.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", delivery.Attempts)
+	}
+
+	if !delivery.NextAttemptAt.IsZero() {
+		t.Error("failed delivery should not have a next attempt")
+	}
+
+	if delivery.ResponseStatus != 502 || delivery.LastError != "bad gateway" {
+		t.Error("expected last response to be recorded")
+	}
+}
+
+func TestDeliveryRecordSuccess(t *testing.T) {
+	delivery := NewDelivery(NewSubscriptionID(), "event-id", category.EventCategoryCreated, []byte(`{}`))
+	delivery.RecordFailure(500, "boom", DefaultRetryPolicy())
+
+	delivery.RecordSuccess(204)
+
+	if delivery.Status != DeliverySucceeded {
+		t.Fatalf("expected succeeded, got %s", delivery.Status)
+	}
+
+	if delivery.LastError != "" {
+		t.Error("expected last error to be cleared")
+	}
+
+	if delivery.Attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", delivery.Attempts)
+	}
+}
+
+func TestDeliveryRedeliver(t *testing.T) {
+	delivery := NewDelivery(NewSubscriptionID(), "event-id", category.EventCategoryCreated, []byte(`{}`))
+	delivery.Abandon("subscription is inactive")
+
+	if err := delivery.Redeliver(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if delivery.Status != DeliveryPending {
+		t.Fatalf("expected pending, got %s", delivery.Status)
+	}
+
+	if delivery.Attempts != 1 {
+		t.Errorf("expected the earlier attempt to be kept, got %d", delivery.Attempts)
+	}
+
+	if delivery.NextAttemptAt.IsZero() {
+		t.Error("expected delivery to be due again")
+	}
+}
+
+func TestDeliveryRedeliver_RefusesPending(t *testing.T) {
+	delivery := NewDelivery(NewSubscriptionID(), "event-id", category.EventCategoryCreated, []byte(`{}`))
+
+	if err := delivery.Redeliver(); !errors.Is(err, ErrDeliveryPending) {
+		t.Fatalf("expected ErrDeliveryPending, got %v", err)
+	}
+}
+
+func TestDeliveryRedeliver_RestartsRetrySchedule(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	delivery := NewDelivery(NewSubscriptionID(), "event-id", category.EventCategoryCreated, []byte(`{}`))
+	delivery.RecordFailure(500, "boom", policy)
+	delivery.RecordFailure(500, "boom", policy)
+
+	if err := delivery.Redeliver(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	before := time.Now()
+	delivery.RecordFailure(500, "boom", policy)
+
+	if delivery.Status != DeliveryPending {
+		t.Fatalf("expected a retry after the first failure since redelivery, got %s", delivery.Status)
+	}
+	if delivery.NextAttemptAt.Before(before.Add(time.Minute)) || delivery.NextAttemptAt.After(before.Add(2*time.Minute)) {
+		t.Errorf("expected the backoff to restart from the base delay, got %s", delivery.NextAttemptAt.Sub(before))
+	}
+
+	delivery.RecordFailure(500, "boom", policy)
+
+	if delivery.Status != DeliveryFailed || delivery.Attempts != 4 {
+		t.Errorf("expected failed after 4 attempts in total, got %s after %d", delivery.Status, delivery.Attempts)
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	payload := []byte(`{"id":"1"}`)
+
+	signature := Sign(validSecret, 1700000000, payload)
+
+	if !VerifySignature(validSecret, 1700000000, payload, signature) {
+		t.Fatal("expected signature to verify")
+	}
+
+	if VerifySignature(validSecret, 1700000001, payload, signature) {
+		t.Error("signature should not verify with a different timestamp")
+	}
+
+	if VerifySignature("another-secret-value", 1700000000, payload, signature) {
+		t.Error("signature should not verify with a different secret")
+	}
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+const SignaturePrefix = "sha256="
+
+// Sign computes the HMAC-SHA256 signature sent with every delivery. The
+// timestamp is part of the signed content so receivers can reject replays.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifySignature(secret string, timestamp int64, payload []byte, signature string) bool {
+	expected := Sign(secret, timestamp, payload)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
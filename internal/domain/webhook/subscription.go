@@ -0,0 +1,99 @@
+// Package webhook provides domain logic for webhook subscriptions and their deliveries.
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/validation"
+)
+
+const minSecretLength = 16
+
+type Subscription struct {
+	ID         SubscriptionID
+	URL        string
+	EventTypes []category.EventType
+	Secret     string
+	IsActive   bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewSubscription(url string, eventTypes []category.EventType, secret string, isActive bool) (*Subscription, error) {
+	now := time.Now().UTC()
+
+	return &Subscription{
+		ID:         NewSubscriptionID(),
+		URL:        strings.TrimSpace(url),
+		EventTypes: eventTypes,
+		Secret:     secret,
+		IsActive:   isActive,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+func (s *Subscription) Update(url string, eventTypes []category.EventType, secret string, isActive bool) {
+	s.URL = strings.TrimSpace(url)
+	s.EventTypes = eventTypes
+	if secret != "" {
+		s.Secret = secret
+	}
+	s.IsActive = isActive
+	s.UpdatedAt = time.Now().UTC()
+}
+
+func (s *Subscription) Matches(eventType category.EventType) bool {
+	if !s.IsActive {
+		return false
+	}
+
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Subscription) Validate() error {
+	var errs []error
+
+	parsed, err := url.Parse(s.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		errs = append(errs, errors.New(
+			"webhook validation error: url must be an absolute http or https URL",
+		))
+	}
+
+	if len(s.EventTypes) == 0 {
+		errs = append(errs, errors.New(
+			"webhook validation error: at least one event type is required",
+		))
+	}
+
+	for _, t := range s.EventTypes {
+		if !t.IsValid() {
+			errs = append(errs, fmt.Errorf(
+				"webhook validation error: unknown event type %q", t,
+			))
+		}
+	}
+
+	if len(s.Secret) < minSecretLength {
+		errs = append(errs, fmt.Errorf(
+			"webhook validation error: secret must have at least %d characters", minSecretLength,
+		))
+	}
+
+	if len(errs) > 0 {
+		return validation.ValidationErrors{Errs: errs}
+	}
+
+	return nil
+}
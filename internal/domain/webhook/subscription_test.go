@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/validation"
+)
+
+const validSecret = "0123456789abcdef"
+
+func TestSubscriptionValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		eventTypes     []category.EventType
+		secret         string
+		expectedErrors int
+	}{
+		{"valid subscription", "https://partner.example.com/hooks", []category.EventType{category.EventCategoryCreated}, validSecret, 0},
+		{"relative url", "/hooks", []category.EventType{category.EventCategoryCreated}, validSecret, 1},
+		{"unsupported scheme", "ftp://partner.example.com", []category.EventType{category.EventCategoryCreated}, validSecret, 1},
+		{"no event types", "https://partner.example.com", nil, validSecret, 1},
+		{"unknown event type", "https://partner.example.com", []category.EventType{"category.renamed"}, validSecret, 1},
+		{"short secret", "https://partner.example.com", []category.EventType{category.EventCategoryCreated}, "short", 1},
+		{"everything wrong", "", nil, "", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, _ := NewSubscription(tt.url, tt.eventTypes, tt.secret, true)
+
+			err := sub.Validate()
+
+			if tt.expectedErrors == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.expectedErrors > 0 && err == nil {
+				t.Fatal("expected validation error")
+			}
+
+			if err != nil {
+				var validationErr validation.ValidationErrors
+
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("expected ValidationErrors, got %v", err)
+				}
+
+				if len(validationErr.Errs) != tt.expectedErrors {
+					t.Fatalf("expected %d errors, got %d: %v", tt.expectedErrors, len(validationErr.Errs), err)
+				}
+			}
+		})
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	sub, _ := NewSubscription(
+		"https://partner.example.com",
+		[]category.EventType{category.EventCategoryCreated, category.EventCategoryDeleted},
+		validSecret,
+		true,
+	)
+
+	if !sub.Matches(category.EventCategoryCreated) {
+		t.Error("expected subscription to match created events")
+	}
+
+	if sub.Matches(category.EventCategoryUpdated) {
+		t.Error("expected subscription not to match updated events")
+	}
+
+	sub.IsActive = false
+
+	if sub.Matches(category.EventCategoryCreated) {
+		t.Error("inactive subscription should not match any event")
+	}
+}
+
+func TestSubscriptionUpdate_KeepsSecretWhenEmpty(t *testing.T) {
+	sub, _ := NewSubscription("https://a.example.com", []category.EventType{category.EventCategoryCreated}, validSecret, true)
+
+	sub.Update("https://b.example.com", []category.EventType{category.EventCategoryUpdated}, "", false)
+
+	if sub.Secret != validSecret {
+		t.Error("expected secret to be kept when update omits it")
+	}
+
+	if sub.URL != "https://b.example.com" {
+		t.Errorf("expected url to be updated, got %s", sub.URL)
+	}
+
+	if sub.IsActive {
+		t.Error("expected subscription to be inactive")
+	}
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type SubscriptionGateway interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	GetSubscriptionByID(ctx context.Context, id SubscriptionID) (*Subscription, error)
+	UpdateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	DeleteSubscription(ctx context.Context, id SubscriptionID) error
+	FindAll(ctx context.Context, query SearchSubscriptionQuery) (*pagination.Pagination[Subscription], error)
+	FindActiveByEventType(ctx context.Context, eventType category.EventType) ([]Subscription, error)
+}
+
+type DeliveryGateway interface {
+	CreateDelivery(ctx context.Context, delivery *Delivery) (*Delivery, error)
+	GetDeliveryByID(ctx context.Context, id DeliveryID) (*Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery *Delivery) (*Delivery, error)
+	FindBySubscription(ctx context.Context, query SearchDeliveryQuery) (*pagination.Pagination[Delivery], error)
+	// ClaimDue returns up to limit pending deliveries due at now and moves
+	// their next attempt to now plus lease, so other workers skip them until
+	// the outcome is recorded or the lease runs out.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+}
+
+type SearchSubscriptionQuery struct {
+	Page    int
+	PerPage int
+}
+
+type SearchDeliveryQuery struct {
+	SubscriptionID SubscriptionID
+	Status         DeliveryStatus
+	Page           int
+	PerPage        int
+}
+
+type SendResult struct {
+	StatusCode int
+}
+
+type Sender interface {
+	Send(ctx context.Context, subscription *Subscription, delivery *Delivery) (SendResult, error)
+}
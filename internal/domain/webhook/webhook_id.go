@@ -0,0 +1,41 @@
+package webhook
+
+import "github.com/gofrs/uuid/v5"
+
+type SubscriptionID uuid.UUID
+
+func NewSubscriptionID() SubscriptionID {
+	id, err := uuid.NewV7()
+	if err != nil {
+		panic(err)
+	}
+	return SubscriptionID(id)
+}
+
+func ParseSubscriptionID(value string) (SubscriptionID, error) {
+	id, err := uuid.FromString(value)
+	return SubscriptionID(id), err
+}
+
+func (id SubscriptionID) String() string {
+	return uuid.UUID(id).String()
+}
+
+type DeliveryID uuid.UUID
+
+func NewDeliveryID() DeliveryID {
+	id, err := uuid.NewV7()
+	if err != nil {
+		panic(err)
+	}
+	return DeliveryID(id)
+}
+
+func ParseDeliveryID(value string) (DeliveryID, error) {
+	id, err := uuid.FromString(value)
+	return DeliveryID(id), err
+}
+
+func (id DeliveryID) String() string {
+	return uuid.UUID(id).String()
+}
@@ -0,0 +1,197 @@
+// Package persistence provides the MySQL gateway implementation for API keys.
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/apikey"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+)
+
+type MySQLAPIKeyGateway struct {
+	DB *sql.DB
+}
+
+func NewMySQLAPIKeyGateway(db *sql.DB) *MySQLAPIKeyGateway {
+	return &MySQLAPIKeyGateway{DB: db}
+}
+
+const apiKeyColumns = `id, name, salt, hash, permissions, allowed_cidrs, created_at, last_used_at, revoked_at`
+
+func (g *MySQLAPIKeyGateway) CreateAPIKey(ctx context.Context, key *apikey.APIKey) (*apikey.APIKey, error) {
+	query := `
+		INSERT INTO api_keys (` + apiKeyColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
+		query,
+		key.ID.String(),
+		key.Name,
+		key.Salt,
+		key.Hash,
+		strings.Join(key.Permissions, ","),
+		strings.Join(key.AllowedCIDRs, ","),
+		key.CreatedAt,
+		nullTime(key.LastUsedAt),
+		nullTime(key.RevokedAt),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (g *MySQLAPIKeyGateway) GetAPIKeyByID(ctx context.Context, id apikey.KeyID) (*apikey.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = ?`
+
+	return scanAPIKey(mysql.Conn(ctx, g.DB).QueryRowContext(ctx, query, id.String()))
+}
+
+func (g *MySQLAPIKeyGateway) UpdateAPIKey(ctx context.Context, key *apikey.APIKey) (*apikey.APIKey, error) {
+	query := `
+		UPDATE api_keys
+		SET name = ?, permissions = ?, allowed_cidrs = ?, last_used_at = ?, revoked_at = ?
+		WHERE id = ?
+	`
+
+	_, err := mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
+		query,
+		key.Name,
+		strings.Join(key.Permissions, ","),
+		strings.Join(key.AllowedCIDRs, ","),
+		nullTime(key.LastUsedAt),
+		nullTime(key.RevokedAt),
+		key.ID.String(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (g *MySQLAPIKeyGateway) RecordUse(ctx context.Context, id apikey.KeyID, usedAt time.Time) error {
+	_, err := mysql.Conn(ctx, g.DB).ExecContext(ctx, `UPDATE api_keys SET last_used_at = ? WHERE id = ?`, usedAt, id.String())
+	return err
+}
+
+func (g *MySQLAPIKeyGateway) FindAll(ctx context.Context, query apikey.SearchAPIKeyQuery) (*pagination.Pagination[apikey.APIKey], error) {
+	offset := (query.Page - 1) * query.PerPage
+
+	whereClause := "WHERE revoked_at IS NULL"
+	if query.IncludeRevoked {
+		whereClause = ""
+	}
+
+	conn := mysql.Conn(ctx, g.DB)
+
+	var total int
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM api_keys `+whereClause).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM api_keys `+whereClause+` ORDER BY created_at ASC LIMIT ? OFFSET ?`,
+		query.PerPage,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []apikey.APIKey
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pagination.Pagination[apikey.APIKey]{
+		CurrentPage: query.Page,
+		PerPage:     query.PerPage,
+		Total:       total,
+		Items:       keys,
+	}, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row rowScanner) (*apikey.APIKey, error) {
+	var key apikey.APIKey
+	var id, permissions, allowedCIDRs string
+	var lastUsedAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&id,
+		&key.Name,
+		&key.Salt,
+		&key.Hash,
+		&permissions,
+		&allowedCIDRs,
+		&key.CreatedAt,
+		&lastUsedAt,
+		&revokedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if key.ID, err = apikey.ParseKeyID(id); err != nil {
+		return nil, err
+	}
+
+	key.Permissions = splitList(permissions)
+	key.AllowedCIDRs = splitList(allowedCIDRs)
+
+	if lastUsedAt.Valid {
+		key.LastUsedAt = lastUsedAt.Time
+	}
+
+	if revokedAt.Valid {
+		key.RevokedAt = revokedAt.Time
+	}
+
+	return &key, nil
+}
+
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
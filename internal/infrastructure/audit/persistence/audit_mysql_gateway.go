@@ -0,0 +1,128 @@
+// Package persistence provides the MySQL gateway implementation for the audit log.
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/audit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+)
+
+type MySQLAuditGateway struct {
+	DB *sql.DB
+}
+
+func NewMySQLAuditGateway(db *sql.DB) *MySQLAuditGateway {
+	return &MySQLAuditGateway{DB: db}
+}
+
+func (g *MySQLAuditGateway) Record(ctx context.Context, entry *audit.Entry) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_log (id, entity_type, entity_id, action, actor, request_id, caused_by, changes, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE id = id
+	`
+
+	_, err = mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
+		query,
+		entry.ID,
+		entry.EntityType,
+		entry.EntityID,
+		string(entry.Action),
+		entry.Actor,
+		nullString(entry.RequestID),
+		nullString(entry.CausedBy),
+		changes,
+		entry.OccurredAt,
+	)
+
+	return err
+}
+
+func (g *MySQLAuditGateway) FindByEntity(ctx context.Context, query audit.SearchAuditQuery) (*pagination.Pagination[audit.Entry], error) {
+	offset := (query.Page - 1) * query.PerPage
+
+	var total int
+	err := mysql.Conn(ctx, g.DB).QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM audit_log WHERE entity_type = ? AND entity_id = ?`,
+		query.EntityType,
+		query.EntityID,
+	).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := mysql.Conn(ctx, g.DB).QueryContext(ctx, `
+		SELECT id, entity_type, entity_id, action, actor, request_id, caused_by, changes, occurred_at
+		FROM audit_log
+		WHERE entity_type = ? AND entity_id = ?
+		ORDER BY occurred_at DESC
+		LIMIT ? OFFSET ?
+	`, query.EntityType, query.EntityID, query.PerPage, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []audit.Entry
+
+	for rows.Next() {
+		var entry audit.Entry
+		var action string
+		var requestID sql.NullString
+		var causedBy sql.NullString
+		var changes []byte
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.EntityType,
+			&entry.EntityID,
+			&action,
+			&entry.Actor,
+			&requestID,
+			&causedBy,
+			&changes,
+			&entry.OccurredAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, err
+		}
+
+		entry.Action = audit.Action(action)
+		entry.RequestID = requestID.String
+		entry.CausedBy = causedBy.String
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pagination.Pagination[audit.Entry]{
+		CurrentPage: query.Page,
+		PerPage:     query.PerPage,
+		Total:       total,
+		Items:       entries,
+	}, nil
+}
+
+func nullString(v string) any {
+	if v == "" {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/audit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+)
+
+type MySQLAuditOutboxGateway struct {
+	DB *sql.DB
+}
+
+func NewMySQLAuditOutboxGateway(db *sql.DB) *MySQLAuditOutboxGateway {
+	return &MySQLAuditOutboxGateway{DB: db}
+}
+
+func (g *MySQLAuditOutboxGateway) Hold(ctx context.Context, pending *audit.PendingEntry) error {
+	entry := pending.Entry
+
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_outbox (id, entity_type, entity_id, action, actor, request_id, caused_by, changes, occurred_at, attempts, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE attempts = VALUES(attempts), next_attempt_at = VALUES(next_attempt_at)
+	`
+
+	_, err = mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
+		query,
+		entry.ID,
+		entry.EntityType,
+		entry.EntityID,
+		string(entry.Action),
+		entry.Actor,
+		nullString(entry.RequestID),
+		nullString(entry.CausedBy),
+		changes,
+		entry.OccurredAt,
+		pending.Attempts,
+		pending.NextAttemptAt,
+	)
+
+	return err
+}
+
+func (g *MySQLAuditOutboxGateway) FindDue(ctx context.Context, now time.Time, limit int) ([]audit.PendingEntry, error) {
+	rows, err := mysql.Conn(ctx, g.DB).QueryContext(ctx, `
+		SELECT id, entity_type, entity_id, action, actor, request_id, caused_by, changes, occurred_at, attempts, next_attempt_at
+		FROM audit_outbox
+		WHERE next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC
+		LIMIT ?
+	`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var held []audit.PendingEntry
+
+	for rows.Next() {
+		var pending audit.PendingEntry
+		var action string
+		var requestID sql.NullString
+		var causedBy sql.NullString
+		var changes []byte
+
+		if err := rows.Scan(
+			&pending.Entry.ID,
+			&pending.Entry.EntityType,
+			&pending.Entry.EntityID,
+			&action,
+			&pending.Entry.Actor,
+			&requestID,
+			&causedBy,
+			&changes,
+			&pending.Entry.OccurredAt,
+			&pending.Attempts,
+			&pending.NextAttemptAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(changes, &pending.Entry.Changes); err != nil {
+			return nil, err
+		}
+
+		pending.Entry.Action = audit.Action(action)
+		pending.Entry.RequestID = requestID.String
+		pending.Entry.CausedBy = causedBy.String
+
+		held = append(held, pending)
+	}
+
+	return held, rows.Err()
+}
+
+func (g *MySQLAuditOutboxGateway) Release(ctx context.Context, id string) error {
+	_, err := mysql.Conn(ctx, g.DB).ExecContext(ctx, `DELETE FROM audit_outbox WHERE id = ?`, id)
+	return err
+}
@@ -0,0 +1,87 @@
+// Package worker provides the background loop that retries failed audit
+// writes.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/audit/record"
+)
+
+// finalRetryTimeout bounds the last attempt made when the worker stops.
+const finalRetryTimeout = 10 * time.Second
+
+type RetryWorker struct {
+	UseCase  *record.RetryCategoryChangesUseCase
+	Interval time.Duration
+}
+
+func NewRetryWorker(useCase *record.RetryCategoryChangesUseCase, interval time.Duration) *RetryWorker {
+	return &RetryWorker{
+		UseCase:  useCase,
+		Interval: interval,
+	}
+}
+
+// Run retries due entries until ctx is cancelled, then makes a final attempt
+// at every entry left.
+func (w *RetryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.Flush()
+			return
+		case <-ticker.C:
+			w.retry(ctx, false)
+		}
+	}
+}
+
+// Flush makes a final attempt at every entry queued in memory, logging the
+// ones that are lost. Processes that exit without running the worker call it
+// last.
+func (w *RetryWorker) Flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), finalRetryTimeout)
+	defer cancel()
+
+	w.retry(ctx, true)
+}
+
+func (w *RetryWorker) retry(ctx context.Context, final bool) {
+	// A final run only concerns the memory queue; the outbox outlives us.
+	if final && w.UseCase.Queue.Len() == 0 {
+		return
+	}
+
+	output, err := w.UseCase.Execute(ctx, record.RetryCategoryChangesInput{Final: final})
+	if err != nil {
+		slog.ErrorContext(ctx, "audit worker: retrying entries failed", "error", err)
+	}
+	if output == nil {
+		return
+	}
+
+	if output.Recorded > 0 {
+		slog.InfoContext(ctx, "audit worker: recorded queued entries", "recorded", output.Recorded)
+	}
+
+	// The entry is logged in full, as it is the only record of the change.
+	for _, entry := range output.Dropped {
+		slog.ErrorContext(ctx, "audit worker: giving up on audit entry",
+			"entry_id", entry.ID,
+			"entity_type", entry.EntityType,
+			"entity_id", entry.EntityID,
+			"action", entry.Action,
+			"actor", entry.Actor,
+			"request_id", entry.RequestID,
+			"caused_by", entry.CausedBy,
+			"changes", entry.Changes,
+			"occurred_at", entry.OccurredAt,
+		)
+	}
+}
@@ -0,0 +1,20 @@
+// Package auth authenticates admin API callers.
+package auth
+
+import "time"
+
+type Config struct {
+	// JWKSFile and JWKSURL are the sources of RS256 verification keys; at
+	// most one of them is used, the file taking precedence.
+	JWKSFile string
+	JWKSURL  string
+	// HS256Secret enables shared-secret tokens. Meant for local development.
+	HS256Secret string
+
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+
+	// RolePermissionsFile replaces the default role-to-permission mapping.
+	RolePermissionsFile string
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// jwksRefreshInterval limits how often an unknown "kid" triggers a refetch
+// of a remote key set, so forged key IDs cannot be used to hammer it.
+const jwksRefreshInterval = 5 * time.Minute
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// KeySet holds RSA public keys by key ID, loaded from a JWKS document.
+type KeySet struct {
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	fetch       func() ([]byte, error)
+	lastFetch   time.Time
+	refreshable bool
+}
+
+func LoadKeySetFile(path string) (*KeySet, error) {
+	ks := &KeySet{
+		fetch: func() ([]byte, error) { return os.ReadFile(path) },
+	}
+
+	if err := ks.refresh(); err != nil {
+		return nil, fmt.Errorf("loading JWKS file %s: %w", path, err)
+	}
+
+	return ks, nil
+}
+
+func LoadKeySetURL(url string, client *http.Client) (*KeySet, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	ks := &KeySet{
+		refreshable: true,
+		fetch: func() ([]byte, error) {
+			resp, err := client.Get(url)
+			if err != nil {
+				return nil, err
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+			}
+
+			return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		},
+	}
+
+	if err := ks.refresh(); err != nil {
+		return nil, fmt.Errorf("loading JWKS from %s: %w", url, err)
+	}
+
+	return ks, nil
+}
+
+// Key returns the key with the given ID. A remote key set is refetched once
+// per refresh interval when the ID is unknown, which picks up rotated keys.
+func (ks *KeySet) Key(kid string) (*rsa.PublicKey, error) {
+	ks.mu.RLock()
+	key, ok := ks.lookup(kid)
+	stale := ks.refreshable && time.Since(ks.lastFetch) > jwksRefreshInterval
+	ks.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if stale {
+		if err := ks.refresh(); err != nil {
+			return nil, err
+		}
+
+		ks.mu.RLock()
+		key, ok = ks.lookup(kid)
+		ks.mu.RUnlock()
+
+		if ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+}
+
+// lookup accepts a token without "kid" when the set holds a single key.
+func (ks *KeySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+func (ks *KeySet) refresh() error {
+	data, err := ks.fetch()
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseKeySet(data)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.keys = keys
+	ks.lastFetch = time.Now()
+
+	return nil
+}
+
+func parseKeySet(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		key, err := parseRSAKey(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", jwk.Kid, err)
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no RSA signing keys found")
+	}
+
+	return keys, nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 {
+		return nil, errors.New("invalid exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
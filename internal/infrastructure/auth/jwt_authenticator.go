@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+)
+
+var ErrNoKeySource = errors.New("no token verification key configured: set AUTH_JWKS_FILE, AUTH_JWKS_URL or AUTH_HS256_SECRET")
+
+// Claims are the token claims the catalog relies on.
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+}
+
+type JWTAuthenticator struct {
+	Keys   *KeySet
+	Secret []byte
+	parser *jwt.Parser
+}
+
+func NewJWTAuthenticator(keys *KeySet, secret []byte, issuer, audience string, options ...jwt.ParserOption) (*JWTAuthenticator, error) {
+	var methods []string
+	if keys != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(secret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, ErrNoKeySource
+	}
+
+	options = append(options, jwt.WithValidMethods(methods), jwt.WithExpirationRequired())
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &JWTAuthenticator{
+		Keys:   keys,
+		Secret: secret,
+		parser: jwt.NewParser(options...),
+	}, nil
+}
+
+// NewJWTAuthenticatorFromConfig loads the configured key sources.
+func NewJWTAuthenticatorFromConfig(cfg Config) (*JWTAuthenticator, error) {
+	var keys *KeySet
+	var err error
+
+	switch {
+	case cfg.JWKSFile != "":
+		keys, err = LoadKeySetFile(cfg.JWKSFile)
+	case cfg.JWKSURL != "":
+		keys, err = LoadKeySetURL(cfg.JWKSURL, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var secret []byte
+	if cfg.HS256Secret != "" {
+		secret = []byte(cfg.HS256Secret)
+	}
+
+	return NewJWTAuthenticator(keys, secret, cfg.Issuer, cfg.Audience, jwt.WithLeeway(cfg.Leeway))
+}
+
+// Authenticate verifies the signature and the exp, nbf, iss and aud claims
+// of a bearer token and returns the principal it identifies.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (requestctx.Principal, error) {
+	var claims Claims
+
+	if _, err := a.parser.ParseWithClaims(token, &claims, a.key); err != nil {
+		return requestctx.Principal{}, err
+	}
+
+	if claims.Subject == "" {
+		return requestctx.Principal{}, errors.New("token has no subject")
+	}
+
+	return requestctx.Principal{
+		Kind:    requestctx.PrincipalUser,
+		Subject: claims.Subject,
+		Roles:   claims.Roles,
+	}, nil
+}
+
+func (a *JWTAuthenticator) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		return a.Keys.Key(kid)
+	case jwt.SigningMethodHS256.Alg():
+		return a.Secret, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://auth.example.com"
+	testAudience = "catalog-admin"
+	testSecret   = "dev-secret-with-enough-entropy"
+)
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	return key
+}
+
+func jwksDocument(t *testing.T, kid string, key *rsa.PublicKey) []byte {
+	t.Helper()
+
+	doc, err := json.Marshal(jsonWebKeySet{Keys: []jsonWebKey{{
+		Kid: kid,
+		Kty: "RSA",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}})
+	if err != nil {
+		t.Fatalf("encoding JWKS: %v", err)
+	}
+	return doc
+}
+
+func writeJWKSFile(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwksDocument(t, kid, key), 0o600); err != nil {
+		t.Fatalf("writing JWKS: %v", err)
+	}
+	return path
+}
+
+func validClaims() Claims {
+	now := time.Now()
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "alice",
+			Issuer:    testIssuer,
+			Audience:  jwt.ClaimStrings{testAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Roles: []string{"catalog-editor"},
+	}
+}
+
+func mintRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims Claims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	return signed
+}
+
+func mintHS256(t *testing.T, secret string, claims Claims) string {
+	t.Helper()
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	return signed
+}
+
+func TestJWTAuthenticator_RS256FromJWKSFile(t *testing.T) {
+	key := newRSAKey(t)
+
+	authenticator, err := NewJWTAuthenticatorFromConfig(Config{
+		JWKSFile: writeJWKSFile(t, "key-1", &key.PublicKey),
+		Issuer:   testIssuer,
+		Audience: testAudience,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	principal, err := authenticator.Authenticate(context.Background(), mintRS256(t, key, "key-1", validClaims()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if principal.Subject != "alice" {
+		t.Errorf("expected subject alice, got %q", principal.Subject)
+	}
+	if len(principal.Roles) != 1 || principal.Roles[0] != "catalog-editor" {
+		t.Errorf("unexpected roles %v", principal.Roles)
+	}
+}
+
+func TestJWTAuthenticator_RS256FromJWKSURL(t *testing.T) {
+	key := newRSAKey(t)
+	doc := jwksDocument(t, "key-1", &key.PublicKey)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(doc)
+	}))
+	defer server.Close()
+
+	authenticator, err := NewJWTAuthenticatorFromConfig(Config{JWKSURL: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := authenticator.Authenticate(context.Background(), mintRS256(t, key, "key-1", validClaims())); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestJWTAuthenticator_Rejects(t *testing.T) {
+	key := newRSAKey(t)
+	otherKey := newRSAKey(t)
+
+	authenticator, err := NewJWTAuthenticatorFromConfig(Config{
+		JWKSFile:    writeJWKSFile(t, "key-1", &key.PublicKey),
+		HS256Secret: testSecret,
+		Issuer:      testIssuer,
+		Audience:    testAudience,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expired := validClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	notYetValid := validClaims()
+	notYetValid.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	wrongIssuer := validClaims()
+	wrongIssuer.Issuer = "https://evil.example.com"
+
+	wrongAudience := validClaims()
+	wrongAudience.Audience = jwt.ClaimStrings{"billing"}
+
+	noExpiry := validClaims()
+	noExpiry.ExpiresAt = nil
+
+	noSubject := validClaims()
+	noSubject.Subject = ""
+
+	tests := map[string]string{
+		"expired":            mintRS256(t, key, "key-1", expired),
+		"not yet valid":      mintRS256(t, key, "key-1", notYetValid),
+		"wrong issuer":       mintRS256(t, key, "key-1", wrongIssuer),
+		"wrong audience":     mintRS256(t, key, "key-1", wrongAudience),
+		"no expiry":          mintRS256(t, key, "key-1", noExpiry),
+		"no subject":         mintRS256(t, key, "key-1", noSubject),
+		"unknown key":        mintRS256(t, otherKey, "key-2", validClaims()),
+		"wrong signature":    mintRS256(t, otherKey, "key-1", validClaims()),
+		"wrong HS256 secret": mintHS256(t, "another-secret-value", validClaims()),
+		"malformed":          "not-a-token",
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := authenticator.Authenticate(context.Background(), token); err == nil {
+				t.Error("expected token to be rejected")
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticator_HS256ForDevelopment(t *testing.T) {
+	authenticator, err := NewJWTAuthenticatorFromConfig(Config{HS256Secret: testSecret})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := authenticator.Authenticate(context.Background(), mintHS256(t, testSecret, validClaims())); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	key := newRSAKey(t)
+	if _, err := authenticator.Authenticate(context.Background(), mintRS256(t, key, "key-1", validClaims())); err == nil {
+		t.Error("expected RS256 token to be rejected when no JWKS is configured")
+	}
+}
+
+func TestNewJWTAuthenticatorFromConfig_RequiresKeySource(t *testing.T) {
+	if _, err := NewJWTAuthenticatorFromConfig(Config{}); err != ErrNoKeySource {
+		t.Errorf("expected ErrNoKeySource, got %v", err)
+	}
+}
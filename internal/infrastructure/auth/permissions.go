@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+)
+
+const (
+	PermissionCatalogRead   = "catalog:read"
+	PermissionCatalogWrite  = "catalog:write"
+	PermissionCatalogDelete = "catalog:delete"
+	PermissionAPIKeysManage = "apikeys:manage"
+)
+
+func Permissions() []string {
+	return []string{
+		PermissionCatalogRead,
+		PermissionCatalogWrite,
+		PermissionCatalogDelete,
+		PermissionAPIKeysManage,
+	}
+}
+
+// APIKeyPermissions are the permissions an API key may be granted. Managing
+// keys is left to people so a leaked key cannot mint others.
+func APIKeyPermissions() []string {
+	return []string{
+		PermissionCatalogRead,
+		PermissionCatalogWrite,
+		PermissionCatalogDelete,
+	}
+}
+
+func IsValidPermission(permission string) bool {
+	for _, known := range Permissions() {
+		if permission == known {
+			return true
+		}
+	}
+	return false
+}
+
+// RolePermissions maps token roles to the permissions they grant.
+type RolePermissions map[string][]string
+
+func DefaultRolePermissions() RolePermissions {
+	return RolePermissions{
+		"catalog-admin":  {PermissionCatalogRead, PermissionCatalogWrite, PermissionCatalogDelete, PermissionAPIKeysManage},
+		"catalog-editor": {PermissionCatalogRead, PermissionCatalogWrite},
+		"catalog-viewer": {PermissionCatalogRead},
+	}
+}
+
+// LoadRolePermissionsFile reads a JSON object of role name to permission
+// list, e.g. {"catalog-editor": ["catalog:read", "catalog:write"]}. It
+// replaces the default mapping entirely.
+func LoadRolePermissionsFile(path string) (RolePermissions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var mapping RolePermissions
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("parsing role permissions %s: %w", path, err)
+	}
+
+	if err := mapping.Validate(); err != nil {
+		return nil, fmt.Errorf("role permissions %s: %w", path, err)
+	}
+
+	return mapping, nil
+}
+
+func (m RolePermissions) Validate() error {
+	roles := make([]string, 0, len(m))
+	for role := range m {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	for _, role := range roles {
+		for _, permission := range m[role] {
+			if !IsValidPermission(permission) {
+				return fmt.Errorf("role %q grants unknown permission %q", role, permission)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Allows reports whether the principal holds permission directly or through
+// any of its roles.
+func (m RolePermissions) Allows(principal requestctx.Principal, permission string) bool {
+	for _, granted := range principal.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+
+	for _, role := range principal.Roles {
+		for _, granted := range m[role] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
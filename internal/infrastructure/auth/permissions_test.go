@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+)
+
+func TestRolePermissions_Allows(t *testing.T) {
+	mapping := DefaultRolePermissions()
+
+	editor := requestctx.Principal{Subject: "alice", Roles: []string{"catalog-editor"}}
+	admin := requestctx.Principal{Subject: "bob", Roles: []string{"other", "catalog-admin"}}
+	nobody := requestctx.Principal{Subject: "carol"}
+
+	if !mapping.Allows(editor, PermissionCatalogWrite) {
+		t.Error("expected editor to write")
+	}
+	if mapping.Allows(editor, PermissionCatalogDelete) {
+		t.Error("expected editor not to delete")
+	}
+	if !mapping.Allows(admin, PermissionCatalogDelete) {
+		t.Error("expected admin to delete")
+	}
+	if mapping.Allows(nobody, PermissionCatalogRead) {
+		t.Error("expected principal without roles to be denied")
+	}
+
+	machine := requestctx.Principal{Kind: requestctx.PrincipalAPIKey, Subject: "1", Permissions: []string{PermissionCatalogRead}}
+	if !mapping.Allows(machine, PermissionCatalogRead) || mapping.Allows(machine, PermissionCatalogWrite) {
+		t.Error("expected direct permissions to be honoured exactly")
+	}
+}
+
+func TestLoadRolePermissionsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "roles.json")
+	os.WriteFile(valid, []byte(`{"sync-job": ["catalog:read"]}`), 0o600)
+
+	mapping, err := LoadRolePermissionsFile(valid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !mapping.Allows(requestctx.Principal{Roles: []string{"sync-job"}}, PermissionCatalogRead) {
+		t.Error("expected sync-job to read")
+	}
+	if mapping.Allows(requestctx.Principal{Roles: []string{"catalog-admin"}}, PermissionCatalogRead) {
+		t.Error("expected file to replace the default mapping")
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalid, []byte(`{"sync-job": ["catalog:everything"]}`), 0o600)
+
+	if _, err := LoadRolePermissionsFile(invalid); err == nil {
+		t.Error("expected unknown permission to be rejected")
+	}
+}
@@ -0,0 +1,116 @@
+package eventsourcing
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+const (
+	eventCategoryCreated = "CategoryCreated"
+	eventCategoryUpdated = "CategoryUpdated"
+	eventCategoryDeleted = "CategoryDeleted"
+)
+
+// categoryState is the serialized form of a category, used both as event
+// payload and as snapshot state.
+type categoryState struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug,omitempty"`
+	ParentID    string     `json:"parent_id,omitempty"`
+	Description string     `json:"description"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Removed     bool       `json:"removed,omitempty"`
+}
+
+func newCategoryState(cat *category.Category) categoryState {
+	state := categoryState{
+		ID:          cat.ID.String(),
+		Name:        cat.Name,
+		Slug:        cat.Slug,
+		Description: cat.Description,
+		IsActive:    cat.IsActive,
+		CreatedAt:   cat.CreatedAt,
+		UpdatedAt:   cat.UpdatedAt,
+	}
+
+	if cat.ParentID != nil {
+		state.ParentID = cat.ParentID.String()
+	}
+
+	if !cat.DeletedAt.IsZero() {
+		deletedAt := cat.DeletedAt
+		state.DeletedAt = &deletedAt
+	}
+
+	return state
+}
+
+func (s categoryState) toCategory(version int) (*category.Category, error) {
+	id, err := category.ParseCategoryID(s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cat := &category.Category{
+		ID:          id,
+		Name:        s.Name,
+		Slug:        s.Slug,
+		Description: s.Description,
+		IsActive:    s.IsActive,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+		Version:     version,
+	}
+
+	if s.ParentID != "" {
+		parentID, err := category.ParseCategoryID(s.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		cat.ParentID = &parentID
+	}
+
+	if s.DeletedAt != nil {
+		cat.DeletedAt = *s.DeletedAt
+	}
+
+	return cat, nil
+}
+
+func newRecord(streamID, eventType string, state categoryState, occurredAt time.Time) (Record, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return Record{}, err
+	}
+
+	return Record{
+		StreamID:   streamID,
+		Type:       eventType,
+		Data:       data,
+		OccurredAt: occurredAt,
+	}, nil
+}
+
+// apply folds one stored event into the state rebuilt so far.
+func apply(state categoryState, record Record) (categoryState, error) {
+	switch record.Type {
+	case eventCategoryCreated, eventCategoryUpdated:
+		var next categoryState
+		if err := json.Unmarshal(record.Data, &next); err != nil {
+			return state, err
+		}
+		return next, nil
+	case eventCategoryDeleted:
+		state.Removed = true
+		return state, nil
+	default:
+		return state, fmt.Errorf("unknown category event type %q", record.Type)
+	}
+}
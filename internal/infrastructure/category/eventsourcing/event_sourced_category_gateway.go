@@ -0,0 +1,323 @@
+package eventsourcing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+// EventSourcedCategoryGateway stores every change to a category as an event
+// and rebuilds categories by replaying them, starting from the latest
+// snapshot. Listing is served by the projected read model. Each change is
+// appended and projected in one transaction of Transactor, so a change the
+// read model rejects, such as a taken name, leaves no event behind.
+type EventSourcedCategoryGateway struct {
+	Store         EventStore
+	Projector     Projector
+	ReadModel     category.CategoryGateway
+	Transactor    usecase.Transactor
+	SnapshotEvery int
+}
+
+func NewEventSourcedCategoryGateway(
+	store EventStore,
+	projector Projector,
+	readModel category.CategoryGateway,
+	transactor usecase.Transactor,
+	snapshotEvery int,
+) *EventSourcedCategoryGateway {
+	return &EventSourcedCategoryGateway{
+		Store:         store,
+		Projector:     projector,
+		ReadModel:     readModel,
+		Transactor:    transactor,
+		SnapshotEvery: snapshotEvery,
+	}
+}
+
+func (g *EventSourcedCategoryGateway) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	state := newCategoryState(cat)
+
+	err := g.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := g.append(ctx, state, 0, eventCategoryCreated, cat.CreatedAt); err != nil {
+			if errors.Is(err, ErrConcurrencyConflict) {
+				return fmt.Errorf("category %s already exists: %w", cat.ID, err)
+			}
+			return err
+		}
+
+		cat.Version = 1
+		return g.project(ctx, cat.ID, cat)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cat, nil
+}
+
+func (g *EventSourcedCategoryGateway) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
+	state, version, err := g.load(ctx, id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	cat, err := state.toCategory(version)
+	if err != nil {
+		return nil, err
+	}
+
+	g.backfillSlug(ctx, cat)
+	return cat, nil
+}
+
+// backfillSlug gives categories whose events predate slugs the slug the
+// migration generated in the read model, so the next event records it.
+func (g *EventSourcedCategoryGateway) backfillSlug(ctx context.Context, cat *category.Category) {
+	if cat.Slug != "" {
+		return
+	}
+	if projected, err := g.ReadModel.GetCategoryByID(ctx, cat.ID); err == nil {
+		cat.Slug = projected.Slug
+	}
+}
+
+// UpdateCategory appends on top of the version the category was read at, so
+// an update based on stale data fails with ErrConcurrencyConflict.
+func (g *EventSourcedCategoryGateway) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	err := g.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		expected := cat.Version
+		if expected == 0 {
+			_, current, err := g.load(ctx, cat.ID.String())
+			if err != nil {
+				return err
+			}
+			expected = current
+		}
+
+		if err := g.append(ctx, newCategoryState(cat), expected, eventCategoryUpdated, cat.UpdatedAt); err != nil {
+			return err
+		}
+
+		cat.Version = expected + 1
+		return g.project(ctx, cat.ID, cat)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cat, nil
+}
+
+func (g *EventSourcedCategoryGateway) DeleteCategory(ctx context.Context, id category.CategoryID) error {
+	return g.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		state, version, err := g.load(ctx, id.String())
+		if err != nil {
+			return err
+		}
+
+		state.Removed = true
+
+		if err := g.append(ctx, state, version, eventCategoryDeleted, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		return g.project(ctx, id, nil)
+	})
+}
+
+func (g *EventSourcedCategoryGateway) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	return g.ReadModel.FindCategoryByName(ctx, name)
+}
+
+func (g *EventSourcedCategoryGateway) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	return g.ReadModel.FindCategoryBySlug(ctx, slug)
+}
+
+func (g *EventSourcedCategoryGateway) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	return g.ReadModel.FindChildren(ctx, parentID)
+}
+
+func (g *EventSourcedCategoryGateway) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	return g.ReadModel.FindAll(ctx, query)
+}
+
+// RebuildProjection replays every stream into the read model, repairing any
+// drift left by a projection that failed after its events were stored.
+// Parents are projected before their children, and removals come last, so
+// the read model never points at a parent it does not hold.
+func (g *EventSourcedCategoryGateway) RebuildProjection(ctx context.Context) error {
+	streamIDs, err := g.Store.StreamIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	var live []*category.Category
+	var removed []category.CategoryID
+
+	for _, streamID := range streamIDs {
+		id, err := category.ParseCategoryID(streamID)
+		if err != nil {
+			return err
+		}
+
+		state, version, err := g.replay(ctx, streamID)
+		if err != nil {
+			return err
+		}
+
+		if state.Removed {
+			removed = append(removed, id)
+			continue
+		}
+
+		cat, err := state.toCategory(version)
+		if err != nil {
+			return err
+		}
+		g.backfillSlug(ctx, cat)
+		live = append(live, cat)
+	}
+
+	for _, cat := range parentsFirst(live) {
+		if err := g.Projector.Project(ctx, cat.ID, cat); err != nil {
+			return err
+		}
+	}
+
+	for i := len(removed) - 1; i >= 0; i-- {
+		if err := g.Projector.Project(ctx, removed[i], nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parentsFirst orders cats so that each comes after its parent, keeping the
+// original order otherwise. Categories whose parent is not among cats keep
+// their place.
+func parentsFirst(cats []*category.Category) []*category.Category {
+	byID := make(map[category.CategoryID]*category.Category, len(cats))
+	for _, cat := range cats {
+		byID[cat.ID] = cat
+	}
+
+	ordered := make([]*category.Category, 0, len(cats))
+	placed := make(map[category.CategoryID]bool, len(cats))
+
+	var place func(cat *category.Category)
+	place = func(cat *category.Category) {
+		if placed[cat.ID] {
+			return
+		}
+		placed[cat.ID] = true
+		if cat.ParentID != nil {
+			if parent, ok := byID[*cat.ParentID]; ok {
+				place(parent)
+			}
+		}
+		ordered = append(ordered, cat)
+	}
+
+	for _, cat := range cats {
+		place(cat)
+	}
+
+	return ordered
+}
+
+func (g *EventSourcedCategoryGateway) append(ctx context.Context, state categoryState, expectedVersion int, eventType string, occurredAt time.Time) error {
+	record, err := newRecord(state.ID, eventType, state, occurredAt)
+	if err != nil {
+		return err
+	}
+
+	if err := g.Store.Append(ctx, state.ID, expectedVersion, []Record{record}); err != nil {
+		return err
+	}
+
+	return g.maybeSnapshot(ctx, state, expectedVersion, expectedVersion+1)
+}
+
+// maybeSnapshot stores the state whenever the stream crosses a multiple of
+// SnapshotEvery, bounding how many events a read has to replay.
+func (g *EventSourcedCategoryGateway) maybeSnapshot(ctx context.Context, state categoryState, fromVersion, toVersion int) error {
+	if g.SnapshotEvery <= 0 || toVersion/g.SnapshotEvery == fromVersion/g.SnapshotEvery {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return g.Store.SaveSnapshot(ctx, Snapshot{
+		StreamID: state.ID,
+		Version:  toVersion,
+		State:    data,
+		TakenAt:  time.Now().UTC(),
+	})
+}
+
+// load rebuilds a live category, treating deleted streams as not found.
+func (g *EventSourcedCategoryGateway) load(ctx context.Context, streamID string) (categoryState, int, error) {
+	state, version, err := g.replay(ctx, streamID)
+	if err != nil {
+		return categoryState{}, 0, err
+	}
+
+	if state.Removed {
+		return categoryState{}, 0, category.ErrCategoryNotFound
+	}
+
+	return state, version, nil
+}
+
+func (g *EventSourcedCategoryGateway) replay(ctx context.Context, streamID string) (categoryState, int, error) {
+	var state categoryState
+	version := 0
+
+	snapshot, err := g.Store.LoadSnapshot(ctx, streamID)
+	if err != nil {
+		return state, 0, err
+	}
+
+	if snapshot != nil {
+		if err := json.Unmarshal(snapshot.State, &state); err != nil {
+			return state, 0, err
+		}
+		version = snapshot.Version
+	}
+
+	records, err := g.Store.Load(ctx, streamID, version)
+	if err != nil {
+		return state, 0, err
+	}
+
+	for _, record := range records {
+		if state, err = apply(state, record); err != nil {
+			return state, 0, err
+		}
+		version = record.Version
+	}
+
+	if version == 0 {
+		return state, 0, category.ErrCategoryNotFound
+	}
+
+	return state, version, nil
+}
+
+func (g *EventSourcedCategoryGateway) project(ctx context.Context, id category.CategoryID, cat *category.Category) error {
+	if err := g.Projector.Project(ctx, id, cat); err != nil {
+		return fmt.Errorf("projecting category %s to the read model: %w", id, err)
+	}
+	return nil
+}
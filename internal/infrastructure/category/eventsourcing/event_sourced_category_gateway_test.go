@@ -0,0 +1,243 @@
+package eventsourcing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type memoryEventStore struct {
+	streams   map[string][]Record
+	snapshots map[string]Snapshot
+	order     []string
+}
+
+func newMemoryEventStore() *memoryEventStore {
+	return &memoryEventStore{
+		streams:   make(map[string][]Record),
+		snapshots: make(map[string]Snapshot),
+	}
+}
+
+func (s *memoryEventStore) Append(ctx context.Context, streamID string, expectedVersion int, records []Record) error {
+	if len(s.streams[streamID]) != expectedVersion {
+		return ErrConcurrencyConflict
+	}
+	if expectedVersion == 0 {
+		s.order = append(s.order, streamID)
+	}
+	for i, record := range records {
+		record.Version = expectedVersion + i + 1
+		s.streams[streamID] = append(s.streams[streamID], record)
+	}
+	return nil
+}
+
+func (s *memoryEventStore) Load(ctx context.Context, streamID string, afterVersion int) ([]Record, error) {
+	var records []Record
+	for _, record := range s.streams[streamID] {
+		if record.Version > afterVersion {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (s *memoryEventStore) SaveSnapshot(ctx context.Context, snapshot Snapshot) error {
+	s.snapshots[snapshot.StreamID] = snapshot
+	return nil
+}
+
+func (s *memoryEventStore) LoadSnapshot(ctx context.Context, streamID string) (*Snapshot, error) {
+	snapshot, ok := s.snapshots[streamID]
+	if !ok {
+		return nil, nil
+	}
+	return &snapshot, nil
+}
+
+func (s *memoryEventStore) StreamIDs(ctx context.Context) ([]string, error) {
+	return s.order, nil
+}
+
+// WithinTransaction restores the streams when fn fails, standing in for the
+// rollback of the MySQL store.
+func (s *memoryEventStore) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	streams := make(map[string][]Record, len(s.streams))
+	for id, records := range s.streams {
+		streams[id] = append([]Record(nil), records...)
+	}
+	order := append([]string(nil), s.order...)
+
+	if err := fn(ctx); err != nil {
+		s.streams = streams
+		s.order = order
+		return err
+	}
+	return nil
+}
+
+type recordingProjector struct {
+	categories map[category.CategoryID]*category.Category
+	Err        error
+}
+
+func newRecordingProjector() *recordingProjector {
+	return &recordingProjector{categories: make(map[category.CategoryID]*category.Category)}
+}
+
+func (p *recordingProjector) Project(ctx context.Context, id category.CategoryID, cat *category.Category) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	if cat == nil {
+		delete(p.categories, id)
+		return nil
+	}
+	copied := *cat
+	p.categories[id] = &copied
+	return nil
+}
+
+func newTestGateway(snapshotEvery int) (*EventSourcedCategoryGateway, *memoryEventStore, *recordingProjector) {
+	store := newMemoryEventStore()
+	projector := newRecordingProjector()
+	return NewEventSourcedCategoryGateway(store, projector, nil, store, snapshotEvery), store, projector
+}
+
+func TestEventSourcedCategoryGateway_CreateGetUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	gateway, _, projector := newTestGateway(0)
+
+	cat, _ := category.NewCategory("Movies", "Feature films", true)
+	if _, err := gateway.CreateCategory(ctx, cat); err != nil {
+		t.Fatalf("unexpected error creating: %v", err)
+	}
+
+	loaded, err := gateway.GetCategoryByID(ctx, cat.ID)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if loaded.Name != "Movies" || loaded.Version != 1 {
+		t.Errorf("expected Movies at version 1, got %s at version %d", loaded.Name, loaded.Version)
+	}
+
+	loaded.Update("Films", "Feature films", false)
+	if _, err := gateway.UpdateCategory(ctx, loaded); err != nil {
+		t.Fatalf("unexpected error updating: %v", err)
+	}
+
+	loaded, err = gateway.GetCategoryByID(ctx, cat.ID)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if loaded.Name != "Films" || loaded.IsActive || loaded.Version != 2 {
+		t.Errorf("unexpected state after update: %+v", loaded)
+	}
+	if projector.categories[cat.ID].Name != "Films" {
+		t.Errorf("expected read model to be projected, got %+v", projector.categories[cat.ID])
+	}
+
+	if err := gateway.DeleteCategory(ctx, cat.ID); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	if _, err := gateway.GetCategoryByID(ctx, cat.ID); !errors.Is(err, category.ErrCategoryNotFound) {
+		t.Errorf("expected ErrCategoryNotFound after delete, got %v", err)
+	}
+	if _, ok := projector.categories[cat.ID]; ok {
+		t.Error("expected category to be removed from read model")
+	}
+}
+
+func TestEventSourcedCategoryGateway_RejectedProjectionLeavesNoEvent(t *testing.T) {
+	ctx := context.Background()
+	gateway, store, projector := newTestGateway(0)
+	projector.Err = category.ErrNameConflict
+
+	cat, _ := category.NewCategory("Movies", "Feature films", true)
+	if _, err := gateway.CreateCategory(ctx, cat); !errors.Is(err, category.ErrNameConflict) {
+		t.Fatalf("expected ErrNameConflict, got %v", err)
+	}
+
+	if len(store.streams[cat.ID.String()]) != 0 || len(store.order) != 0 {
+		t.Error("expected the rejected category to leave no event stream")
+	}
+}
+
+func TestEventSourcedCategoryGateway_RejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	gateway, _, _ := newTestGateway(0)
+
+	cat, _ := category.NewCategory("Movies", "", true)
+	gateway.CreateCategory(ctx, cat)
+
+	first, _ := gateway.GetCategoryByID(ctx, cat.ID)
+	second, _ := gateway.GetCategoryByID(ctx, cat.ID)
+
+	first.Update("Films", "", true)
+	if _, err := gateway.UpdateCategory(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second.Update("Cinema", "", true)
+	if _, err := gateway.UpdateCategory(ctx, second); !errors.Is(err, ErrConcurrencyConflict) {
+		t.Errorf("expected ErrConcurrencyConflict, got %v", err)
+	}
+}
+
+func TestEventSourcedCategoryGateway_SnapshotsEveryN(t *testing.T) {
+	ctx := context.Background()
+	gateway, store, _ := newTestGateway(3)
+
+	cat, _ := category.NewCategory("v1", "", true)
+	gateway.CreateCategory(ctx, cat)
+
+	for _, name := range []string{"v2", "v3", "v4"} {
+		current, _ := gateway.GetCategoryByID(ctx, cat.ID)
+		current.Update(name, "", true)
+		if _, err := gateway.UpdateCategory(ctx, current); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	snapshot, _ := store.LoadSnapshot(ctx, cat.ID.String())
+	if snapshot == nil || snapshot.Version != 3 {
+		t.Fatalf("expected snapshot at version 3, got %+v", snapshot)
+	}
+
+	loaded, err := gateway.GetCategoryByID(ctx, cat.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Name != "v4" || loaded.Version != 4 {
+		t.Errorf("expected v4 at version 4 replayed over snapshot, got %s at %d", loaded.Name, loaded.Version)
+	}
+}
+
+func TestEventSourcedCategoryGateway_RebuildProjection(t *testing.T) {
+	ctx := context.Background()
+	gateway, _, projector := newTestGateway(0)
+
+	kept, _ := category.NewCategory("Movies", "", true)
+	removed, _ := category.NewCategory("Series", "", true)
+	gateway.CreateCategory(ctx, kept)
+	gateway.CreateCategory(ctx, removed)
+	gateway.DeleteCategory(ctx, removed.ID)
+
+	projector.categories = make(map[category.CategoryID]*category.Category)
+	projector.categories[removed.ID] = removed
+
+	if err := gateway.RebuildProjection(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if projector.categories[kept.ID] == nil || projector.categories[kept.ID].Name != "Movies" {
+		t.Errorf("expected Movies in rebuilt read model, got %+v", projector.categories[kept.ID])
+	}
+	if _, ok := projector.categories[removed.ID]; ok {
+		t.Error("expected deleted category to be absent from rebuilt read model")
+	}
+}
@@ -0,0 +1,40 @@
+// Package eventsourcing provides an event-store-backed implementation of category.CategoryGateway.
+package eventsourcing
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrConcurrencyConflict = errors.New("event stream was modified concurrently")
+
+// Record is a single stored event. Versions start at 1 and are contiguous
+// within a stream.
+type Record struct {
+	StreamID   string
+	Version    int
+	Type       string
+	Data       []byte
+	OccurredAt time.Time
+}
+
+type Snapshot struct {
+	StreamID string
+	Version  int
+	State    []byte
+	TakenAt  time.Time
+}
+
+type EventStore interface {
+	// Append stores records after expectedVersion, failing with
+	// ErrConcurrencyConflict if the stream is no longer at that version.
+	Append(ctx context.Context, streamID string, expectedVersion int, records []Record) error
+	// Load returns the records of a stream with a version greater than afterVersion.
+	Load(ctx context.Context, streamID string, afterVersion int) ([]Record, error)
+	SaveSnapshot(ctx context.Context, snapshot Snapshot) error
+	// LoadSnapshot returns the latest snapshot of a stream, or nil if there is none.
+	LoadSnapshot(ctx context.Context, streamID string) (*Snapshot, error)
+	// StreamIDs lists every stream, in creation order.
+	StreamIDs(ctx context.Context) ([]string, error)
+}
@@ -0,0 +1,152 @@
+package eventsourcing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+)
+
+const mysqlDuplicateEntry = 1062
+
+type MySQLEventStore struct {
+	DB *sql.DB
+}
+
+func NewMySQLEventStore(db *sql.DB) *MySQLEventStore {
+	return &MySQLEventStore{DB: db}
+}
+
+// Append checks the stream head inside a transaction, joining the caller's
+// when there is one; the (stream_id, version) primary key is the final guard
+// against a concurrent writer.
+func (s *MySQLEventStore) Append(ctx context.Context, streamID string, expectedVersion int, records []Record) error {
+	return mysql.NewTransactor(s.DB).WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := mysql.Conn(ctx, s.DB)
+
+		var current int
+		err := conn.QueryRowContext(
+			ctx,
+			`SELECT COALESCE(MAX(version), 0) FROM category_events WHERE stream_id = ? FOR UPDATE`,
+			streamID,
+		).Scan(&current)
+		if err != nil {
+			return err
+		}
+
+		if current != expectedVersion {
+			return ErrConcurrencyConflict
+		}
+
+		for i, record := range records {
+			_, err := conn.ExecContext(
+				ctx,
+				`INSERT INTO category_events (stream_id, version, event_type, data, occurred_at) VALUES (?, ?, ?, ?, ?)`,
+				streamID,
+				expectedVersion+i+1,
+				record.Type,
+				record.Data,
+				record.OccurredAt,
+			)
+
+			var mysqlErr *mysqlDriver.MySQLError
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+				return ErrConcurrencyConflict
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *MySQLEventStore) Load(ctx context.Context, streamID string, afterVersion int) ([]Record, error) {
+	rows, err := mysql.Conn(ctx, s.DB).QueryContext(ctx, `
+		SELECT stream_id, version, event_type, data, occurred_at
+		FROM category_events
+		WHERE stream_id = ? AND version > ?
+		ORDER BY version ASC
+	`, streamID, afterVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+
+	for rows.Next() {
+		var record Record
+
+		if err := rows.Scan(
+			&record.StreamID,
+			&record.Version,
+			&record.Type,
+			&record.Data,
+			&record.OccurredAt,
+		); err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+func (s *MySQLEventStore) SaveSnapshot(ctx context.Context, snapshot Snapshot) error {
+	_, err := mysql.Conn(ctx, s.DB).ExecContext(ctx, `
+		INSERT INTO category_snapshots (stream_id, version, state, taken_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE version = VALUES(version), state = VALUES(state), taken_at = VALUES(taken_at)
+	`, snapshot.StreamID, snapshot.Version, snapshot.State, snapshot.TakenAt)
+
+	return err
+}
+
+func (s *MySQLEventStore) LoadSnapshot(ctx context.Context, streamID string) (*Snapshot, error) {
+	var snapshot Snapshot
+
+	err := mysql.Conn(ctx, s.DB).QueryRowContext(
+		ctx,
+		`SELECT stream_id, version, state, taken_at FROM category_snapshots WHERE stream_id = ?`,
+		streamID,
+	).Scan(&snapshot.StreamID, &snapshot.Version, &snapshot.State, &snapshot.TakenAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+func (s *MySQLEventStore) StreamIDs(ctx context.Context) ([]string, error) {
+	rows, err := mysql.Conn(ctx, s.DB).QueryContext(ctx, `
+		SELECT stream_id
+		FROM category_events
+		WHERE version = 1
+		ORDER BY occurred_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var streamIDs []string
+
+	for rows.Next() {
+		var streamID string
+		if err := rows.Scan(&streamID); err != nil {
+			return nil, err
+		}
+		streamIDs = append(streamIDs, streamID)
+	}
+
+	return streamIDs, rows.Err()
+}
@@ -0,0 +1,46 @@
+package eventsourcing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+// Projector keeps a read model in sync with the event store. A nil category
+// means the stream has been deleted.
+type Projector interface {
+	Project(ctx context.Context, id category.CategoryID, cat *category.Category) error
+}
+
+// ReadModelProjector writes the current state of each category to a regular
+// gateway, typically the MySQL "categories" table that FindAll reads from.
+// Projections are upserts so the read model can be rebuilt from scratch.
+type ReadModelProjector struct {
+	ReadModel category.CategoryGateway
+}
+
+func NewReadModelProjector(readModel category.CategoryGateway) *ReadModelProjector {
+	return &ReadModelProjector{ReadModel: readModel}
+}
+
+func (p *ReadModelProjector) Project(ctx context.Context, id category.CategoryID, cat *category.Category) error {
+	_, err := p.ReadModel.GetCategoryByID(ctx, id)
+	exists := err == nil
+	if err != nil && !errors.Is(err, category.ErrCategoryNotFound) {
+		return err
+	}
+
+	switch {
+	case cat == nil && exists:
+		return p.ReadModel.DeleteCategory(ctx, id)
+	case cat == nil:
+		return nil
+	case exists:
+		_, err = p.ReadModel.UpdateCategory(ctx, cat)
+		return err
+	default:
+		_, err = p.ReadModel.CreateCategory(ctx, cat)
+		return err
+	}
+}
@@ -2,32 +2,62 @@
 package persistence
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
 	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	mysqlDuplicateEntry = 1062
+	// nameIndex and slugIndex are the unique indexes on
+	// categories.normalized_name and categories.slug.
+	nameIndex = "idx_categories_normalized_name"
+	slugIndex = "idx_categories_slug"
+	// slugRegistry holds every current slug and alias; its triggers reject a
+	// slug another category holds with a duplicate key naming this table.
+	slugRegistry = "category_slugs"
 )
 
+// MySQLCategoryGateway joins the transaction of a mysql.Transactor when the
+// context carries one.
 type MySQLCategoryGateway struct {
 	DB *sql.DB
+	// Tracer records one span per SQL statement. It defaults to the
+	// globally registered provider.
+	Tracer trace.Tracer
 }
 
 func NewMySQLCategoryGateway(db *sql.DB) *MySQLCategoryGateway {
-	return &MySQLCategoryGateway{DB: db}
+	return &MySQLCategoryGateway{DB: db, Tracer: otel.Tracer(tracerName)}
 }
 
-func (g *MySQLCategoryGateway) CreateCategory(cat *category.Category) (*category.Category, error) {
+func (g *MySQLCategoryGateway) CreateCategory(ctx context.Context, cat *category.Category) (_ *category.Category, err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "INSERT", "categories")
+	defer func() { end(err) }()
+
 	query := `
-		INSERT INTO categories (id, name, description, activated, created_at, updated_at, deleted_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO categories (id, name, normalized_name, slug, parent_id, description, activated, created_at, updated_at, deleted_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := g.DB.Exec(
+	_, err = mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
 		query,
 		cat.ID.String(),
 		cat.Name,
+		category.NormalizeName(cat.Name),
+		cat.Slug,
+		nullParentID(cat.ParentID),
 		cat.Description,
 		cat.IsActive,
 		cat.CreatedAt,
@@ -36,27 +66,112 @@ func (g *MySQLCategoryGateway) CreateCategory(cat *category.Category) (*category
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, g.conflict(ctx, cat, err)
 	}
 
 	return cat, nil
 }
 
-func (g *MySQLCategoryGateway) GetCategoryByID(id category.CategoryID) (*category.Category, error) {
+func (g *MySQLCategoryGateway) GetCategoryByID(ctx context.Context, id category.CategoryID) (_ *category.Category, err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "SELECT", "categories")
+	defer func() { end(err) }()
+
 	query := `
-		SELECT id, name, description, activated, created_at, updated_at, deleted_at
+		SELECT id, name, slug, parent_id, description, activated, created_at, updated_at, deleted_at
 		FROM categories
 		WHERE id = ?
 	`
 
-	row := g.DB.QueryRow(query, id.String())
+	return scanCategory(mysql.Conn(ctx, g.DB).QueryRowContext(ctx, query, id.String()))
+}
+
+func (g *MySQLCategoryGateway) FindCategoryByName(ctx context.Context, name string) (_ *category.Category, err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "SELECT", "categories")
+	defer func() { end(err) }()
+
+	query := `
+		SELECT id, name, slug, parent_id, description, activated, created_at, updated_at, deleted_at
+		FROM categories
+		WHERE normalized_name = ?
+	`
+
+	return scanCategory(mysql.Conn(ctx, g.DB).QueryRowContext(ctx, query, category.NormalizeName(name)))
+}
+
+// FindCategoryBySlug looks at current slugs before aliases, so a slug is
+// never resolved through an alias while a category holds it.
+func (g *MySQLCategoryGateway) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	cat, err := g.findBySlug(ctx, slug)
+	if !errors.Is(err, category.ErrCategoryNotFound) {
+		return cat, err
+	}
+	return g.findBySlugAlias(ctx, slug)
+}
+
+func (g *MySQLCategoryGateway) findBySlug(ctx context.Context, slug string) (_ *category.Category, err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "SELECT", "categories")
+	defer func() { end(err) }()
+
+	query := `
+		SELECT id, name, slug, parent_id, description, activated, created_at, updated_at, deleted_at
+		FROM categories
+		WHERE slug = ?
+	`
+
+	return scanCategory(mysql.Conn(ctx, g.DB).QueryRowContext(ctx, query, slug))
+}
+
+func (g *MySQLCategoryGateway) findBySlugAlias(ctx context.Context, slug string) (_ *category.Category, err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "SELECT", "category_slug_aliases")
+	defer func() { end(err) }()
+
+	query := `
+		SELECT c.id, c.name, c.slug, c.parent_id, c.description, c.activated, c.created_at, c.updated_at, c.deleted_at
+		FROM category_slug_aliases a
+		JOIN categories c ON c.id = a.category_id
+		WHERE a.slug = ?
+	`
+
+	return scanCategory(mysql.Conn(ctx, g.DB).QueryRowContext(ctx, query, slug))
+}
+
+// conflict turns a violation of the unique name or slug index into the
+// matching domain error, naming the category that holds the value.
+func (g *MySQLCategoryGateway) conflict(ctx context.Context, cat *category.Category, err error) error {
+	var mysqlErr *mysqlDriver.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlDuplicateEntry {
+		return err
+	}
+
+	switch {
+	case strings.Contains(mysqlErr.Message, nameIndex):
+		existing, findErr := g.FindCategoryByName(ctx, cat.Name)
+		if findErr != nil {
+			return fmt.Errorf("%w: %q", category.ErrNameConflict, cat.Name)
+		}
+		return &category.NameConflictError{Name: cat.Name, ExistingID: existing.ID}
+	case strings.Contains(mysqlErr.Message, slugIndex), strings.Contains(mysqlErr.Message, slugRegistry):
+		existing, findErr := g.FindCategoryBySlug(ctx, cat.Slug)
+		if findErr != nil {
+			return fmt.Errorf("%w: %q", category.ErrSlugConflict, cat.Slug)
+		}
+		return &category.SlugConflictError{Slug: cat.Slug, ExistingID: existing.ID}
+	default:
+		return err
+	}
+}
 
+func scanCategory(row rowScanner) (*category.Category, error) {
 	var cat category.Category
+	var rawID string
+	var parentID sql.NullString
 	var deletedAt sql.NullTime
 
 	err := row.Scan(
-		&cat.ID,
+		&rawID,
 		&cat.Name,
+		&cat.Slug,
+		&parentID,
 		&cat.Description,
 		&cat.IsActive,
 		&cat.CreatedAt,
@@ -64,10 +179,25 @@ func (g *MySQLCategoryGateway) GetCategoryByID(id category.CategoryID) (*categor
 		&deletedAt,
 	)
 
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, category.ErrCategoryNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if cat.ID, err = category.ParseCategoryID(rawID); err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		parsed, err := category.ParseCategoryID(parentID.String)
+		if err != nil {
+			return nil, err
+		}
+		cat.ParentID = &parsed
+	}
+
 	if deletedAt.Valid {
 		cat.DeletedAt = deletedAt.Time
 	}
@@ -75,16 +205,43 @@ func (g *MySQLCategoryGateway) GetCategoryByID(id category.CategoryID) (*categor
 	return &cat, nil
 }
 
-func (g *MySQLCategoryGateway) UpdateCategory(cat *category.Category) (*category.Category, error) {
+// UpdateCategory keeps the slug being replaced as an alias of the category,
+// in the same transaction as the update, and drops the alias the new slug
+// may have been.
+func (g *MySQLCategoryGateway) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	err := mysql.NewTransactor(g.DB).WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := g.keepSlugAlias(ctx, cat); err != nil {
+			return err
+		}
+		if err := g.dropSlugAlias(ctx, cat); err != nil {
+			return err
+		}
+		return g.update(ctx, cat)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cat, nil
+}
+
+func (g *MySQLCategoryGateway) update(ctx context.Context, cat *category.Category) (err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "UPDATE", "categories")
+	defer func() { end(err) }()
+
 	query := `
 		UPDATE categories
-		SET name = ?, description = ?, activated = ?, updated_at = ?, deleted_at = ?
+		SET name = ?, normalized_name = ?, slug = ?, parent_id = ?, description = ?, activated = ?, updated_at = ?, deleted_at = ?
 		WHERE id = ?
 	`
 
-	_, err := g.DB.Exec(
+	_, err = mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
 		query,
 		cat.Name,
+		category.NormalizeName(cat.Name),
+		cat.Slug,
+		nullParentID(cat.ParentID),
 		cat.Description,
 		cat.IsActive,
 		cat.UpdatedAt,
@@ -93,87 +250,165 @@ func (g *MySQLCategoryGateway) UpdateCategory(cat *category.Category) (*category
 	)
 
 	if err != nil {
-		return nil, err
+		return g.conflict(ctx, cat, err)
 	}
 
-	return cat, nil
+	return nil
 }
 
-func (g *MySQLCategoryGateway) DeleteCategory(id category.CategoryID) error {
-	query := `DELETE FROM categories WHERE id = ?`
-	_, err := g.DB.Exec(query, id.String())
+// keepSlugAlias fails with ErrSlugConflict rather than taking over an alias
+// another category holds, which would move that category's old URLs here.
+func (g *MySQLCategoryGateway) keepSlugAlias(ctx context.Context, cat *category.Category) (err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "INSERT", "category_slug_aliases")
+	defer func() { end(err) }()
+
+	query := `
+		INSERT INTO category_slug_aliases (slug, category_id, created_at)
+		SELECT slug, id, ? FROM categories WHERE id = ? AND slug <> ?
+	`
+
+	_, err = mysql.Conn(ctx, g.DB).ExecContext(ctx, query, cat.UpdatedAt, cat.ID.String(), cat.Slug)
+
+	var mysqlErr *mysqlDriver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return fmt.Errorf("%w: the slug category %s is leaving is held by another category", category.ErrSlugConflict, cat.ID)
+	}
+
 	return err
 }
 
-func (g *MySQLCategoryGateway) FindAll(query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
-	offset := (query.Page - 1) * query.PerPage
+func (g *MySQLCategoryGateway) dropSlugAlias(ctx context.Context, cat *category.Category) (err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "DELETE", "category_slug_aliases")
+	defer func() { end(err) }()
+
+	query := `DELETE FROM category_slug_aliases WHERE slug = ? AND category_id = ?`
+	_, err = mysql.Conn(ctx, g.DB).ExecContext(ctx, query, cat.Slug, cat.ID.String())
+	return err
+}
+
+func (g *MySQLCategoryGateway) DeleteCategory(ctx context.Context, id category.CategoryID) (err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "DELETE", "categories")
+	defer func() { end(err) }()
+
+	query := `DELETE FROM categories WHERE id = ?`
+	_, err = mysql.Conn(ctx, g.DB).ExecContext(ctx, query, id.String())
+	return err
+}
 
-	whereClause := ""
-	args := []any{}
+func (g *MySQLCategoryGateway) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	query := `
+		SELECT id, name, slug, parent_id, description, activated, created_at, updated_at, deleted_at
+		FROM categories
+		WHERE parent_id = ?
+		ORDER BY name, id
+	`
 
-	if query.Terms != "" {
-		whereClause = "WHERE name LIKE ? OR description LIKE ?"
-		terms := "%" + query.Terms + "%"
-		args = append(args, terms, terms)
+	var children []category.Category
+	err := g.search(ctx, query, []any{parentID.String()}, func(cat category.Category) error {
+		children = append(children, cat)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	sort := resolveSort(query.Sort)
-	direction := resolveDirection(query.Direction)
+	return children, nil
+}
+
+func (g *MySQLCategoryGateway) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	offset := (query.Page - 1) * query.PerPage
+
+	whereClause, args := searchFilter(query)
 
 	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM categories %s`, whereClause)
 
-	var total int
-	if err := g.DB.QueryRow(countQuery, args...).Scan(&total); err != nil {
+	total, err := g.count(ctx, countQuery, args)
+	if err != nil {
 		return nil, err
 	}
 
 	searchQuery := fmt.Sprintf(`
-		SELECT id, name, description, activated, created_at, updated_at, deleted_at
+		SELECT id, name, slug, parent_id, description, activated, created_at, updated_at, deleted_at
 		FROM categories
 		%s
 		ORDER BY %s %s
 		LIMIT ? OFFSET ?
-	`, whereClause, sort, direction)
+	`, whereClause, resolveSort(query.Sort), resolveDirection(query.Direction))
 
 	args = append(args, query.PerPage, offset)
 
-	rows, err := g.DB.Query(searchQuery, args...)
+	var categories []category.Category
+	err = g.search(ctx, searchQuery, args, func(cat category.Category) error {
+		categories = append(categories, cat)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var categories []category.Category
+	return &pagination.Pagination[category.Category]{
+		CurrentPage: query.Page,
+		PerPage:     query.PerPage,
+		Total:       total,
+		Items:       categories,
+	}, nil
+}
+
+// StreamCategories runs a single unpaginated query and hands rows to visit
+// as the driver reads them off the connection, so memory use does not grow
+// with the number of categories. The connection is held until visit has
+// seen the last row.
+func (g *MySQLCategoryGateway) StreamCategories(ctx context.Context, query category.SearchCategoryQuery, visit func(category.Category) error) error {
+	whereClause, args := searchFilter(query)
+
+	streamQuery := fmt.Sprintf(`
+		SELECT id, name, slug, parent_id, description, activated, created_at, updated_at, deleted_at
+		FROM categories
+		%s
+		ORDER BY %s %s, id
+	`, whereClause, resolveSort(query.Sort), resolveDirection(query.Direction))
+
+	return g.search(ctx, streamQuery, args, visit)
+}
+
+func searchFilter(query category.SearchCategoryQuery) (string, []any) {
+	if query.Terms == "" {
+		return "", nil
+	}
+	terms := "%" + query.Terms + "%"
+	return "WHERE name LIKE ? OR description LIKE ?", []any{terms, terms}
+}
+
+func (g *MySQLCategoryGateway) count(ctx context.Context, query string, args []any) (total int, err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "SELECT", "categories")
+	defer func() { end(err) }()
+
+	err = mysql.Conn(ctx, g.DB).QueryRowContext(ctx, query, args...).Scan(&total)
+	return total, err
+}
+
+func (g *MySQLCategoryGateway) search(ctx context.Context, query string, args []any, visit func(category.Category) error) (err error) {
+	ctx, end := startStatement(ctx, g.Tracer, "SELECT", "categories")
+	defer func() { end(err) }()
+
+	rows, err := mysql.Conn(ctx, g.DB).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
-		var cat category.Category
-		var deletedAt sql.NullTime
-
-		if err := rows.Scan(
-			&cat.ID,
-			&cat.Name,
-			&cat.Description,
-			&cat.IsActive,
-			&cat.CreatedAt,
-			&cat.UpdatedAt,
-			&deletedAt,
-		); err != nil {
-			return nil, err
+		cat, err := scanCategory(rows)
+		if err != nil {
+			return err
 		}
 
-		if deletedAt.Valid {
-			cat.DeletedAt = deletedAt.Time
+		if err := visit(*cat); err != nil {
+			return err
 		}
-
-		categories = append(categories, cat)
 	}
 
-	return &pagination.Pagination[category.Category]{
-		CurrentPage: query.Page,
-		PerPage:     query.PerPage,
-		Total:       total,
-		Items:       categories,
-	}, nil
+	return rows.Err()
 }
 
 func resolveSort(sort string) string {
@@ -192,6 +427,13 @@ func resolveDirection(direction string) string {
 	return "ASC"
 }
 
+func nullParentID(id *category.CategoryID) any {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
+
 func nullTime(t time.Time) any {
 	if t.IsZero() {
 		return nil
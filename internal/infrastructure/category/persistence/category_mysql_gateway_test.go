@@ -0,0 +1,141 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var errUnavailable = errors.New("database unavailable")
+
+// unavailableDriver accepts connections but fails every statement, which is
+// enough to exercise the spans without a MySQL server.
+type unavailableDriver struct{}
+
+func (unavailableDriver) Open(string) (driver.Conn, error) { return unavailableConn{}, nil }
+
+type unavailableConn struct{}
+
+func (unavailableConn) Prepare(string) (driver.Stmt, error) { return nil, errUnavailable }
+func (unavailableConn) Close() error                        { return nil }
+func (unavailableConn) Begin() (driver.Tx, error)           { return nil, errUnavailable }
+
+// duplicateDriver opens transactions but rejects every statement as a
+// duplicate of the slug alias primary key.
+type duplicateDriver struct{}
+
+func (duplicateDriver) Open(string) (driver.Conn, error) { return duplicateConn{}, nil }
+
+type duplicateConn struct{}
+
+func (duplicateConn) Prepare(string) (driver.Stmt, error) { return duplicateStmt{}, nil }
+func (duplicateConn) Close() error                        { return nil }
+func (duplicateConn) Begin() (driver.Tx, error)           { return duplicateTx{}, nil }
+
+type duplicateTx struct{}
+
+func (duplicateTx) Commit() error   { return nil }
+func (duplicateTx) Rollback() error { return nil }
+
+type duplicateStmt struct{}
+
+func (duplicateStmt) Close() error  { return nil }
+func (duplicateStmt) NumInput() int { return -1 }
+func (duplicateStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, &mysqlDriver.MySQLError{Number: mysqlDuplicateEntry, Message: "Duplicate entry 'movies' for key 'category_slug_aliases.PRIMARY'"}
+}
+func (duplicateStmt) Query([]driver.Value) (driver.Rows, error) { return nil, errUnavailable }
+
+func init() {
+	sql.Register("unavailable", unavailableDriver{})
+	sql.Register("duplicate", duplicateDriver{})
+}
+
+func newTracedGateway(t *testing.T) (*MySQLCategoryGateway, *tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	t.Helper()
+
+	db, err := sql.Open("unavailable", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	gateway := NewMySQLCategoryGateway(db)
+	gateway.Tracer = provider.Tracer(tracerName)
+	return gateway, recorder, provider
+}
+
+func TestMySQLCategoryGatewayRecordsStatementSpans(t *testing.T) {
+	gateway, recorder, provider := newTracedGateway(t)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	cat, _ := category.NewCategory("Secret Name", "secret description", true)
+
+	if _, err := gateway.CreateCategory(ctx, cat); err == nil {
+		t.Fatal("expected error from unavailable database")
+	}
+	if _, err := gateway.FindAll(ctx, category.SearchCategoryQuery{Page: 1, PerPage: 10, Terms: "secret"}); err == nil {
+		t.Fatal("expected error from unavailable database")
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+
+	insert := spans[0]
+	if insert.Name() != "INSERT categories" {
+		t.Errorf("expected span INSERT categories, got %q", insert.Name())
+	}
+	if insert.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("expected statement span to be a child of the caller's span")
+	}
+	if insert.Status().Code != codes.Error {
+		t.Errorf("expected error status, got %v", insert.Status().Code)
+	}
+
+	attrs := map[string]string{}
+	for _, kv := range insert.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["db.system.name"] != "mysql" || attrs["db.operation.name"] != "INSERT" {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+	for _, v := range attrs {
+		if strings.Contains(strings.ToLower(v), "secret") {
+			t.Errorf("span attributes leak a query parameter: %v", attrs)
+		}
+	}
+
+	if spans[1].Name() != "SELECT categories" {
+		t.Errorf("expected count span SELECT categories, got %q", spans[1].Name())
+	}
+}
+
+func TestMySQLCategoryGateway_UpdateRefusesAliasOfAnotherCategory(t *testing.T) {
+	db, err := sql.Open("duplicate", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	cat, _ := category.NewCategory("Films", "Feature films", true)
+
+	_, err = NewMySQLCategoryGateway(db).UpdateCategory(context.Background(), cat)
+	if !errors.Is(err, category.ErrSlugConflict) {
+		t.Fatalf("expected ErrSlugConflict, got %v", err)
+	}
+}
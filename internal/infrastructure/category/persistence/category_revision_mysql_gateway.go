@@ -0,0 +1,190 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+)
+
+type MySQLCategoryRevisionGateway struct {
+	DB *sql.DB
+}
+
+func NewMySQLCategoryRevisionGateway(db *sql.DB) *MySQLCategoryRevisionGateway {
+	return &MySQLCategoryRevisionGateway{DB: db}
+}
+
+const revisionColumns = `category_id, revision, event_id, name, slug, parent_id, description, activated, deleted_at, actor, recorded_at`
+
+// AppendRevision numbers the snapshot inside the transaction that stores it,
+// joining the caller's when there is one; the (category_id, revision)
+// primary key rejects a concurrent duplicate.
+func (g *MySQLCategoryRevisionGateway) AppendRevision(ctx context.Context, rev *category.CategoryRevision) (*category.CategoryRevision, error) {
+	err := mysql.NewTransactor(g.DB).WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := mysql.Conn(ctx, g.DB)
+
+		var next int
+		err := conn.QueryRowContext(
+			ctx,
+			`SELECT COALESCE(MAX(revision), 0) + 1 FROM category_revisions WHERE category_id = ? FOR UPDATE`,
+			rev.CategoryID.String(),
+		).Scan(&next)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.ExecContext(
+			ctx,
+			`INSERT INTO category_revisions (`+revisionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			rev.CategoryID.String(),
+			next,
+			rev.EventID,
+			rev.Name,
+			rev.Slug,
+			nullParentID(rev.ParentID),
+			rev.Description,
+			rev.IsActive,
+			nullTime(rev.DeletedAt),
+			rev.Actor,
+			rev.RecordedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		rev.Revision = next
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+func (g *MySQLCategoryRevisionGateway) GetRevision(ctx context.Context, id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+	row := mysql.Conn(ctx, g.DB).QueryRowContext(
+		ctx,
+		`SELECT `+revisionColumns+` FROM category_revisions WHERE category_id = ? AND revision = ?`,
+		id.String(),
+		revision,
+	)
+
+	return scanRevision(row)
+}
+
+func (g *MySQLCategoryRevisionGateway) GetRevisionAsOf(ctx context.Context, id category.CategoryID, at time.Time) (*category.CategoryRevision, error) {
+	row := mysql.Conn(ctx, g.DB).QueryRowContext(ctx, `
+		SELECT `+revisionColumns+`
+		FROM category_revisions
+		WHERE category_id = ? AND recorded_at <= ?
+		ORDER BY revision DESC
+		LIMIT 1
+	`, id.String(), at)
+
+	return scanRevision(row)
+}
+
+func (g *MySQLCategoryRevisionGateway) FindRevisions(ctx context.Context, query category.SearchRevisionQuery) (*pagination.Pagination[category.CategoryRevision], error) {
+	offset := (query.Page - 1) * query.PerPage
+
+	var total int
+	err := mysql.Conn(ctx, g.DB).QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM category_revisions WHERE category_id = ?`,
+		query.CategoryID.String(),
+	).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := mysql.Conn(ctx, g.DB).QueryContext(ctx, `
+		SELECT `+revisionColumns+`
+		FROM category_revisions
+		WHERE category_id = ?
+		ORDER BY revision DESC
+		LIMIT ? OFFSET ?
+	`, query.CategoryID.String(), query.PerPage, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []category.CategoryRevision
+
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *rev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pagination.Pagination[category.CategoryRevision]{
+		CurrentPage: query.Page,
+		PerPage:     query.PerPage,
+		Total:       total,
+		Items:       revisions,
+	}, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRevision(row rowScanner) (*category.CategoryRevision, error) {
+	var rev category.CategoryRevision
+	var rawID string
+	var slug, parentID, description sql.NullString
+	var deletedAt sql.NullTime
+
+	err := row.Scan(
+		&rawID,
+		&rev.Revision,
+		&rev.EventID,
+		&rev.Name,
+		&slug,
+		&parentID,
+		&description,
+		&rev.IsActive,
+		&deletedAt,
+		&rev.Actor,
+		&rev.RecordedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, category.ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if rev.CategoryID, err = category.ParseCategoryID(rawID); err != nil {
+		return nil, err
+	}
+
+	rev.Slug = slug.String
+	rev.Description = description.String
+
+	if parentID.Valid {
+		parsed, err := category.ParseCategoryID(parentID.String)
+		if err != nil {
+			return nil, err
+		}
+		rev.ParentID = &parsed
+	}
+
+	if deletedAt.Valid {
+		rev.DeletedAt = deletedAt.Time
+	}
+
+	return &rev, nil
+}
@@ -0,0 +1,41 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/category/persistence"
+
+// startStatement opens a client span for a single SQL statement. Only the
+// statement type and table are recorded; query parameters never reach the
+// span.
+func startStatement(ctx context.Context, tracer trace.Tracer, operation, table string) (context.Context, func(error)) {
+	if tracer == nil {
+		return ctx, func(error) {}
+	}
+
+	ctx, span := tracer.Start(ctx, operation+" "+table,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "mysql"),
+			attribute.String("db.operation.name", operation),
+			attribute.String("db.collection.name", table),
+		),
+	)
+
+	return ctx, func(err error) {
+		// A missing row is an expected outcome, not a failed statement.
+		if err != nil && !errors.Is(err, sql.ErrNoRows) && !errors.Is(err, category.ErrCategoryNotFound) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
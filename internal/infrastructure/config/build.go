@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/idempotency"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/logging"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/ratelimit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/tracing"
+)
+
+// build converts the resolved values into the typed configuration,
+// collecting every parse and validation error instead of stopping at the
+// first one.
+func build(values map[string]value) (*Config, Errors) {
+	p := &parser{values: values}
+	cfg := &Config{values: values}
+
+	cfg.Server.Addr = p.required("http.addr")
+	cfg.Server.ReadHeaderTimeout = p.duration("http.read_header_timeout", 0)
+	cfg.Server.ReadTimeout = p.duration("http.read_timeout", 0)
+	cfg.Server.WriteTimeout = p.duration("http.write_timeout", 0)
+	cfg.Server.IdleTimeout = p.duration("http.idle_timeout", 0)
+	cfg.Server.ReadinessDelay = p.duration("http.shutdown_readiness_delay", 0)
+	cfg.Server.ShutdownTimeout = p.duration("http.shutdown_timeout", time.Nanosecond)
+
+	cfg.Database.Host = p.required("db.host")
+	cfg.Database.Port = p.intRange("db.port", 1, 65535)
+	cfg.Database.User = p.required("db.user")
+	cfg.Database.Password = p.string("db.password")
+	cfg.Database.Database = p.required("db.name")
+	cfg.Database.MaxOpenConns = p.intRange("db.max_open_conns", 1, 0)
+	cfg.Database.MaxIdleConns = p.intRange("db.max_idle_conns", 0, 0)
+	cfg.Database.ConnMaxLifetime = time.Duration(p.intRange("db.conn_max_lifetime", 0, 0)) * time.Minute
+
+	cfg.Migrate.Auto = p.bool("migrate.auto")
+	cfg.Migrate.Dir = p.string("migrate.dir")
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(p.string("log.level"))); err != nil {
+		p.invalid("log.level", "expected debug, info, warn or error")
+	}
+	cfg.Log.Level = level
+	cfg.Log.Format = p.oneOf("log.format", logging.FormatJSON, logging.FormatText)
+
+	cfg.Auth.JWKSFile = p.string("auth.jwks_file")
+	cfg.Auth.JWKSURL = p.string("auth.jwks_url")
+	cfg.Auth.HS256Secret = p.string("auth.hs256_secret")
+	cfg.Auth.Issuer = p.string("auth.issuer")
+	cfg.Auth.Audience = p.string("auth.audience")
+	cfg.Auth.Leeway = time.Duration(p.intRange("auth.leeway_seconds", 0, 0)) * time.Second
+	cfg.Auth.RolePermissionsFile = p.string("auth.role_permissions_file")
+
+	cfg.RateLimit.Enabled = p.bool("rate_limit.enabled")
+	cfg.RateLimit.Groups = make(map[string]ratelimit.Limit)
+	for _, group := range []string{ratelimit.GroupIP, ratelimit.GroupRead, ratelimit.GroupWrite, ratelimit.GroupAdmin} {
+		key := "rate_limit." + group
+		limit, err := ratelimit.ParseLimit(p.string(key))
+		if err != nil {
+			p.invalid(key, "expected <requests>/<period>, e.g. 100/1m")
+		}
+		cfg.RateLimit.Groups[group] = limit
+	}
+
+	cfg.Idempotency.TTL = p.duration("idempotency.ttl", time.Second)
+	cfg.Idempotency.Store = p.oneOf("idempotency.store", idempotency.StoreMySQL, idempotency.StoreMemory)
+
+	cfg.Tracing.Exporter = p.oneOf("tracing.exporter", tracing.ExporterOTLP, tracing.ExporterStdout, tracing.ExporterNone)
+	cfg.Tracing.ServiceName = p.required("tracing.service_name")
+	cfg.Tracing.SampleRatio = p.ratio("tracing.sample_ratio")
+
+	cfg.Health.Timeout = p.duration("readiness.check_timeout", time.Nanosecond)
+
+	cfg.Category.Gateway = p.oneOf("category.gateway", GatewayMySQL, GatewayEventSourced)
+	cfg.Category.SnapshotEvery = p.intRange("event_store.snapshot_every", 0, 0)
+	cfg.Category.RebuildProjection = p.bool("event_store.rebuild_projection")
+	cfg.Category.ImportMaxRows = p.intRange("category.import_max_rows", 1, 0)
+	cfg.Category.BatchMaxSize = p.intRange("category.batch_max_size", 1, 0)
+
+	return cfg, p.errs
+}
+
+type parser struct {
+	values map[string]value
+	errs   Errors
+}
+
+func (p *parser) invalid(key, expectation string) {
+	s, _ := lookupSetting(key)
+	v := p.values[key]
+	p.errs = append(p.errs, fmt.Errorf("%s (%s): invalid value %q from %s: %s", key, s.Env, p.display(key), v.source, expectation))
+}
+
+// display hides secrets from error messages.
+func (p *parser) display(key string) string {
+	if s, _ := lookupSetting(key); s.Secret && p.values[key].raw != "" {
+		return redacted
+	}
+	return p.values[key].raw
+}
+
+func (p *parser) string(key string) string {
+	return strings.TrimSpace(p.values[key].raw)
+}
+
+func (p *parser) required(key string) string {
+	v := p.string(key)
+	if v == "" {
+		p.invalid(key, "must not be empty")
+	}
+	return v
+}
+
+func (p *parser) oneOf(key string, allowed ...string) string {
+	v := strings.ToLower(p.string(key))
+	for _, a := range allowed {
+		if v == a {
+			return v
+		}
+	}
+	p.invalid(key, "expected one of "+strings.Join(allowed, ", "))
+	return v
+}
+
+// intRange parses an integer of at least min and, when max is positive, at
+// most max.
+func (p *parser) intRange(key string, min, max int) int {
+	v, err := strconv.Atoi(p.string(key))
+	switch {
+	case err != nil:
+		p.invalid(key, "expected an integer")
+	case v < min:
+		p.invalid(key, fmt.Sprintf("must be at least %d", min))
+	case max > 0 && v > max:
+		p.invalid(key, fmt.Sprintf("must be at most %d", max))
+	}
+	return v
+}
+
+func (p *parser) duration(key string, min time.Duration) time.Duration {
+	v, err := time.ParseDuration(p.string(key))
+	switch {
+	case err != nil:
+		p.invalid(key, "expected a duration such as 500ms, 15s or 1m")
+	case v < min:
+		if min > 0 {
+			p.invalid(key, "must be positive")
+		} else {
+			p.invalid(key, "must not be negative")
+		}
+	}
+	return v
+}
+
+func (p *parser) bool(key string) bool {
+	v, err := strconv.ParseBool(p.string(key))
+	if err != nil {
+		p.invalid(key, "expected true or false")
+	}
+	return v
+}
+
+func (p *parser) ratio(key string) float64 {
+	v, err := strconv.ParseFloat(p.string(key), 64)
+	if err != nil || v < 0 || v > 1 {
+		p.invalid(key, "expected a number between 0 and 1")
+	}
+	return v
+}
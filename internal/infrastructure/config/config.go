@@ -0,0 +1,263 @@
+// Package config loads the application configuration from, in increasing
+// precedence: built-in defaults, a YAML or JSON file, a .env file, the
+// environment and command-line flags. Every value is validated up front and
+// all problems are reported together.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/auth"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/health"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/idempotency"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/logging"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/ratelimit"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/server"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/tracing"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources a value can come from, lowest precedence first.
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnvFile = "env-file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+const defaultEnvFile = ".env"
+
+type Config struct {
+	Server      server.Config
+	Database    mysql.Config
+	Migrate     MigrateConfig
+	Log         logging.Config
+	Auth        auth.Config
+	RateLimit   ratelimit.Config
+	Idempotency idempotency.Config
+	Tracing     tracing.Config
+	Health      health.Config
+	Category    CategoryConfig
+
+	values map[string]value
+}
+
+type MigrateConfig struct {
+	// Auto applies pending migrations at server start; when off, the schema
+	// is managed with the migrate command.
+	Auto bool
+	// Dir overrides the migrations embedded in the binary.
+	Dir string
+}
+
+type CategoryConfig struct {
+	// Gateway selects the category repository: GatewayMySQL or
+	// GatewayEventSourced.
+	Gateway           string
+	SnapshotEvery     int
+	RebuildProjection bool
+	// ImportMaxRows caps the rows of one bulk import.
+	ImportMaxRows int
+	// BatchMaxSize caps the operations of one batch request.
+	BatchMaxSize int
+}
+
+const (
+	GatewayMySQL        = "mysql"
+	GatewayEventSourced = "eventsourced"
+)
+
+type value struct {
+	raw    string
+	source string
+}
+
+// Errors lists every configuration problem found.
+type Errors []error
+
+func (e Errors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = "  - " + err.Error()
+	}
+	return "invalid configuration:\n" + strings.Join(lines, "\n")
+}
+
+// Flags are the configuration flags registered on a command's flag set.
+type Flags struct {
+	set        *flag.FlagSet
+	configFile *string
+	envFile    *string
+	values     map[string]*string
+}
+
+// RegisterFlags adds -config, -env-file and one flag per setting (e.g.
+// -db.host) to fs, so commands can mix them with their own flags.
+func RegisterFlags(set *flag.FlagSet) *Flags {
+	f := &Flags{
+		set:        set,
+		configFile: set.String("config", "", "YAML or JSON configuration file (env CONFIG_FILE)"),
+		envFile:    set.String("env-file", defaultEnvFile, "dotenv file overlaid below the environment, empty to skip"),
+		values:     make(map[string]*string, len(settings)),
+	}
+
+	for _, s := range settings {
+		usage := s.Usage + " (env " + s.Env
+		if s.Default != "" {
+			usage += fmt.Sprintf(", default %q", s.Default)
+		}
+		f.values[s.Key] = set.String(s.Key, "", usage+")")
+	}
+
+	return f
+}
+
+// Load parses args as flags and resolves every setting; see Flags.Load.
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	set := flag.NewFlagSet("config", flag.ContinueOnError)
+	set.SetOutput(io.Discard)
+
+	flags := RegisterFlags(set)
+	if err := set.Parse(args); err != nil {
+		return nil, err
+	}
+	if set.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(set.Args(), " "))
+	}
+
+	return flags.Load(lookupEnv)
+}
+
+// Load resolves every setting once the flag set has been parsed. lookupEnv
+// is normally os.LookupEnv. The config file is named by -config or
+// CONFIG_FILE; the .env file by -env-file (empty to skip), and a missing
+// default .env is not an error. When only values are invalid the resolved
+// Config is returned along with Errors, so it can still be printed for
+// debugging.
+func (f *Flags) Load(lookupEnv func(string) (string, bool)) (*Config, error) {
+	explicit := map[string]bool{}
+	f.set.Visit(func(fl *flag.Flag) { explicit[fl.Name] = true })
+
+	values := make(map[string]value, len(settings))
+	for _, s := range settings {
+		values[s.Key] = value{raw: s.Default, source: SourceDefault}
+	}
+
+	var errs Errors
+
+	configFile := *f.configFile
+	if configFile == "" {
+		configFile, _ = lookupEnv("CONFIG_FILE")
+	}
+	if configFile != "" {
+		fileValues, err := readFile(configFile)
+		var fileErrs Errors
+		if errors.As(err, &fileErrs) {
+			errs = append(errs, fileErrs...)
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+		for key, raw := range fileValues {
+			values[key] = value{raw: raw, source: SourceFile}
+		}
+	}
+
+	dotenv := map[string]string{}
+	if *f.envFile != "" {
+		var err error
+		dotenv, err = godotenv.Read(*f.envFile)
+		if err != nil && !(errors.Is(err, fs.ErrNotExist) && !explicit["env-file"]) {
+			errs = append(errs, fmt.Errorf("env file: %w", err))
+		}
+	}
+
+	for _, s := range settings {
+		if raw, ok := dotenv[s.Env]; ok {
+			values[s.Key] = value{raw: raw, source: SourceEnvFile}
+		}
+		if raw, ok := lookupEnv(s.Env); ok {
+			values[s.Key] = value{raw: raw, source: SourceEnv}
+		}
+		if explicit[s.Key] {
+			values[s.Key] = value{raw: *f.values[s.Key], source: SourceFlag}
+		}
+	}
+
+	cfg, buildErrs := build(values)
+	errs = append(errs, buildErrs...)
+	if len(errs) > 0 {
+		return cfg, errs
+	}
+
+	return cfg, nil
+}
+
+// readFile flattens a YAML or JSON document into dotted keys, e.g.
+// {"db": {"host": "x"}} becomes db.host.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+
+	var document map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &document)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &document)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported extension, expected .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	var errs Errors
+	flatten("", document, values, &errs)
+
+	for key := range values {
+		if _, ok := lookupSetting(key); !ok {
+			errs = append(errs, fmt.Errorf("config file %s: unknown setting %q", path, key))
+			delete(values, key)
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+		return values, errs
+	}
+	return values, nil
+}
+
+func flatten(prefix string, node map[string]any, out map[string]string, errs *Errors) {
+	for key, raw := range node {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := raw.(type) {
+		case map[string]any:
+			flatten(key, v, out, errs)
+		case []any:
+			*errs = append(*errs, fmt.Errorf("%s: expected a single value, got a list", key))
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// noEnvFile keeps tests independent of a .env in the working directory.
+const noEnvFile = "-env-file="
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load([]string{noEnvFile}, env(map[string]string{"AUTH_HS256_SECRET": "dev"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Server.Addr != ":8080" || cfg.Server.ReadinessDelay != 5*time.Second || cfg.Server.ShutdownTimeout != 20*time.Second {
+		t.Errorf("unexpected server defaults %+v", cfg.Server)
+	}
+	if cfg.Database.Port != 3306 || cfg.Database.ConnMaxLifetime != 5*time.Minute {
+		t.Errorf("unexpected database defaults %+v", cfg.Database)
+	}
+	if cfg.Category.Gateway != GatewayMySQL || cfg.Category.SnapshotEvery != 50 {
+		t.Errorf("unexpected category defaults %+v", cfg.Category)
+	}
+	if !cfg.RateLimit.Enabled || cfg.RateLimit.Groups["read"].Requests != 300 {
+		t.Errorf("unexpected rate limit defaults %+v", cfg.RateLimit)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeFile(t, "catalog.yaml", `
+db:
+  host: file-host
+  port: 3310
+  user: file-user
+  name: file-db
+http:
+  addr: ":7000"
+auth:
+  hs256_secret: file-secret
+`)
+	envFile := writeFile(t, "test.env", "DB_USER=envfile-user\nDB_NAME=envfile-db\n")
+
+	cfg, err := Load(
+		[]string{"-config", file, "-env-file", envFile, "-db.name=flag-db"},
+		env(map[string]string{"DB_USER": "env-user", "DB_NAME": "env-db"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Database.Host != "file-host" || cfg.Database.Port != 3310 {
+		t.Errorf("expected file values, got %+v", cfg.Database)
+	}
+	if cfg.Database.User != "env-user" {
+		t.Errorf("expected environment to override the env file, got %q", cfg.Database.User)
+	}
+	if cfg.Database.Database != "flag-db" {
+		t.Errorf("expected flag to win, got %q", cfg.Database.Database)
+	}
+	if cfg.Server.Addr != ":7000" || cfg.Server.ReadTimeout != 15*time.Second {
+		t.Errorf("expected file value over default, got %+v", cfg.Server)
+	}
+
+	for key, source := range map[string]string{
+		"db.host":     SourceFile,
+		"db.user":     SourceEnv,
+		"db.name":     SourceFlag,
+		"db.password": SourceDefault,
+	} {
+		if got := cfg.Source(key); got != source {
+			t.Errorf("expected %s from %s, got %s", key, source, got)
+		}
+	}
+}
+
+func TestLoadJSONFile(t *testing.T) {
+	file := writeFile(t, "catalog.json", `{"auth": {"hs256_secret": "s"}, "tracing": {"exporter": "stdout", "sample_ratio": 0.25}}`)
+
+	cfg, err := Load([]string{"-config", file, noEnvFile}, env(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Tracing.Exporter != "stdout" || cfg.Tracing.SampleRatio != 0.25 {
+		t.Errorf("unexpected tracing config %+v", cfg.Tracing)
+	}
+}
+
+func TestLoadReportsEveryError(t *testing.T) {
+	file := writeFile(t, "catalog.yaml", "db:\n  hots: typo\n")
+
+	cfg, err := Load([]string{"-config", file, noEnvFile, "-log.level=loud"}, env(map[string]string{
+		"DB_PORT":          "abc",
+		"RATE_LIMIT_WRITE": "lots",
+		"CATEGORY_GATEWAY": "redis",
+	}))
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected Errors, got %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("expected the resolved config alongside validation errors")
+	}
+
+	for _, want := range []string{
+		`unknown setting "db.hots"`,
+		"db.port (DB_PORT)",
+		"log.level (LOG_LEVEL)",
+		"rate_limit.write (RATE_LIMIT_WRITE)",
+		"category.gateway (CATEGORY_GATEWAY)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in:\n%s", want, err)
+		}
+	}
+	if len(errs) != 5 {
+		t.Errorf("expected 5 errors, got %d:\n%s", len(errs), err)
+	}
+}
+
+func TestLoadRejectsMissingExplicitEnvFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.env")
+
+	_, err := Load([]string{"-env-file", missing}, env(map[string]string{"AUTH_HS256_SECRET": "dev"}))
+	if err == nil || !strings.Contains(err.Error(), missing) {
+		t.Errorf("expected missing env file to be reported, got %v", err)
+	}
+}
+
+func TestLoadHelp(t *testing.T) {
+	if _, err := Load([]string{"-h"}, env(nil)); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp, got %v", err)
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	cfg, err := Load([]string{noEnvFile, "-db.password=hunter2"}, env(map[string]string{
+		"AUTH_HS256_SECRET": "top-secret",
+		"DB_HOST":           "mysql.internal",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := cfg.Print(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	printed := out.String()
+	if strings.Contains(printed, "hunter2") || strings.Contains(printed, "top-secret") {
+		t.Fatalf("expected secrets to be redacted:\n%s", printed)
+	}
+	for _, want := range []string{
+		"password: '[REDACTED]' # flag (DB_PASSWORD)",
+		"host: mysql.internal # env (DB_HOST)",
+	} {
+		if !strings.Contains(printed, want) {
+			t.Errorf("expected %q in:\n%s", want, printed)
+		}
+	}
+
+	// The dump is itself a valid config file.
+	file := writeFile(t, "printed.yaml", printed)
+	if _, err := Load([]string{"-config", file, noEnvFile}, env(nil)); err != nil {
+		t.Errorf("expected printed config to load back, got %v", err)
+	}
+}
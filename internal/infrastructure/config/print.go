@@ -0,0 +1,62 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// Print writes the effective configuration as YAML, annotating each value
+// with where it came from. Secrets are redacted, so the output is safe to
+// share when debugging.
+func (c *Config) Print(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := map[string]*yaml.Node{}
+
+	for _, s := range settings {
+		section, name, _ := strings.Cut(s.Key, ".")
+
+		node, ok := sections[section]
+		if !ok {
+			node = &yaml.Node{Kind: yaml.MappingNode}
+			sections[section] = node
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: section}, node)
+		}
+
+		v := c.values[s.Key]
+		raw := v.raw
+		if s.Secret && raw != "" {
+			raw = redacted
+		}
+
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: name},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: raw, LineComment: v.source + " (" + s.Env + ")"},
+		)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// Source reports where the value of key came from.
+func (c *Config) Source(key string) string {
+	return c.values[key].source
+}
+
+// PrintUsage lists every configuration flag with its environment variable
+// and default.
+func PrintUsage(w io.Writer) {
+	set := flag.NewFlagSet("config", flag.ContinueOnError)
+	set.SetOutput(w)
+	RegisterFlags(set)
+	set.PrintDefaults()
+}
@@ -0,0 +1,76 @@
+package config
+
+// setting is one configuration value. Key names it in config files and on
+// the command line (-db.host); Env is the environment variable overriding it.
+type setting struct {
+	Key     string
+	Env     string
+	Default string
+	Secret  bool
+	Usage   string
+}
+
+// settings lists every supported value, grouped by section in the order
+// they are printed.
+var settings = []setting{
+	{Key: "http.addr", Env: "HTTP_ADDR", Default: ":8080", Usage: "address the HTTP server listens on"},
+	{Key: "http.read_header_timeout", Env: "HTTP_READ_HEADER_TIMEOUT", Default: "5s", Usage: "time allowed to read request headers"},
+	{Key: "http.read_timeout", Env: "HTTP_READ_TIMEOUT", Default: "15s", Usage: "time allowed to read a whole request"},
+	{Key: "http.write_timeout", Env: "HTTP_WRITE_TIMEOUT", Default: "30s", Usage: "time allowed to write a response"},
+	{Key: "http.idle_timeout", Env: "HTTP_IDLE_TIMEOUT", Default: "60s", Usage: "keep-alive connection idle timeout"},
+	{Key: "http.shutdown_readiness_delay", Env: "HTTP_SHUTDOWN_READINESS_DELAY", Default: "5s", Usage: "time to keep serving after failing readiness on shutdown, so load balancers stop routing here first"},
+	{Key: "http.shutdown_timeout", Env: "HTTP_SHUTDOWN_TIMEOUT", Default: "20s", Usage: "time allowed for in-flight requests to drain on shutdown"},
+
+	{Key: "db.host", Env: "DB_HOST", Default: "localhost", Usage: "MySQL host"},
+	{Key: "db.port", Env: "DB_PORT", Default: "3306", Usage: "MySQL port"},
+	{Key: "db.user", Env: "DB_USER", Default: "root", Usage: "MySQL user"},
+	{Key: "db.password", Env: "DB_PASSWORD", Secret: true, Usage: "MySQL password"},
+	{Key: "db.name", Env: "DB_NAME", Default: "admin_videos", Usage: "MySQL database"},
+	{Key: "db.max_open_conns", Env: "DB_MAX_OPEN_CONNS", Default: "25", Usage: "maximum open connections"},
+	{Key: "db.max_idle_conns", Env: "DB_MAX_IDLE_CONNS", Default: "10", Usage: "maximum idle connections"},
+	{Key: "db.conn_max_lifetime", Env: "DB_CONN_MAX_LIFETIME", Default: "5", Usage: "connection lifetime in minutes"},
+
+	{Key: "migrate.auto", Env: "MIGRATE_AUTO", Default: "true", Usage: "apply pending migrations when the server starts"},
+	{Key: "migrate.dir", Env: "MIGRATIONS_DIR", Usage: "load migrations from this directory instead of the embedded set"},
+
+	{Key: "log.level", Env: "LOG_LEVEL", Default: "info", Usage: "debug, info, warn or error"},
+	{Key: "log.format", Env: "LOG_FORMAT", Default: "json", Usage: "json or text"},
+
+	{Key: "auth.jwks_file", Env: "AUTH_JWKS_FILE", Usage: "local JWKS file with RS256 keys"},
+	{Key: "auth.jwks_url", Env: "AUTH_JWKS_URL", Usage: "URL serving the RS256 JWKS"},
+	{Key: "auth.hs256_secret", Env: "AUTH_HS256_SECRET", Secret: true, Usage: "shared HS256 secret, for development"},
+	{Key: "auth.issuer", Env: "AUTH_ISSUER", Usage: "required token issuer"},
+	{Key: "auth.audience", Env: "AUTH_AUDIENCE", Usage: "required token audience"},
+	{Key: "auth.leeway_seconds", Env: "AUTH_LEEWAY_SECONDS", Default: "30", Usage: "clock skew tolerated on exp/nbf"},
+	{Key: "auth.role_permissions_file", Env: "AUTH_ROLE_PERMISSIONS_FILE", Usage: "JSON file replacing the role-to-permission mapping"},
+
+	{Key: "rate_limit.enabled", Env: "RATE_LIMIT_ENABLED", Default: "true", Usage: "enable per-client rate limiting"},
+	{Key: "rate_limit.ip", Env: "RATE_LIMIT_IP", Default: "600/1m", Usage: "limit per client IP over every route, checked before authentication"},
+	{Key: "rate_limit.read", Env: "RATE_LIMIT_READ", Default: "300/1m", Usage: "limit for read routes"},
+	{Key: "rate_limit.write", Env: "RATE_LIMIT_WRITE", Default: "60/1m", Usage: "limit for write routes"},
+	{Key: "rate_limit.admin", Env: "RATE_LIMIT_ADMIN", Default: "20/1m", Usage: "limit for admin routes"},
+
+	{Key: "idempotency.ttl", Env: "IDEMPOTENCY_TTL", Default: "24h", Usage: "how long Idempotency-Key responses are kept"},
+	{Key: "idempotency.store", Env: "IDEMPOTENCY_STORE", Default: "mysql", Usage: "mysql, shared by replicas, or memory"},
+
+	{Key: "tracing.exporter", Env: "OTEL_TRACES_EXPORTER", Default: "none", Usage: "otlp, stdout or none"},
+	{Key: "tracing.service_name", Env: "OTEL_SERVICE_NAME", Default: "code-flix-admin-catalog", Usage: "service name on exported spans"},
+	{Key: "tracing.sample_ratio", Env: "OTEL_TRACES_SAMPLER_ARG", Default: "1", Usage: "fraction of new traces sampled"},
+
+	{Key: "readiness.check_timeout", Env: "READINESS_CHECK_TIMEOUT", Default: "2s", Usage: "timeout for each readiness check"},
+
+	{Key: "category.gateway", Env: "CATEGORY_GATEWAY", Default: "mysql", Usage: "mysql or eventsourced"},
+	{Key: "category.import_max_rows", Env: "CATEGORY_IMPORT_MAX_ROWS", Default: "1000", Usage: "most rows accepted by one import"},
+	{Key: "category.batch_max_size", Env: "CATEGORY_BATCH_MAX_SIZE", Default: "100", Usage: "most operations accepted by one batch"},
+	{Key: "event_store.snapshot_every", Env: "EVENT_STORE_SNAPSHOT_EVERY", Default: "50", Usage: "events between snapshots, 0 disables them"},
+	{Key: "event_store.rebuild_projection", Env: "EVENT_STORE_REBUILD_PROJECTION", Default: "false", Usage: "rebuild the categories read table at startup"},
+}
+
+func lookupSetting(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
@@ -0,0 +1,57 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// versionFormat numbers migrations sequentially, as the existing ones are;
+// golang-migrate never runs a version below one already applied, so mixing
+// in timestamps would strand every later sequential file.
+const versionFormat = "%06d"
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create writes an empty up/down migration pair named after name into dir
+// and returns their paths. It takes the version after the highest one in
+// existing and in dir, so files created since the binary was built count.
+func Create(dir, name string, existing fs.FS) (up, down string, err error) {
+	slug := strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if slug == "" {
+		return "", "", fmt.Errorf("invalid migration name %q", name)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", err
+	}
+
+	var latest uint
+	for _, files := range []fs.FS{existing, os.DirFS(dir)} {
+		version, err := LatestVersion(files)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", "", err
+		}
+		latest = max(latest, version)
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf(versionFormat, latest+1)+"_"+slug)
+	up, down = base+".up.sql", base+".down.sql"
+
+	for _, path := range []string{up, down} {
+		// O_EXCL refuses to overwrite a migration created concurrently.
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return "", "", err
+		}
+		if err := f.Close(); err != nil {
+			return "", "", err
+		}
+	}
+
+	return up, down, nil
+}
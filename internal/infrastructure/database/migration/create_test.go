@@ -0,0 +1,72 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	existing := fstest.MapFS{
+		"000001_create_categories.up.sql":   {},
+		"000001_create_categories.down.sql": {},
+		"000012_add_slugs.up.sql":           {},
+		"000012_add_slugs.down.sql":         {},
+	}
+
+	up, down, err := Create(dir, "Add category Slugs!", existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := filepath.Join(dir, "000013_add_category_slugs.up.sql"); up != want {
+		t.Errorf("expected %s, got %s", want, up)
+	}
+	if want := filepath.Join(dir, "000013_add_category_slugs.down.sql"); down != want {
+		t.Errorf("expected %s, got %s", want, down)
+	}
+	for _, path := range []string{up, down} {
+		if _, err := os.Stat(path); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if _, _, err := Create(dir, "!!!", existing); err == nil {
+		t.Error("expected an empty name to be rejected")
+	}
+}
+
+func TestCreateStartsAtOne(t *testing.T) {
+	dir := t.TempDir()
+
+	up, _, err := Create(dir, "first", fstest.MapFS{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "000001_first.up.sql"); up != want {
+		t.Errorf("expected %s, got %s", want, up)
+	}
+}
+
+func TestLatestVersionIncludesCreatedMigrations(t *testing.T) {
+	dir := t.TempDir()
+
+	// The second migration is numbered after the first, which is only on
+	// disk and not in the set passed in.
+	if _, _, err := Create(dir, "first", fstest.MapFS{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Create(dir, "second", fstest.MapFS{}); err != nil {
+		t.Fatal(err)
+	}
+
+	version, err := LatestVersion(Source(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 2 {
+		t.Errorf("expected version 2, got %d", version)
+	}
+}
@@ -3,30 +3,102 @@
 
 import (
 	"database/sql"
+	"errors"
+	"io/fs"
+	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	mysqlmigrate "github.com/golang-migrate/migrate/v4/database/mysql"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/renamrgb/code-flix-admin-catalog/migrations"
 )
 
-func RunMigrations(db *sql.DB, databaseName string) error {
+// Source returns the migrations embedded in the binary, or those in dir when
+// it is set; the override lets development iterate without rebuilding.
+func Source(dir string) fs.FS {
+	if dir == "" {
+		return migrations.FS
+	}
+	return os.DirFS(dir)
+}
+
+// Migrator moves the schema between versions of a set of migrations.
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+func NewMigrator(db *sql.DB, databaseName string, files fs.FS) (*Migrator, error) {
+	src, err := iofs.New(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
 	driver, err := mysqlmigrate.WithInstance(db, &mysqlmigrate.Config{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		databaseName,
-		driver,
-	)
+	m, err := migrate.NewWithInstance("iofs", src, databaseName, driver)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+	return &Migrator{m: m}, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.m.Up())
+}
+
+// Down rolls back the last n applied migrations.
+func (m *Migrator) Down(n int) error {
+	return ignoreNoChange(m.m.Steps(-n))
+}
+
+// Goto migrates up or down to version.
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.m.Migrate(version))
+}
+
+// Version returns the applied version and whether the last migration failed
+// halfway; 0 means nothing has been applied.
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Force records version as applied and clean without running anything, to
+// recover from a dirty state after fixing the schema by hand. -1 means no
+// version.
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+// Close releases the source and the database, including the *sql.DB given
+// to NewMigrator.
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// RunMigrations applies every pending migration; the server calls it at
+// startup unless auto-migration is disabled.
+func RunMigrations(db *sql.DB, databaseName string, files fs.FS) error {
+	m, err := NewMigrator(db, databaseName, files)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return m.Up()
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
 }
@@ -0,0 +1,62 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// LatestVersion returns the highest migration version in files, i.e.
+// the version a fully migrated database reports.
+func LatestVersion(files fs.FS) (uint, error) {
+	src, err := iofs.New(files, ".")
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
+// VersionCheck reports an error unless the database schema is clean and at
+// the expected version.
+func VersionCheck(db *sql.DB, expected uint) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var version uint
+		var dirty bool
+
+		err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no migrations applied, expected version %d", expected)
+		}
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", version)
+		}
+		if version != expected {
+			return fmt.Errorf("schema at version %d, expected %d", version, expected)
+		}
+		return nil
+	}
+}
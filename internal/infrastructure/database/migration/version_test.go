@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLatestVersion(t *testing.T) {
+	entries, err := os.ReadDir("../../../../migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected uint64
+	for _, entry := range entries {
+		prefix, _, _ := strings.Cut(entry.Name(), "_")
+		if v, err := strconv.ParseUint(prefix, 10, 64); err == nil && v > expected {
+			expected = v
+		}
+	}
+
+	version, err := LatestVersion(Source(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if uint64(version) != expected {
+		t.Errorf("expected version %d, got %d", expected, version)
+	}
+}
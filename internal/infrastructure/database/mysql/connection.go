@@ -3,8 +3,6 @@
 import (
 	"database/sql"
 	"fmt"
-	"os"
-	"strconv"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -22,44 +20,10 @@ type Config struct {
 	ConnMaxLifetime time.Duration
 }
 
-func LoadConfigFromEnv() (Config, error) {
-
-	port, err := strconv.Atoi(getEnv("DB_PORT", "3306"))
-	if err != nil {
-		return Config{}, err
-	}
-
-	maxOpen, err := strconv.Atoi(getEnv("DB_MAX_OPEN_CONNS", "25"))
-	if err != nil {
-		return Config{}, err
-	}
-
-	maxIdle, err := strconv.Atoi(getEnv("DB_MAX_IDLE_CONNS", "10"))
-	if err != nil {
-		return Config{}, err
-	}
-
-	lifetimeMinutes, err := strconv.Atoi(getEnv("DB_CONN_MAX_LIFETIME", "5"))
-	if err != nil {
-		return Config{}, err
-	}
-
-	return Config{
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     port,
-		User:     getEnv("DB_USER", "root"),
-		Password: getEnv("DB_PASSWORD", ""),
-		Database: getEnv("DB_NAME", "admin_videos"),
-
-		MaxOpenConns:    maxOpen,
-		MaxIdleConns:    maxIdle,
-		ConnMaxLifetime: time.Minute * time.Duration(lifetimeMinutes),
-	}, nil
-}
-
 func NewConnection(cfg Config) (*sql.DB, error) {
 	dsn := fmt.Sprintf(
-		"%s:%s@tcp(%s:%d)/%s?parseTime=true",
+		// Migrations may hold several statements per file.
+		"%s:%s@tcp(%s:%d)/%s?parseTime=true&multiStatements=true",
 		cfg.User,
 		cfg.Password,
 		cfg.Host,
@@ -82,10 +46,3 @@ func NewConnection(cfg Config) (*sql.DB, error) {
 
 	return db, nil
 }
-
-func getEnv(key, fallback string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return fallback
-}
@@ -0,0 +1,124 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingDriver logs transaction boundaries and statements.
+type recordingDriver struct {
+	mu  sync.Mutex
+	log []string
+}
+
+func (d *recordingDriver) record(entry string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, entry)
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return recordingConn{d}, nil }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return recordingStmt{c.d, query}, nil
+}
+func (c recordingConn) Close() error { return nil }
+func (c recordingConn) Begin() (driver.Tx, error) {
+	c.d.record("begin")
+	return recordingTx{c.d}, nil
+}
+
+type recordingTx struct{ d *recordingDriver }
+
+func (t recordingTx) Commit() error   { t.d.record("commit"); return nil }
+func (t recordingTx) Rollback() error { t.d.record("rollback"); return nil }
+
+type recordingStmt struct {
+	d     *recordingDriver
+	query string
+}
+
+func (s recordingStmt) Close() error  { return nil }
+func (s recordingStmt) NumInput() int { return -1 }
+func (s recordingStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.d.record(s.query)
+	return driver.RowsAffected(1), nil
+}
+func (s recordingStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+var drivers sync.Map
+
+func openRecording(t *testing.T) (*sql.DB, *recordingDriver) {
+	t.Helper()
+
+	d := &recordingDriver{}
+	name := "recording-" + t.Name()
+	if _, loaded := drivers.LoadOrStore(name, d); loaded {
+		t.Fatal("driver registered twice")
+	}
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+func TestTransactorCommitsAndJoinsNestedCalls(t *testing.T) {
+	db, d := openRecording(t)
+	transactor := NewTransactor(db)
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if _, err := Conn(ctx, db).ExecContext(ctx, "INSERT a"); err != nil {
+			return err
+		}
+		return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			_, err := Conn(ctx, db).ExecContext(ctx, "INSERT b")
+			return err
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(d.log, ","); got != "begin,INSERT a,INSERT b,commit" {
+		t.Errorf("unexpected statements %s", got)
+	}
+}
+
+func TestTransactorRollsBackOnError(t *testing.T) {
+	db, d := openRecording(t)
+	failure := errors.New("invalid row")
+
+	err := NewTransactor(db).WithinTransaction(context.Background(), func(ctx context.Context) error {
+		Conn(ctx, db).ExecContext(ctx, "INSERT a")
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the callback's error, got %v", err)
+	}
+
+	if got := strings.Join(d.log, ","); got != "begin,INSERT a,rollback" {
+		t.Errorf("unexpected statements %s", got)
+	}
+}
+
+func TestConnOutsideTransactionUsesDB(t *testing.T) {
+	db, _ := openRecording(t)
+
+	if Conn(context.Background(), db) != Executor(db) {
+		t.Error("expected the database itself")
+	}
+}
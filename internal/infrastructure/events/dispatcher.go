@@ -0,0 +1,39 @@
+// Package events provides an in-process dispatcher that fans category events out to subscribers.
+package events
+
+import (
+	"sync"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type Handler func(event category.CategoryEvent)
+
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+func (d *Dispatcher) Subscribe(handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers = append(d.handlers, handler)
+}
+
+// Publish calls every handler synchronously, in subscription order. Handlers
+// that do slow work are expected to hand it off themselves.
+func (d *Dispatcher) Publish(event category.CategoryEvent) {
+	d.mu.RLock()
+	handlers := make([]Handler, len(d.handlers))
+	copy(handlers, d.handlers)
+	d.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
@@ -0,0 +1,159 @@
+package events
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+const subscriberBuffer = 64
+
+type StreamEvent struct {
+	Seq  uint64
+	Type category.EventType
+	Data []byte
+}
+
+// Stream keeps the last events in a bounded ring so reconnecting clients can
+// resume from a Last-Event-ID, and fans new events out to live subscribers.
+type Stream struct {
+	mu          sync.Mutex
+	capacity    int
+	buffer      []StreamEvent
+	lastSeq     uint64
+	subscribers map[*streamSubscriber]struct{}
+	closed      bool
+}
+
+type streamSubscriber struct {
+	types  map[category.EventType]bool
+	events chan StreamEvent
+}
+
+func NewStream(capacity int) *Stream {
+	return &Stream{
+		capacity:    capacity,
+		buffer:      make([]StreamEvent, 0, capacity),
+		subscribers: make(map[*streamSubscriber]struct{}),
+	}
+}
+
+func (s *Stream) Publish(event category.CategoryEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("event stream: encoding event failed", "event_id", event.ID, "request_id", event.RequestID, "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastSeq++
+	streamEvent := StreamEvent{Seq: s.lastSeq, Type: event.Type, Data: data}
+
+	if len(s.buffer) == s.capacity && s.capacity > 0 {
+		copy(s.buffer, s.buffer[1:])
+		s.buffer = s.buffer[:len(s.buffer)-1]
+	}
+	if s.capacity > 0 {
+		s.buffer = append(s.buffer, streamEvent)
+	}
+
+	for sub := range s.subscribers {
+		if !sub.accepts(event.Type) {
+			continue
+		}
+
+		select {
+		case sub.events <- streamEvent:
+		default:
+			// A subscriber that cannot keep up is dropped; its client reconnects
+			// with Last-Event-ID and catches up from the replay buffer.
+			s.remove(sub)
+		}
+	}
+}
+
+// Subscribe registers a subscriber and returns the buffered events after
+// lastSeq that match types, the channel for live events and a function that
+// must be called to release the subscription. The channel is closed when the
+// subscription ends, and is already closed once the stream is, so that
+// clients reconnecting during shutdown do not hold the drain open.
+func (s *Stream) Subscribe(lastSeq uint64, types []category.EventType) ([]StreamEvent, <-chan StreamEvent, func()) {
+	sub := &streamSubscriber{
+		events: make(chan StreamEvent, subscriberBuffer),
+	}
+
+	if len(types) > 0 {
+		sub.types = make(map[category.EventType]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An ID ahead of ours was issued before a restart; replay everything kept.
+	if lastSeq > s.lastSeq {
+		lastSeq = 0
+	}
+
+	var replay []StreamEvent
+	for _, event := range s.buffer {
+		if event.Seq > lastSeq && sub.accepts(event.Type) {
+			replay = append(replay, event)
+		}
+	}
+
+	if s.closed {
+		close(sub.events)
+		return replay, sub.events, func() {}
+	}
+
+	s.subscribers[sub] = struct{}{}
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.remove(sub)
+	}
+
+	return replay, sub.events, unsubscribe
+}
+
+func (s *Stream) SubscriberCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.subscribers)
+}
+
+// Close ends every live subscription so streaming handlers return and the
+// server can finish shutting down, and refuses later subscriptions. Clients
+// reconnect elsewhere with their Last-Event-ID.
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	for sub := range s.subscribers {
+		s.remove(sub)
+	}
+}
+
+func (s *Stream) remove(sub *streamSubscriber) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(s.subscribers, sub)
+	close(sub.events)
+}
+
+func (sub *streamSubscriber) accepts(eventType category.EventType) bool {
+	return sub.types == nil || sub.types[eventType]
+}
@@ -0,0 +1,167 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+func publishN(stream *Stream, eventType category.EventType, n int) {
+	for i := 0; i < n; i++ {
+		cat, _ := category.NewCategory("Movies", "desc", true)
+		stream.Publish(category.NewCategoryEvent(eventType, nil, cat))
+	}
+}
+
+func TestStreamReplayIsBounded(t *testing.T) {
+	stream := NewStream(3)
+
+	publishN(stream, category.EventCategoryCreated, 5)
+
+	replay, _, unsubscribe := stream.Subscribe(0, nil)
+	defer unsubscribe()
+
+	if len(replay) != 3 {
+		t.Fatalf("expected 3 buffered events, got %d", len(replay))
+	}
+
+	if replay[0].Seq != 3 || replay[2].Seq != 5 {
+		t.Errorf("expected the most recent events, got seq %d..%d", replay[0].Seq, replay[2].Seq)
+	}
+}
+
+func TestStreamResumeFromLastEventID(t *testing.T) {
+	stream := NewStream(10)
+
+	publishN(stream, category.EventCategoryCreated, 4)
+
+	replay, _, unsubscribe := stream.Subscribe(2, nil)
+	defer unsubscribe()
+
+	if len(replay) != 2 || replay[0].Seq != 3 {
+		t.Fatalf("expected events after seq 2, got %+v", replay)
+	}
+}
+
+func TestStreamResumeFromUnknownFutureIDReplaysBuffer(t *testing.T) {
+	stream := NewStream(10)
+
+	publishN(stream, category.EventCategoryCreated, 2)
+
+	replay, _, unsubscribe := stream.Subscribe(99, nil)
+	defer unsubscribe()
+
+	if len(replay) != 2 {
+		t.Fatalf("expected full replay, got %d events", len(replay))
+	}
+}
+
+func TestStreamFiltersByType(t *testing.T) {
+	stream := NewStream(10)
+
+	publishN(stream, category.EventCategoryCreated, 1)
+	publishN(stream, category.EventCategoryDeleted, 1)
+
+	replay, live, unsubscribe := stream.Subscribe(0, []category.EventType{category.EventCategoryDeleted})
+	defer unsubscribe()
+
+	if len(replay) != 1 || replay[0].Type != category.EventCategoryDeleted {
+		t.Fatalf("expected only deleted events in replay, got %+v", replay)
+	}
+
+	publishN(stream, category.EventCategoryUpdated, 1)
+	publishN(stream, category.EventCategoryDeleted, 1)
+
+	event := <-live
+	if event.Type != category.EventCategoryDeleted {
+		t.Errorf("expected deleted event, got %s", event.Type)
+	}
+
+	if len(live) != 0 {
+		t.Error("expected filtered events not to be delivered")
+	}
+}
+
+func TestStreamUnsubscribeClosesChannel(t *testing.T) {
+	stream := NewStream(10)
+
+	_, live, unsubscribe := stream.Subscribe(0, nil)
+
+	if stream.SubscriberCount() != 1 {
+		t.Fatal("expected one subscriber")
+	}
+
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-live; ok {
+		t.Error("expected channel to be closed")
+	}
+
+	if stream.SubscriberCount() != 0 {
+		t.Error("expected subscriber to be removed")
+	}
+}
+
+func TestStreamCloseEndsSubscriptions(t *testing.T) {
+	stream := NewStream(10)
+
+	_, first, unsubscribeFirst := stream.Subscribe(0, nil)
+	defer unsubscribeFirst()
+	_, second, unsubscribeSecond := stream.Subscribe(0, nil)
+	defer unsubscribeSecond()
+
+	stream.Close()
+
+	for _, live := range []<-chan StreamEvent{first, second} {
+		if _, ok := <-live; ok {
+			t.Error("expected channel to be closed")
+		}
+	}
+
+	if stream.SubscriberCount() != 0 {
+		t.Error("expected subscribers to be removed")
+	}
+}
+
+func TestStreamSubscribeAfterClose(t *testing.T) {
+	stream := NewStream(10)
+	publishN(stream, category.EventCategoryCreated, 2)
+
+	stream.Close()
+
+	replay, live, unsubscribe := stream.Subscribe(0, nil)
+	defer unsubscribe()
+
+	if len(replay) != 2 {
+		t.Errorf("expected the buffered events to be replayed, got %d", len(replay))
+	}
+	if _, ok := <-live; ok {
+		t.Error("expected the channel of a closed stream to be closed")
+	}
+	if stream.SubscriberCount() != 0 {
+		t.Error("expected a closed stream to keep no subscribers")
+	}
+}
+
+func TestStreamDropsSlowSubscriber(t *testing.T) {
+	stream := NewStream(10)
+
+	_, live, unsubscribe := stream.Subscribe(0, nil)
+	defer unsubscribe()
+
+	publishN(stream, category.EventCategoryCreated, subscriberBuffer+1)
+
+	if stream.SubscriberCount() != 0 {
+		t.Fatal("expected slow subscriber to be dropped")
+	}
+
+	received := 0
+	for range live {
+		received++
+	}
+
+	if received != subscriberBuffer {
+		t.Errorf("expected %d buffered events before close, got %d", subscriberBuffer, received)
+	}
+}
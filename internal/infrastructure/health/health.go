@@ -0,0 +1,138 @@
+// Package health tracks whether the service can take traffic: its
+// dependencies answer in time and it is not shutting down.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// ErrShuttingDown reports that the service is draining and should receive
+// no new traffic.
+var ErrShuttingDown = errors.New("shutting down")
+
+// CheckFunc probes one dependency. It must honour ctx's deadline.
+type CheckFunc func(ctx context.Context) error
+
+type Config struct {
+	// Timeout bounds each dependency check.
+	Timeout time.Duration
+}
+
+type CheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Health runs the registered readiness checks.
+type Health struct {
+	Timeout time.Duration
+
+	mu           sync.RWMutex
+	checks       []namedCheck
+	shuttingDown atomic.Bool
+}
+
+func New(cfg Config) *Health {
+	return &Health{Timeout: cfg.Timeout}
+}
+
+// AddCheck registers a dependency under name; brokers, object storage and
+// similar backends plug in here as they are configured.
+func (h *Health) AddCheck(name string, check CheckFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// Shutdown makes every later readiness report fail, so load balancers stop
+// routing here while in-flight requests drain.
+func (h *Health) Shutdown() {
+	h.shuttingDown.Store(true)
+}
+
+func (h *Health) ShuttingDown() bool {
+	return h.shuttingDown.Load()
+}
+
+// Readiness runs every check concurrently, each bounded by Timeout, and
+// reports the outcome per dependency.
+func (h *Health) Readiness(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := h.checks
+	h.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks)+1)}
+
+	if h.ShuttingDown() {
+		report.Status = StatusFail
+		report.Checks["shutdown"] = CheckResult{Status: StatusFail, Error: ErrShuttingDown.Error(), Duration: "0s"}
+	}
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = h.run(ctx, c.check)
+		}()
+	}
+	wg.Wait()
+
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+func (h *Health) run(ctx context.Context, check CheckFunc) CheckResult {
+	if h.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := check(ctx)
+	result := CheckResult{Status: StatusOK, Duration: time.Since(start).Round(time.Microsecond).String()}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// PingCheck verifies the database accepts connections.
+func PingCheck(db *sql.DB) CheckFunc {
+	return db.PingContext
+}
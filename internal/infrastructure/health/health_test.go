@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadinessReportsEachCheck(t *testing.T) {
+	h := New(Config{Timeout: time.Second})
+	h.AddCheck("database", func(ctx context.Context) error { return nil })
+	h.AddCheck("broker", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	report := h.Readiness(context.Background())
+
+	if report.OK() {
+		t.Fatal("expected failing report")
+	}
+	if report.Checks["database"].Status != StatusOK {
+		t.Errorf("expected database ok, got %+v", report.Checks["database"])
+	}
+	if got := report.Checks["broker"]; got.Status != StatusFail || got.Error != "connection refused" {
+		t.Errorf("expected broker failure, got %+v", got)
+	}
+}
+
+func TestReadinessTimesOutSlowChecks(t *testing.T) {
+	h := New(Config{Timeout: 20 * time.Millisecond})
+	h.AddCheck("database", func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+
+	start := time.Now()
+	report := h.Readiness(context.Background())
+
+	if report.OK() {
+		t.Fatal("expected slow check to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected check to be cut off by the timeout, took %s", elapsed)
+	}
+}
+
+func TestReadinessFailsDuringShutdown(t *testing.T) {
+	h := New(Config{Timeout: time.Second})
+	h.AddCheck("database", func(ctx context.Context) error { return nil })
+
+	if !h.Readiness(context.Background()).OK() {
+		t.Fatal("expected ready before shutdown")
+	}
+
+	h.Shutdown()
+
+	report := h.Readiness(context.Background())
+	if report.OK() || report.Checks["shutdown"].Status != StatusFail {
+		t.Errorf("expected shutdown to fail readiness, got %+v", report)
+	}
+}
@@ -0,0 +1,69 @@
+// Package idempotency stores the responses of requests sent with an
+// Idempotency-Key, so a retried request can be answered without running it
+// again.
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	StoreMySQL  = "mysql"
+	StoreMemory = "memory"
+)
+
+type Config struct {
+	// TTL is how long a key and its response are kept.
+	TTL time.Duration
+	// Store is StoreMySQL, shared by every replica, or StoreMemory.
+	Store string
+}
+
+// Record is what a Store holds for a key. Response is empty until the
+// request that reserved the key completes.
+type Record struct {
+	RequestHash string
+	Completed   bool
+	Response    Response
+	ExpiresAt   time.Time
+}
+
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Store keeps one record per key. Reserve must be atomic: of concurrent
+// requests with the same key exactly one reserves it.
+type Store interface {
+	// Reserve records key as in flight for ttl and returns nil, unless a
+	// live record exists, which it returns instead.
+	Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*Record, error)
+	// Complete stores the response of the request that reserved key.
+	Complete(ctx context.Context, key string, response Response) error
+	// Release forgets a reservation, so the request can be retried.
+	Release(ctx context.Context, key string) error
+	// Purge deletes expired records.
+	Purge(ctx context.Context) error
+}
+
+// PurgeEvery purges store at each interval until ctx is done.
+func PurgeEvery(ctx context.Context, store Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := store.Purge(ctx); err != nil {
+				slog.ErrorContext(ctx, "error purging idempotency keys", "error", err)
+			}
+		}
+	}
+}
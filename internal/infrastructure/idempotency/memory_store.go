@@ -0,0 +1,68 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore serves a single instance; keys are lost on restart.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*Record
+	now     func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]*Record),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	if record, ok := s.records[key]; ok && now.Before(record.ExpiresAt) {
+		existing := *record
+		return &existing, nil
+	}
+
+	s.records[key] = &Record{RequestHash: requestHash, ExpiresAt: now.Add(ttl)}
+	return nil, nil
+}
+
+func (s *MemoryStore) Complete(ctx context.Context, key string, response Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok {
+		record.Completed = true
+		record.Response = response
+	}
+	return nil
+}
+
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+func (s *MemoryStore) Purge(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, record := range s.records {
+		if !now.Before(record.ExpiresAt) {
+			delete(s.records, key)
+		}
+	}
+	return nil
+}
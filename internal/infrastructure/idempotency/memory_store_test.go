@@ -0,0 +1,88 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestStore(now *time.Time) *MemoryStore {
+	store := NewMemoryStore()
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestMemoryStore_ReserveCompleteReplay(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newTestStore(&now)
+	ctx := context.Background()
+
+	if record, _ := store.Reserve(ctx, "key", "hash", time.Minute); record != nil {
+		t.Fatalf("expected the key to be reserved, got %+v", record)
+	}
+
+	record, _ := store.Reserve(ctx, "key", "hash", time.Minute)
+	if record == nil || record.Completed {
+		t.Fatalf("expected an in-flight record, got %+v", record)
+	}
+
+	store.Complete(ctx, "key", Response{Status: http.StatusCreated, Body: []byte("{}")})
+
+	record, _ = store.Reserve(ctx, "key", "hash", time.Minute)
+	if record == nil || !record.Completed || record.Response.Status != http.StatusCreated {
+		t.Fatalf("expected the stored response, got %+v", record)
+	}
+
+	now = now.Add(time.Minute)
+
+	if record, _ := store.Reserve(ctx, "key", "other", time.Minute); record != nil {
+		t.Errorf("expected an expired key to be reserved again, got %+v", record)
+	}
+}
+
+func TestMemoryStore_ReleaseAndPurge(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newTestStore(&now)
+	ctx := context.Background()
+
+	store.Reserve(ctx, "released", "hash", time.Minute)
+	store.Release(ctx, "released")
+	if record, _ := store.Reserve(ctx, "released", "hash", time.Minute); record != nil {
+		t.Errorf("expected a released key to be reserved again, got %+v", record)
+	}
+
+	store.Reserve(ctx, "short", "hash", time.Second)
+	now = now.Add(time.Second)
+	store.Purge(ctx)
+
+	if _, ok := store.records["short"]; ok {
+		t.Error("expected the expired key to be purged")
+	}
+	if _, ok := store.records["released"]; !ok {
+		t.Error("expected the live key to be kept")
+	}
+}
+
+func TestMemoryStore_ConcurrentReserve(t *testing.T) {
+	store := NewMemoryStore()
+	var reserved atomic.Int32
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if record, _ := store.Reserve(context.Background(), "key", "hash", time.Minute); record == nil {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved.Load() != 1 {
+		t.Errorf("expected exactly one reservation, got %d", reserved.Load())
+	}
+}
@@ -0,0 +1,99 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const mysqlDuplicateEntry = 1062
+
+// MySQLStore shares keys between replicas. The primary key on the key makes
+// Reserve atomic.
+type MySQLStore struct {
+	DB  *sql.DB
+	now func() time.Time
+}
+
+func NewMySQLStore(db *sql.DB) *MySQLStore {
+	return &MySQLStore{DB: db, now: time.Now}
+}
+
+func (s *MySQLStore) Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*Record, error) {
+	now := s.now().UTC()
+
+	// An expired record no longer holds its key.
+	if _, err := s.DB.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE id = ? AND expires_at <= ?`, key, now); err != nil {
+		return nil, err
+	}
+
+	_, err := s.DB.ExecContext(ctx,
+		`INSERT INTO idempotency_keys (id, request_hash, created_at, expires_at) VALUES (?, ?, ?, ?)`,
+		key, requestHash, now, now.Add(ttl),
+	)
+
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlDuplicateEntry {
+		return nil, err
+	}
+
+	record, err := s.get(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released between the insert and the read: report it in flight,
+		// and the client's retry will find the key free.
+		return &Record{RequestHash: requestHash, ExpiresAt: now}, nil
+	}
+	return record, err
+}
+
+func (s *MySQLStore) get(ctx context.Context, key string) (*Record, error) {
+	var record Record
+	var status sql.NullInt64
+	var header []byte
+
+	err := s.DB.QueryRowContext(ctx, `
+		SELECT request_hash, status_code, headers, body, expires_at
+		FROM idempotency_keys
+		WHERE id = ?
+	`, key).Scan(&record.RequestHash, &status, &header, &record.Response.Body, &record.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Valid {
+		record.Completed = true
+		record.Response.Status = int(status.Int64)
+		if err := json.Unmarshal(header, &record.Response.Header); err != nil {
+			return nil, err
+		}
+	}
+
+	return &record, nil
+}
+
+func (s *MySQLStore) Complete(ctx context.Context, key string, response Response) error {
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.ExecContext(ctx,
+		`UPDATE idempotency_keys SET status_code = ?, headers = ?, body = ? WHERE id = ?`,
+		response.Status, header, response.Body, key,
+	)
+	return err
+}
+
+func (s *MySQLStore) Release(ctx context.Context, key string) error {
+	_, err := s.DB.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE id = ? AND status_code IS NULL`, key)
+	return err
+}
+
+func (s *MySQLStore) Purge(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, s.now().UTC())
+	return err
+}
@@ -0,0 +1,389 @@
+// Package cli runs catalog operations from the command line through the same
+// use cases the HTTP API uses.
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/create"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/delete"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/retrive"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/update"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+// ErrUsage wraps errors caused by how a command was invoked rather than by
+// the operation itself.
+var ErrUsage = errors.New("usage error")
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputCSV   = "csv"
+)
+
+// CategoryOptions are the flags shared by the category commands.
+type CategoryOptions struct {
+	Output string
+	DryRun bool
+
+	// List filters, as in category.SearchCategoryQuery.
+	Page      int
+	PerPage   int
+	Terms     string
+	Sort      string
+	Direction string
+
+	// Fields for create and update; update only changes those given.
+	Name        string
+	Slug        string
+	Parent      string
+	Description string
+	Active      bool
+
+	set      *flag.FlagSet
+	explicit map[string]bool
+}
+
+// Register adds the options to fs. Update relies on fs to tell which fields
+// were given.
+func (o *CategoryOptions) Register(fs *flag.FlagSet) {
+	o.set = fs
+
+	fs.StringVar(&o.Output, "output", OutputTable, "output format: table, json or csv")
+	fs.BoolVar(&o.DryRun, "dry-run", false, "validate a change and print the result without saving it")
+
+	fs.IntVar(&o.Page, "page", 1, "page to list")
+	fs.IntVar(&o.PerPage, "per-page", 10, "categories per page")
+	fs.StringVar(&o.Terms, "terms", "", "match name or description")
+	fs.StringVar(&o.Sort, "sort", "", "sort by name, created_at or updated_at")
+	fs.StringVar(&o.Direction, "direction", "", "sort direction: asc or desc")
+
+	fs.StringVar(&o.Name, "name", "", "category name")
+	fs.StringVar(&o.Slug, "slug", "", "URL slug, generated from the name on create when empty")
+	fs.StringVar(&o.Parent, "parent", "", "ID of the parent category; empty for a root category")
+	fs.StringVar(&o.Description, "description", "", "category description")
+	fs.BoolVar(&o.Active, "active", true, "whether the category is active")
+}
+
+// given reports whether the flag name was set on the command line.
+func (o *CategoryOptions) given(name string) bool {
+	if o.explicit == nil {
+		o.explicit = map[string]bool{}
+		if o.set != nil {
+			o.set.Visit(func(f *flag.Flag) { o.explicit[f.Name] = true })
+		}
+	}
+	return o.explicit[name]
+}
+
+type CategoryCommands struct {
+	CreateUC  *create.CreateCategoryUseCase
+	UpdateUC  *update.UpdateCategoryUseCase
+	DeleteUC  *delete.DeleteCategoryUseCase
+	GetByIDUC *retrive.GetCategoryByIDUseCase
+	ListUC    *retrive.ListCategoriesUseCase
+
+	// Out receives the requested data; Err receives notices, so that Out
+	// stays parseable.
+	Out io.Writer
+	Err io.Writer
+}
+
+func NewCategoryCommands(
+	createUC *create.CreateCategoryUseCase,
+	updateUC *update.UpdateCategoryUseCase,
+	deleteUC *delete.DeleteCategoryUseCase,
+	getByIDUC *retrive.GetCategoryByIDUseCase,
+	listUC *retrive.ListCategoriesUseCase,
+	out, errOut io.Writer,
+) *CategoryCommands {
+	return &CategoryCommands{
+		CreateUC:  createUC,
+		UpdateUC:  updateUC,
+		DeleteUC:  deleteUC,
+		GetByIDUC: getByIDUC,
+		ListUC:    listUC,
+		Out:       out,
+		Err:       errOut,
+	}
+}
+
+// Check reports a usage error for an unknown action or output, a missing
+// ID or a create without a name, so callers can reject the invocation
+// before connecting to anything.
+func (o *CategoryOptions) Check(action, id string) error {
+	switch o.Output {
+	case OutputTable, OutputJSON, OutputCSV:
+	default:
+		return fmt.Errorf("%w: unknown output %q", ErrUsage, o.Output)
+	}
+
+	switch action {
+	case "list":
+		return nil
+	case "create":
+		if !o.given("name") {
+			return fmt.Errorf("%w: create needs -name", ErrUsage)
+		}
+		return nil
+	case "get", "update", "delete", "activate", "deactivate":
+		if id == "" {
+			return fmt.Errorf("%w: %s needs a category ID", ErrUsage, action)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrUsage, action)
+	}
+}
+
+// Run performs action; id is required by every action but list and create.
+func (c *CategoryCommands) Run(ctx context.Context, action, id string, opts *CategoryOptions) error {
+	if err := opts.Check(action, id); err != nil {
+		return err
+	}
+
+	switch action {
+	case "list":
+		return c.list(ctx, opts)
+	case "create":
+		return c.create(ctx, opts)
+	case "get":
+		cat, err := c.GetByIDUC.Execute(ctx, retrive.GetCategoryByIDInput{ID: id})
+		if err != nil {
+			return err
+		}
+		return c.print(opts.Output, []category.Category{*cat}, false)
+	case "update":
+		return c.update(ctx, id, opts)
+	case "activate", "deactivate":
+		return c.update(ctx, id, &CategoryOptions{
+			Output:   opts.Output,
+			DryRun:   opts.DryRun,
+			Active:   action == "activate",
+			explicit: map[string]bool{"active": true},
+		})
+	default:
+		return c.delete(ctx, id, opts)
+	}
+}
+
+func (c *CategoryCommands) list(ctx context.Context, opts *CategoryOptions) error {
+	page, err := c.ListUC.Execute(ctx, retrive.ListCategoriesInput{
+		Page:      opts.Page,
+		PerPage:   opts.PerPage,
+		Terms:     opts.Terms,
+		Sort:      opts.Sort,
+		Direction: opts.Direction,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.print(opts.Output, page.Items, true); err != nil {
+		return err
+	}
+
+	if opts.Output == OutputTable {
+		fmt.Fprintf(c.Err, "page %d, %d of %d categories\n", page.CurrentPage, len(page.Items), page.Total)
+	}
+	return nil
+}
+
+func (c *CategoryCommands) create(ctx context.Context, opts *CategoryOptions) error {
+	if opts.DryRun {
+		cat, err := category.NewCategory(opts.Name, opts.Description, opts.Active)
+		if err != nil {
+			return err
+		}
+		if opts.Slug != "" {
+			cat.Slug = opts.Slug
+		}
+		if cat.ParentID, err = category.ParseParentID(opts.Parent); err != nil {
+			return err
+		}
+		if err := cat.Validate(); err != nil {
+			return err
+		}
+		fmt.Fprintln(c.Err, "dry run: category not created")
+		return c.print(opts.Output, []category.Category{*cat}, false)
+	}
+
+	output, err := c.CreateUC.Execute(ctx, create.CreateCategoryInput{
+		Name:        opts.Name,
+		Slug:        opts.Slug,
+		Description: opts.Description,
+		IsActive:    opts.Active,
+		ParentID:    opts.Parent,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.show(ctx, output.ID, opts.Output)
+}
+
+// update applies the given fields on top of the stored category, since the
+// update use case replaces every field.
+func (c *CategoryCommands) update(ctx context.Context, id string, opts *CategoryOptions) error {
+	current, err := c.GetByIDUC.Execute(ctx, retrive.GetCategoryByIDInput{ID: id})
+	if err != nil {
+		return err
+	}
+
+	input := update.UpdateCategoryInput{
+		ID:          id,
+		Name:        current.Name,
+		Description: current.Description,
+		IsActive:    current.IsActive,
+	}
+	if opts.given("name") {
+		input.Name = opts.Name
+	}
+	if opts.given("slug") {
+		input.Slug = opts.Slug
+	}
+	if opts.given("parent") {
+		input.ParentID = &opts.Parent
+	}
+	if opts.given("description") {
+		input.Description = opts.Description
+	}
+	if opts.given("active") {
+		input.IsActive = opts.Active
+	}
+
+	if opts.DryRun {
+		cat := *current
+		cat.Update(input.Name, input.Description, input.IsActive)
+		if input.Slug != "" {
+			cat.ChangeSlug(input.Slug)
+		}
+		if input.ParentID != nil {
+			parentID, err := category.ParseParentID(*input.ParentID)
+			if err != nil {
+				return err
+			}
+			cat.MoveTo(parentID)
+		}
+		if err := cat.Validate(); err != nil {
+			return err
+		}
+		fmt.Fprintln(c.Err, "dry run: category not updated")
+		return c.print(opts.Output, []category.Category{cat}, false)
+	}
+
+	if _, err := c.UpdateUC.Execute(ctx, input); err != nil {
+		return err
+	}
+
+	return c.show(ctx, id, opts.Output)
+}
+
+func (c *CategoryCommands) delete(ctx context.Context, id string, opts *CategoryOptions) error {
+	if opts.DryRun {
+		if _, err := c.GetByIDUC.Execute(ctx, retrive.GetCategoryByIDInput{ID: id}); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.Err, "dry run: category %s not deleted\n", id)
+		return nil
+	}
+
+	if err := c.DeleteUC.Execute(ctx, delete.DeleteCategoryInput{ID: id}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Err, "category %s deleted\n", id)
+	return nil
+}
+
+// show prints the category as stored after a change.
+func (c *CategoryCommands) show(ctx context.Context, id, output string) error {
+	cat, err := c.GetByIDUC.Execute(ctx, retrive.GetCategoryByIDInput{ID: id})
+	if err != nil {
+		return err
+	}
+	return c.print(output, []category.Category{*cat}, false)
+}
+
+type categoryView struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	ParentID    string    `json:"parent_id,omitempty"`
+	Description string    `json:"description"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+var columns = []string{"id", "name", "slug", "parent_id", "description", "is_active", "created_at", "updated_at"}
+
+func (v categoryView) row() []string {
+	return []string{
+		v.ID,
+		v.Name,
+		v.Slug,
+		v.ParentID,
+		v.Description,
+		strconv.FormatBool(v.IsActive),
+		v.CreatedAt.UTC().Format(time.RFC3339),
+		v.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// print writes categories in the given format. JSON lists are arrays and
+// single categories are objects.
+func (c *CategoryCommands) print(output string, categories []category.Category, list bool) error {
+	views := make([]categoryView, 0, len(categories))
+	for _, cat := range categories {
+		view := categoryView{
+			ID:          cat.ID.String(),
+			Name:        cat.Name,
+			Slug:        cat.Slug,
+			Description: cat.Description,
+			IsActive:    cat.IsActive,
+			CreatedAt:   cat.CreatedAt,
+			UpdatedAt:   cat.UpdatedAt,
+		}
+		if cat.ParentID != nil {
+			view.ParentID = cat.ParentID.String()
+		}
+		views = append(views, view)
+	}
+
+	switch output {
+	case OutputJSON:
+		encoder := json.NewEncoder(c.Out)
+		encoder.SetIndent("", "  ")
+		if list {
+			return encoder.Encode(views)
+		}
+		return encoder.Encode(views[0])
+	case OutputCSV:
+		w := csv.NewWriter(c.Out)
+		w.Write(columns)
+		for _, v := range views {
+			w.Write(v.row())
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		w := tabwriter.NewWriter(c.Out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.ToUpper(strings.Join(columns, "\t")))
+		for _, v := range views {
+			fmt.Fprintln(w, strings.Join(v.row(), "\t"))
+		}
+		return w.Flush()
+	}
+}
@@ -0,0 +1,363 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/create"
+	deleteCategory "github.com/renamrgb/code-flix-admin-catalog/internal/application/category/delete"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/retrive"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/update"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type memoryGateway struct {
+	categories map[category.CategoryID]category.Category
+	query      category.SearchCategoryQuery
+}
+
+func newMemoryGateway(cats ...*category.Category) *memoryGateway {
+	g := &memoryGateway{categories: map[category.CategoryID]category.Category{}}
+	for _, cat := range cats {
+		g.categories[cat.ID] = *cat
+	}
+	return g
+}
+
+func (g *memoryGateway) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	g.categories[cat.ID] = *cat
+	return cat, nil
+}
+
+func (g *memoryGateway) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
+	cat, ok := g.categories[id]
+	if !ok {
+		return nil, category.ErrCategoryNotFound
+	}
+	return &cat, nil
+}
+
+func (g *memoryGateway) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	g.categories[cat.ID] = *cat
+	return cat, nil
+}
+
+func (g *memoryGateway) DeleteCategory(ctx context.Context, id category.CategoryID) error {
+	delete(g.categories, id)
+	return nil
+}
+
+func (g *memoryGateway) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	for _, cat := range g.categories {
+		if cat.Name == name {
+			return &cat, nil
+		}
+	}
+	return nil, category.ErrCategoryNotFound
+}
+
+func (g *memoryGateway) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	for _, cat := range g.categories {
+		if cat.Slug == slug {
+			return &cat, nil
+		}
+	}
+	return nil, category.ErrCategoryNotFound
+}
+
+func (g *memoryGateway) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	var children []category.Category
+	for _, cat := range g.categories {
+		if cat.ParentID != nil && *cat.ParentID == parentID {
+			children = append(children, cat)
+		}
+	}
+	return children, nil
+}
+
+func (g *memoryGateway) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	g.query = query
+	var items []category.Category
+	for _, cat := range g.categories {
+		items = append(items, cat)
+	}
+	return &pagination.Pagination[category.Category]{
+		CurrentPage: query.Page,
+		PerPage:     query.PerPage,
+		Total:       len(items),
+		Items:       items,
+	}, nil
+}
+
+type publisherMock struct {
+	Events []category.CategoryEvent
+}
+
+func (m *publisherMock) Publish(event category.CategoryEvent) {
+	m.Events = append(m.Events, event)
+}
+
+// discardRevisions stores nothing and finds no revision.
+type discardRevisions struct{}
+
+func (discardRevisions) AppendRevision(ctx context.Context, rev *category.CategoryRevision) (*category.CategoryRevision, error) {
+	return rev, nil
+}
+
+func (discardRevisions) GetRevision(ctx context.Context, id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (discardRevisions) GetRevisionAsOf(ctx context.Context, id category.CategoryID, at time.Time) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (discardRevisions) FindRevisions(ctx context.Context, query category.SearchRevisionQuery) (*pagination.Pagination[category.CategoryRevision], error) {
+	return nil, nil
+}
+
+func newCommands(gateway *memoryGateway, publisher *publisherMock) (*CategoryCommands, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return NewCategoryCommands(
+		create.NewCreateCategoryUseCase(gateway, discardRevisions{}, publisher, usecase.NoTransaction{}),
+		update.NewUpdateCategoryUseCase(gateway, discardRevisions{}, publisher, usecase.NoTransaction{}),
+		deleteCategory.NewDeleteCategoryUseCase(gateway, publisher),
+		retrive.NewGetCategoryByIDUseCase(gateway),
+		retrive.NewListCategoriesUseCase(gateway),
+		out,
+		io.Discard,
+	), out
+}
+
+func parseOptions(t *testing.T, args ...string) *CategoryOptions {
+	t.Helper()
+
+	fs := flag.NewFlagSet("categories", flag.ContinueOnError)
+	opts := &CategoryOptions{}
+	opts.Register(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return opts
+}
+
+func TestListPassesFiltersAndWritesCSV(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films, mostly", true)
+	gateway := newMemoryGateway(movies)
+	commands, out := newCommands(gateway, &publisherMock{})
+
+	opts := parseOptions(t, "-output", "csv", "-page", "2", "-per-page", "5", "-terms", "mov", "-sort", "name", "-direction", "desc")
+
+	if err := commands.Run(context.Background(), "list", "", opts); err != nil {
+		t.Fatal(err)
+	}
+
+	want := category.SearchCategoryQuery{Page: 2, PerPage: 5, Terms: "mov", Sort: "name", Direction: "desc"}
+	if gateway.query != want {
+		t.Errorf("expected query %+v, got %+v", want, gateway.query)
+	}
+
+	records, err := csv.NewReader(out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0][0] != "id" || records[1][2] != "movies" || records[1][4] != "films, mostly" {
+		t.Errorf("unexpected CSV %q", records)
+	}
+}
+
+func TestCreateDryRunDoesNotPersist(t *testing.T) {
+	gateway := newMemoryGateway()
+	publisher := &publisherMock{}
+	commands, out := newCommands(gateway, publisher)
+
+	opts := parseOptions(t, "-name", "Movies", "-dry-run", "-output", "json")
+
+	if err := commands.Run(context.Background(), "create", "", opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(gateway.categories) != 0 || len(publisher.Events) != 0 {
+		t.Error("expected nothing to be saved or published")
+	}
+
+	var view categoryView
+	if err := json.Unmarshal(out.Bytes(), &view); err != nil {
+		t.Fatal(err)
+	}
+	if view.Name != "Movies" {
+		t.Errorf("expected the would-be category, got %+v", view)
+	}
+}
+
+func TestCreateDryRunStillValidates(t *testing.T) {
+	commands, _ := newCommands(newMemoryGateway(), &publisherMock{})
+
+	err := commands.Run(context.Background(), "create", "", parseOptions(t, "-name", "ab", "-dry-run"))
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestUpdateKeepsFieldsNotGiven(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films", true)
+	gateway := newMemoryGateway(movies)
+	commands, _ := newCommands(gateway, &publisherMock{})
+
+	opts := parseOptions(t, "-description", "feature films")
+
+	if err := commands.Run(context.Background(), "update", movies.ID.String(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	got := gateway.categories[movies.ID]
+	if got.Name != "Movies" || got.Description != "feature films" || !got.IsActive {
+		t.Errorf("unexpected category %+v", got)
+	}
+}
+
+func TestDeactivateAndActivate(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films", true)
+	gateway := newMemoryGateway(movies)
+	publisher := &publisherMock{}
+	commands, _ := newCommands(gateway, publisher)
+
+	if err := commands.Run(context.Background(), "deactivate", movies.ID.String(), parseOptions(t)); err != nil {
+		t.Fatal(err)
+	}
+	if gateway.categories[movies.ID].IsActive {
+		t.Fatal("expected category to be deactivated")
+	}
+
+	if err := commands.Run(context.Background(), "activate", movies.ID.String(), parseOptions(t)); err != nil {
+		t.Fatal(err)
+	}
+	if !gateway.categories[movies.ID].IsActive {
+		t.Fatal("expected category to be activated")
+	}
+
+	if len(publisher.Events) != 2 {
+		t.Errorf("expected 2 events, got %d", len(publisher.Events))
+	}
+}
+
+func TestDeleteDryRunKeepsCategory(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films", true)
+	gateway := newMemoryGateway(movies)
+	commands, _ := newCommands(gateway, &publisherMock{})
+
+	if err := commands.Run(context.Background(), "delete", movies.ID.String(), parseOptions(t, "-dry-run")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := gateway.categories[movies.ID]; !ok {
+		t.Fatal("expected category to be kept")
+	}
+
+	if err := commands.Run(context.Background(), "delete", movies.ID.String(), parseOptions(t)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := gateway.categories[movies.ID]; ok {
+		t.Fatal("expected category to be deleted")
+	}
+}
+
+func TestGetWritesTable(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films", true)
+	commands, out := newCommands(newMemoryGateway(movies), &publisherMock{})
+
+	if err := commands.Run(context.Background(), "get", movies.ID.String(), parseOptions(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	assertTableRow(t, out.String(), map[string]string{
+		"ID":          movies.ID.String(),
+		"NAME":        "Movies",
+		"SLUG":        "movies",
+		"DESCRIPTION": "films",
+		"IS_ACTIVE":   "true",
+	})
+}
+
+// assertTableRow checks that each header of a one-row table sits above its
+// value.
+func assertTableRow(t *testing.T, table string, want map[string]string) {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimRight(table, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a header and one row, got:\n%s", table)
+	}
+	header, row := lines[0], lines[1]+strings.Repeat(" ", len(lines[0]))
+
+	got := map[string]string{}
+	cells := regexp.MustCompile(`\S+`).FindAllStringIndex(header, -1)
+	for i, cell := range cells {
+		end := len(row)
+		if i+1 < len(cells) {
+			end = cells[i+1][0]
+		}
+		got[header[cell[0]:cell[1]]] = strings.TrimSpace(row[cell[0]:end])
+	}
+
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("expected %s to be %q, got %q in:\n%s", name, value, got[name], table)
+		}
+	}
+}
+
+func TestGetWritesParentInTable(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "films", true)
+	action, _ := category.NewCategory("Action", "", true)
+	action.ParentID = &movies.ID
+	commands, out := newCommands(newMemoryGateway(movies, action), &publisherMock{})
+
+	if err := commands.Run(context.Background(), "get", action.ID.String(), parseOptions(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	assertTableRow(t, out.String(), map[string]string{
+		"ID":          action.ID.String(),
+		"SLUG":        "action",
+		"PARENT_ID":   movies.ID.String(),
+		"DESCRIPTION": "",
+		"IS_ACTIVE":   "true",
+		"CREATED_AT":  action.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func TestUsageErrors(t *testing.T) {
+	commands, _ := newCommands(newMemoryGateway(), &publisherMock{})
+
+	cases := []struct {
+		action string
+		args   []string
+	}{
+		{"get", nil},
+		{"rename", []string{}},
+		{"list", []string{"-output", "xml"}},
+		{"create", nil},
+	}
+
+	for _, tc := range cases {
+		id := ""
+		if tc.action == "rename" {
+			id = "some-id"
+		}
+		err := commands.Run(context.Background(), tc.action, id, parseOptions(t, tc.args...))
+		if !errors.Is(err, ErrUsage) {
+			t.Errorf("%s %v: expected usage error, got %v", tc.action, tc.args, err)
+		}
+	}
+}
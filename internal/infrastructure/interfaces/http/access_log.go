@@ -0,0 +1,77 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+)
+
+// RouteMatcher resolves the route pattern serving a request; *http.ServeMux
+// implements it. Patterns keep log and metric cardinality bounded, unlike
+// raw paths.
+type RouteMatcher interface {
+	Handler(r *http.Request) (http.Handler, string)
+}
+
+// AccessLog writes one record per request once it has been served.
+func AccessLog(logger *slog.Logger, routes RouteMatcher) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := newStatusRecorder(w)
+
+			next.ServeHTTP(recorder, r)
+
+			logger.LogAttrs(r.Context(), slog.LevelInfo, "http request",
+				slog.String("method", r.Method),
+				slog.String("route", routePattern(routes, r)),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", recorder.status),
+				slog.Int64("bytes", recorder.bytes),
+				slog.Duration("latency", time.Since(start)),
+				slog.String("client", requestctx.ClientIP(r.Context())),
+			)
+		})
+	}
+}
+
+func routePattern(routes RouteMatcher, r *http.Request) string {
+	if _, pattern := routes.Handler(r); pattern != "" {
+		return pattern
+	}
+	return "unmatched"
+}
+
+// statusRecorder captures what a handler wrote. It unwraps to the original
+// writer so http.ResponseController keeps working for streaming handlers.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func newStatusRecorder(w http.ResponseWriter) *statusRecorder {
+	return &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/logging"
+)
+
+func TestAccessLogWithRequestContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(logging.Config{Level: slog.LevelInfo, Format: logging.FormatJSON}, &buf)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /categories/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("short and stout"))
+	})
+
+	handler := RequestContext(AccessLog(logger, mux)(mux))
+
+	tests := []struct {
+		name      string
+		requestID string
+	}{
+		{"propagated", "req-from-client"},
+		{"generated", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+
+			req := httptest.NewRequest(http.MethodGet, "/categories/42", nil)
+			req.RemoteAddr = "10.0.0.7:5123"
+			if tt.requestID != "" {
+				req.Header.Set(HeaderRequestID, tt.requestID)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			requestID := rec.Header().Get(HeaderRequestID)
+			if requestID == "" || (tt.requestID != "" && requestID != tt.requestID) {
+				t.Fatalf("unexpected response request ID %q", requestID)
+			}
+
+			var record map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("expected one JSON record, got %q", buf.String())
+			}
+
+			expected := map[string]any{
+				"msg":        "http request",
+				"method":     "GET",
+				"route":      "GET /categories/{id}",
+				"status":     float64(http.StatusTeapot),
+				"bytes":      float64(len("short and stout")),
+				"client":     "10.0.0.7",
+				"request_id": requestID,
+			}
+			for key, value := range expected {
+				if record[key] != value {
+					t.Errorf("expected %s=%v, got %v", key, value, record[key])
+				}
+			}
+			if _, ok := record["latency"]; !ok {
+				t.Error("expected latency to be logged")
+			}
+		})
+	}
+}
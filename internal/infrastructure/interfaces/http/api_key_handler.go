@@ -0,0 +1,91 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/apikey/create"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/apikey/retrive"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/apikey/revoke"
+)
+
+type APIKeyHandler struct {
+	CreateUC *create.CreateAPIKeyUseCase
+	RevokeUC *revoke.RevokeAPIKeyUseCase
+	ListUC   *retrive.ListAPIKeysUseCase
+}
+
+func NewAPIKeyHandler(
+	createUC *create.CreateAPIKeyUseCase,
+	revokeUC *revoke.RevokeAPIKeyUseCase,
+	listUC *retrive.ListAPIKeysUseCase,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		CreateUC: createUC,
+		RevokeUC: revokeUC,
+		ListUC:   listUC,
+	}
+}
+
+type APIKeyRequest struct {
+	Name         string   `json:"name"`
+	Permissions  []string `json:"permissions"`
+	AllowedCIDRs []string `json:"allowed_cidrs"`
+}
+
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req APIKeyRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.CreateUC.Execute(r.Context(), create.CreateAPIKeyInput{
+		Name:         req.Name,
+		Permissions:  req.Permissions,
+		AllowedCIDRs: req.AllowedCIDRs,
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	location := fmt.Sprintf("/api-keys/%s", output.ID)
+	w.Header().Set("Location", location)
+	w.Header().Set("Cache-Control", "no-store")
+
+	respondJSON(w, http.StatusCreated, output)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	err := h.RevokeUC.Execute(r.Context(), revoke.RevokeAPIKeyInput{
+		ID: r.PathValue("id"),
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	output, err := h.ListUC.Execute(r.Context(), retrive.ListAPIKeysInput{
+		IncludeRevoked: query.Get("include_revoked") == "true",
+		Page:           parseInt(query.Get("page"), 1),
+		PerPage:        parseInt(query.Get("per_page"), 10),
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/audit/retrive"
+)
+
+type AuditHandler struct {
+	ListCategoryHistoryUC *retrive.ListCategoryHistoryUseCase
+}
+
+func NewAuditHandler(listCategoryHistoryUC *retrive.ListCategoryHistoryUseCase) *AuditHandler {
+	return &AuditHandler{
+		ListCategoryHistoryUC: listCategoryHistoryUC,
+	}
+}
+
+func (h *AuditHandler) CategoryHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	output, err := h.ListCategoryHistoryUC.Execute(r.Context(), retrive.ListCategoryHistoryInput{
+		CategoryID: r.PathValue("id"),
+		Page:       parseInt(query.Get("page"), 1),
+		PerPage:    parseInt(query.Get("per_page"), 10),
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, output)
+}
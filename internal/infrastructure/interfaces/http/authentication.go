@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/apikey/authenticate"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+)
+
+const authRealm = "catalog"
+
+const (
+	SchemeBearer = "Bearer"
+	SchemeAPIKey = "ApiKey"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, credentials string) (requestctx.Principal, error)
+}
+
+// AuthScheme binds an Authorization header scheme to its authenticator.
+type AuthScheme struct {
+	Name          string
+	Authenticator Authenticator
+}
+
+// Authenticate rejects requests without valid credentials for one of the
+// schemes and stores the authenticated principal in the request context.
+func Authenticate(schemes ...AuthScheme) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name, credentials, ok := authorization(r)
+
+			scheme, known := findScheme(schemes, name)
+			if !ok || !known {
+				unauthorized(w, r, challenges(schemes, ""), "missing credentials")
+				return
+			}
+
+			principal, err := scheme.Authenticator.Authenticate(r.Context(), credentials)
+			if err != nil {
+				challenge := fmt.Sprintf(`error="invalid_token", error_description=%q`, err.Error())
+				unauthorized(w, r, challenges([]AuthScheme{scheme}, challenge), fmt.Sprintf("invalid %s credentials", scheme.Name))
+				return
+			}
+
+			ctx := requestctx.WithPrincipal(r.Context(), principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// APIKeyAuthenticator adapts the API key use case to the ApiKey scheme.
+type APIKeyAuthenticator struct {
+	UseCase *authenticate.AuthenticateAPIKeyUseCase
+}
+
+func NewAPIKeyAuthenticator(useCase *authenticate.AuthenticateAPIKeyUseCase) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{UseCase: useCase}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, credentials string) (requestctx.Principal, error) {
+	output, err := a.UseCase.Execute(ctx, authenticate.AuthenticateAPIKeyInput{
+		Token:    credentials,
+		ClientIP: requestctx.ClientIP(ctx),
+	})
+	if err != nil {
+		return requestctx.Principal{}, err
+	}
+
+	return requestctx.Principal{
+		Kind:        requestctx.PrincipalAPIKey,
+		Subject:     output.ID,
+		Permissions: output.Permissions,
+	}, nil
+}
+
+func authorization(r *http.Request) (string, string, bool) {
+	scheme, credentials, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	credentials = strings.TrimSpace(credentials)
+
+	return scheme, credentials, ok && credentials != ""
+}
+
+func findScheme(schemes []AuthScheme, name string) (AuthScheme, bool) {
+	for _, scheme := range schemes {
+		if strings.EqualFold(scheme.Name, name) {
+			return scheme, true
+		}
+	}
+	return AuthScheme{}, false
+}
+
+func challenges(schemes []AuthScheme, params string) []string {
+	values := make([]string, 0, len(schemes))
+	for _, scheme := range schemes {
+		challenge := fmt.Sprintf(`%s realm=%q`, scheme.Name, authRealm)
+		if params != "" {
+			challenge += ", " + params
+		}
+		values = append(values, challenge)
+	}
+	return values
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, challenges []string, detail string) {
+	for _, challenge := range challenges {
+		w.Header().Add("WWW-Authenticate", challenge)
+	}
+	respondProblem(w, r, http.StatusUnauthorized, detail)
+}
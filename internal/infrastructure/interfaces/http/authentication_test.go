@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+)
+
+type authenticatorFunc func(credentials string) (requestctx.Principal, error)
+
+func (f authenticatorFunc) Authenticate(ctx context.Context, credentials string) (requestctx.Principal, error) {
+	return f(credentials)
+}
+
+func TestAuthenticate(t *testing.T) {
+	bearer := authenticatorFunc(func(token string) (requestctx.Principal, error) {
+		switch token {
+		case "good":
+			return requestctx.Principal{Kind: requestctx.PrincipalUser, Subject: "alice", Roles: []string{"admin"}}, nil
+		case "impostor":
+			return requestctx.Principal{Kind: requestctx.PrincipalUser, Subject: "apikey:1"}, nil
+		}
+		return requestctx.Principal{}, errors.New("token is expired")
+	})
+	apiKey := authenticatorFunc(func(key string) (requestctx.Principal, error) {
+		if key != "key" {
+			return requestctx.Principal{}, errors.New("invalid api key")
+		}
+		return requestctx.Principal{Kind: requestctx.PrincipalAPIKey, Subject: "1", Permissions: []string{"catalog:read"}}, nil
+	})
+
+	var actor string
+	handler := Authenticate(
+		AuthScheme{Name: SchemeBearer, Authenticator: bearer},
+		AuthScheme{Name: SchemeAPIKey, Authenticator: apiKey},
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = requestctx.Actor(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+		actor         string
+		challenges    []string
+	}{
+		{"valid token", "Bearer good", http.StatusNoContent, "user:alice", nil},
+		{"valid api key", "ApiKey key", http.StatusNoContent, "apikey:1", nil},
+		{"token subject shaped like a key", "Bearer impostor", http.StatusNoContent, "user:apikey:1", nil},
+		{"missing header", "", http.StatusUnauthorized, "", []string{`Bearer realm="catalog"`, `ApiKey realm="catalog"`}},
+		{"other scheme", "Basic Zm9vOmJhcg==", http.StatusUnauthorized, "", []string{`Bearer realm="catalog"`, `ApiKey realm="catalog"`}},
+		{"invalid token", "Bearer bad", http.StatusUnauthorized, "", []string{`Bearer realm="catalog", error="invalid_token"`}},
+		{"invalid api key", "ApiKey bad", http.StatusUnauthorized, "", []string{`ApiKey realm="catalog", error="invalid_token"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor = ""
+
+			req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+
+			challenges := rec.Header().Values("WWW-Authenticate")
+			if len(challenges) != len(tt.challenges) {
+				t.Fatalf("expected challenges %q, got %q", tt.challenges, challenges)
+			}
+			for i, expected := range tt.challenges {
+				if !strings.HasPrefix(challenges[i], expected) {
+					t.Errorf("expected challenge starting with %q, got %q", expected, challenges[i])
+				}
+			}
+
+			if actor != tt.actor {
+				t.Errorf("expected actor %q, got %q", tt.actor, actor)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+)
+
+type Authorizer interface {
+	Allows(principal requestctx.Principal, permission string) bool
+}
+
+// RequirePermission only lets requests through when the authenticated
+// principal holds permission, answering 403 with a problem document otherwise.
+func RequirePermission(authorizer Authorizer, permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := requestctx.CurrentPrincipal(r.Context())
+		if !ok || !authorizer.Allows(principal, permission) {
+			respondProblem(w, r, http.StatusForbidden, fmt.Sprintf("missing permission %s", permission))
+			return
+		}
+
+		next(w, r)
+	}
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+)
+
+type authorizerFunc func(principal requestctx.Principal, permission string) bool
+
+func (f authorizerFunc) Allows(principal requestctx.Principal, permission string) bool {
+	return f(principal, permission)
+}
+
+func TestRequirePermission(t *testing.T) {
+	authorizer := authorizerFunc(func(principal requestctx.Principal, permission string) bool {
+		return principal.Subject == "admin" || permission == "catalog:read"
+	})
+
+	handler := RequirePermission(authorizer, "catalog:delete", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name      string
+		principal *requestctx.Principal
+		status    int
+	}{
+		{"allowed", &requestctx.Principal{Subject: "admin"}, http.StatusNoContent},
+		{"denied", &requestctx.Principal{Subject: "viewer"}, http.StatusForbidden},
+		{"unauthenticated", nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/categories/1", nil)
+			if tt.principal != nil {
+				req = req.WithContext(requestctx.WithPrincipal(req.Context(), *tt.principal))
+			}
+			rec := httptest.NewRecorder()
+
+			handler(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+
+			if tt.status != http.StatusForbidden {
+				return
+			}
+
+			if contentType := rec.Header().Get("Content-Type"); contentType != problemContentType {
+				t.Errorf("expected problem content type, got %q", contentType)
+			}
+
+			var problem Problem
+			if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+				t.Fatalf("decoding problem: %v", err)
+			}
+			if problem.Status != http.StatusForbidden || problem.Detail != "missing permission catalog:delete" || problem.Instance != "/categories/1" {
+				t.Errorf("unexpected problem %+v", problem)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/batch"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/validation"
+)
+
+// maxBatchBytes bounds the request body of a batch; the number of
+// operations is capped by the use case.
+const maxBatchBytes = 1 << 20
+
+type BatchHandler struct {
+	BatchUC *batch.BatchCategoriesUseCase
+	// Authorizer checks DeletePermission for batches with delete
+	// operations, since the route itself only requires write access.
+	Authorizer       Authorizer
+	DeletePermission string
+}
+
+func NewBatchHandler(batchUC *batch.BatchCategoriesUseCase, authorizer Authorizer, deletePermission string) *BatchHandler {
+	return &BatchHandler{
+		BatchUC:          batchUC,
+		Authorizer:       authorizer,
+		DeletePermission: deletePermission,
+	}
+}
+
+type BatchRequest struct {
+	Atomic     bool                    `json:"atomic"`
+	Operations []BatchOperationRequest `json:"operations"`
+}
+
+// BatchOperationRequest is one of create, update, delete, activate or
+// deactivate; update changes only the fields present.
+type BatchOperationRequest struct {
+	Op          string  `json:"op"`
+	ID          string  `json:"id"`
+	Name        *string `json:"name"`
+	Slug        *string `json:"slug"`
+	Description *string `json:"description"`
+	IsActive    *bool   `json:"is_active"`
+	ParentID    *string `json:"parent_id"`
+}
+
+type BatchResponse struct {
+	Atomic    bool                  `json:"atomic"`
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Results   []BatchResultResponse `json:"results"`
+}
+
+// BatchResultResponse carries the HTTP status the operation would have had
+// on its own endpoint, as in a WebDAV multi-status response.
+type BatchResultResponse struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	ID     string `json:"id,omitempty"`
+	Status int    `json:"status"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ApplyBatch answers 207 Multi-Status with one result per operation, in
+// request order.
+func (h *BatchHandler) ApplyBatch(w http.ResponseWriter, r *http.Request) {
+	var req BatchRequest
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBytes)).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondProblem(w, r, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := batch.BatchCategoriesInput{Atomic: req.Atomic}
+	for _, op := range req.Operations {
+		if batch.OperationType(op.Op) == batch.OperationDelete && !h.allowsDelete(r) {
+			respondProblem(w, r, http.StatusForbidden, fmt.Sprintf("missing permission %s", h.DeletePermission))
+			return
+		}
+
+		input.Operations = append(input.Operations, batch.Operation{
+			Type:        batch.OperationType(op.Op),
+			ID:          op.ID,
+			Name:        op.Name,
+			Slug:        op.Slug,
+			Description: op.Description,
+			IsActive:    op.IsActive,
+			ParentID:    op.ParentID,
+		})
+	}
+
+	output, err := h.BatchUC.Execute(r.Context(), input)
+
+	if err != nil {
+		respondError(w, r, err, batchErrorStatus(err))
+		return
+	}
+
+	response := BatchResponse{
+		Atomic:    output.Atomic,
+		Succeeded: output.Succeeded,
+		Failed:    output.Failed,
+		Results:   make([]BatchResultResponse, 0, len(output.Results)),
+	}
+	for _, result := range output.Results {
+		response.Results = append(response.Results, BatchResultResponse{
+			Index:  result.Index,
+			Op:     string(result.Type),
+			ID:     result.ID,
+			Status: batchResultStatus(result),
+			Result: string(result.Status),
+			Error:  result.Error,
+		})
+	}
+
+	respondJSON(w, http.StatusMultiStatus, response)
+}
+
+func (h *BatchHandler) allowsDelete(r *http.Request) bool {
+	principal, ok := requestctx.CurrentPrincipal(r.Context())
+	return ok && h.Authorizer.Allows(principal, h.DeletePermission)
+}
+
+func batchErrorStatus(err error) int {
+	if errors.Is(err, batch.ErrBatchTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+func batchResultStatus(result batch.OperationResult) int {
+	switch result.Status {
+	case batch.StatusCreated:
+		return http.StatusCreated
+	case batch.StatusUpdated:
+		return http.StatusOK
+	case batch.StatusDeleted:
+		return http.StatusNoContent
+	case batch.StatusRolledBack, batch.StatusSkipped:
+		return http.StatusFailedDependency
+	}
+
+	var invalid validation.ValidationErrors
+	switch {
+	case errors.Is(result.Err, category.ErrCategoryNotFound):
+		return http.StatusNotFound
+	case errors.Is(result.Err, category.ErrNameConflict), errors.Is(result.Err, category.ErrSlugConflict),
+		errors.Is(result.Err, category.ErrCategoryHasChildren):
+		return http.StatusConflict
+	case errors.As(result.Err, &invalid):
+		return http.StatusUnprocessableEntity
+	case errors.Is(result.Err, batch.ErrInvalidOperation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/batch"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+func postBatch(subject, body string) *httptest.ResponseRecorder {
+	authorizer := authorizerFunc(func(principal requestctx.Principal, permission string) bool {
+		return principal.Subject == "admin"
+	})
+	handler := NewBatchHandler(
+		batch.NewBatchCategoriesUseCase(&importGatewayStub{}, discardRevisions{}, discardPublisher{}, usecase.NoTransaction{}, 10),
+		authorizer,
+		"catalog:delete",
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/categories/batch", strings.NewReader(body))
+	req = req.WithContext(requestctx.WithPrincipal(req.Context(), requestctx.Principal{Subject: subject}))
+	rec := httptest.NewRecorder()
+	handler.ApplyBatch(rec, req)
+	return rec
+}
+
+func TestApplyBatchReportsStatusPerOperation(t *testing.T) {
+	missing := category.NewCategoryID().String()
+	body := `{"operations":[
+		{"op":"create","name":"Movies"},
+		{"op":"deactivate","id":"` + missing + `"},
+		{"op":"create","name":"ab"},
+		{"op":"rename","id":"` + missing + `"}
+	]}`
+
+	rec := postBatch("editor", body)
+
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d: %s", rec.Code, rec.Body)
+	}
+
+	var response BatchResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{http.StatusCreated, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusBadRequest}
+	for i, result := range response.Results {
+		if result.Status != want[i] {
+			t.Errorf("operation %d: expected %d, got %d (%s)", i, want[i], result.Status, result.Error)
+		}
+	}
+	if response.Results[0].ID == "" || response.Succeeded != 1 || response.Failed != 3 {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestApplyBatchRequiresDeletePermissionForDeletes(t *testing.T) {
+	body := `{"operations":[{"op":"delete","id":"` + category.NewCategoryID().String() + `"}]}`
+
+	if rec := postBatch("editor", body); rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for an editor, got %d", rec.Code)
+	}
+	if rec := postBatch("admin", body); rec.Code != http.StatusMultiStatus {
+		t.Errorf("expected 207 for an admin, got %d", rec.Code)
+	}
+}
+
+func TestApplyBatchRejectsOversizedBatches(t *testing.T) {
+	ops := strings.Repeat(`{"op":"create","name":"Movies"},`, 11)
+	rec := postBatch("editor", `{"operations":[`+strings.TrimSuffix(ops, ",")+`]}`)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,248 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/export"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExportHandler struct {
+	ExportUC *export.ExportCategoriesUseCase
+}
+
+func NewExportHandler(exportUC *export.ExportCategoriesUseCase) *ExportHandler {
+	return &ExportHandler{
+		ExportUC: exportUC,
+	}
+}
+
+type exportFormat struct {
+	contentType string
+	extension   string
+	newEncoder  func(io.Writer) categoryEncoder
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv":    {"text/csv; charset=utf-8", "csv", newCSVEncoder},
+	"ndjson": {"application/x-ndjson", "ndjson", newNDJSONEncoder},
+	"xlsx":   {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx", newXLSXEncoder},
+}
+
+var exportColumns = []string{"id", "name", "slug", "description", "is_active", "created_at", "updated_at"}
+
+// ExportCategories downloads every category matching the ListCategories
+// filters (terms, sort, direction) as ?format=csv, ndjson or xlsx. Rows are
+// written as they are read from the database.
+func (h *ExportHandler) ExportCategories(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	name := query.Get("format")
+	if name == "" {
+		name = "csv"
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		respondProblem(w, r, http.StatusBadRequest, "format must be csv, ndjson or xlsx")
+		return
+	}
+
+	// Large exports outlive any server-wide write timeout.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		respondError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	filename := "categories-" + time.Now().UTC().Format("20060102") + "." + format.extension
+
+	w.Header().Set("Content-Type", format.contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set("Cache-Control", "no-store")
+
+	out := &trackingWriter{w: w}
+	encoder := format.newEncoder(out)
+
+	err := h.ExportUC.Execute(r.Context(), export.ExportCategoriesInput{
+		Terms:     query.Get("terms"),
+		Sort:      query.Get("sort"),
+		Direction: query.Get("direction"),
+	}, encoder.Encode)
+	if err == nil {
+		err = encoder.Close()
+	}
+
+	if err == nil {
+		return
+	}
+
+	if !out.written {
+		w.Header().Del("Content-Disposition")
+		respondError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	// The status is already sent; abort the connection so the client sees
+	// an incomplete download rather than a file that looks whole.
+	slog.ErrorContext(r.Context(), "export interrupted", slog.Any("error", err))
+	panic(http.ErrAbortHandler)
+}
+
+// trackingWriter records whether any byte reached the client.
+type trackingWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		t.written = true
+	}
+	return t.w.Write(p)
+}
+
+type categoryEncoder interface {
+	Encode(category.Category) error
+	Close() error
+}
+
+func exportRow(cat category.Category) []string {
+	return []string{
+		cat.ID.String(),
+		cat.Name,
+		cat.Slug,
+		cat.Description,
+		strconv.FormatBool(cat.IsActive),
+		cat.CreatedAt.UTC().Format(time.RFC3339),
+		cat.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+type csvEncoder struct {
+	w      *csv.Writer
+	header bool
+}
+
+func newCSVEncoder(w io.Writer) categoryEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+// writeHeader is deferred to the first row, so a query that fails before
+// returning anything can still be answered with an error status.
+func (e *csvEncoder) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+	return e.w.Write(exportColumns)
+}
+
+func (e *csvEncoder) Encode(cat category.Category) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	return e.w.Write(exportRow(cat))
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonEncoder writes categories as GET /categories lists them.
+type ndjsonEncoder struct {
+	json *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) categoryEncoder {
+	return &ndjsonEncoder{json: json.NewEncoder(w)}
+}
+
+func (e *ndjsonEncoder) Encode(cat category.Category) error {
+	return e.json.Encode(cat)
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return nil
+}
+
+// xlsxEncoder fills a worksheet through excelize's stream writer, which
+// spills to a temporary file instead of holding every row; the workbook is
+// written out on Close.
+type xlsxEncoder struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+	err    error
+}
+
+const xlsxSheet = "Categories"
+
+func newXLSXEncoder(w io.Writer) categoryEncoder {
+	e := &xlsxEncoder{out: w, file: excelize.NewFile(), row: 1}
+
+	if e.err = e.file.SetSheetName("Sheet1", xlsxSheet); e.err != nil {
+		return e
+	}
+	if e.stream, e.err = e.file.NewStreamWriter(xlsxSheet); e.err != nil {
+		return e
+	}
+
+	header := make([]any, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column
+	}
+	e.err = e.writeRow(header)
+	return e
+}
+
+func (e *xlsxEncoder) writeRow(values []any) error {
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	e.row++
+	return e.stream.SetRow(cell, values)
+}
+
+func (e *xlsxEncoder) Encode(cat category.Category) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.err = e.writeRow([]any{
+		cat.ID.String(),
+		cat.Name,
+		cat.Slug,
+		cat.Description,
+		cat.IsActive,
+		cat.CreatedAt.UTC(),
+		cat.UpdatedAt.UTC(),
+	})
+	return e.err
+}
+
+func (e *xlsxEncoder) Close() error {
+	defer e.file.Close()
+
+	if e.err != nil {
+		return e.err
+	}
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := e.file.WriteTo(e.out)
+	return err
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/export"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/xuri/excelize/v2"
+)
+
+type streamerStub struct {
+	categories []category.Category
+	query      category.SearchCategoryQuery
+	err        error
+}
+
+func (s *streamerStub) StreamCategories(ctx context.Context, query category.SearchCategoryQuery, visit func(category.Category) error) error {
+	s.query = query
+	if s.err != nil {
+		return s.err
+	}
+	for _, cat := range s.categories {
+		if err := visit(cat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportRequest(streamer *streamerStub, query string) *httptest.ResponseRecorder {
+	handler := NewExportHandler(export.NewExportCategoriesUseCase(streamer))
+
+	rec := httptest.NewRecorder()
+	handler.ExportCategories(rec, httptest.NewRequest(http.MethodGet, "/categories/export"+query, nil))
+	return rec
+}
+
+func exportFixture() *streamerStub {
+	movies, _ := category.NewCategory("Movies", "films, mostly", true)
+	series, _ := category.NewCategory("Series", "", false)
+	return &streamerStub{categories: []category.Category{*movies, *series}}
+}
+
+func TestExportCategoriesCSV(t *testing.T) {
+	streamer := exportFixture()
+
+	rec := exportRequest(streamer, "?terms=mov&sort=name&direction=desc")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Disposition"); !strings.HasPrefix(got, "attachment; filename=categories-") || !strings.HasSuffix(got, ".csv") {
+		t.Errorf("unexpected Content-Disposition %q", got)
+	}
+
+	want := category.SearchCategoryQuery{Terms: "mov", Sort: "name", Direction: "desc"}
+	if streamer.query != want {
+		t.Errorf("expected query %+v, got %+v", want, streamer.query)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || records[0][1] != "name" || records[1][2] != "movies" || records[1][3] != "films, mostly" || records[2][4] != "false" {
+		t.Errorf("unexpected CSV %q", records)
+	}
+}
+
+func TestExportCategoriesNDJSON(t *testing.T) {
+	rec := exportRequest(exportFixture(), "?format=ndjson")
+
+	if got := rec.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("unexpected Content-Type %q", got)
+	}
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+
+	var cat category.Category
+	if err := json.Unmarshal([]byte(lines[1]), &cat); err != nil {
+		t.Fatal(err)
+	}
+	if cat.Name != "Series" {
+		t.Errorf("unexpected category %+v", cat)
+	}
+}
+
+func TestExportCategoriesXLSX(t *testing.T) {
+	rec := exportRequest(exportFixture(), "?format=xlsx")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	file, err := excelize.OpenReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(xlsxSheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][0] != "id" || rows[1][1] != "Movies" || rows[1][2] != "movies" || rows[2][4] != "FALSE" {
+		t.Errorf("unexpected rows %q", rows)
+	}
+}
+
+func TestExportCategoriesErrors(t *testing.T) {
+	if rec := exportRequest(exportFixture(), "?format=pdf"); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown format, got %d", rec.Code)
+	}
+
+	rec := exportRequest(&streamerStub{err: errors.New("connection refused")}, "")
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when the query fails, got %d", rec.Code)
+	}
+	if rec.Header().Get("Content-Disposition") != "" {
+		t.Error("expected no attachment for an error")
+	}
+}
@@ -39,8 +39,10 @@ func NewCategoryHandler(
 
 type CreateCategoryRequest struct {
 	Name        string `json:"name"`
+	Slug        string `json:"slug"`
 	Description string `json:"description"`
 	IsActive    bool   `json:"is_active"`
+	ParentID    string `json:"parent_id"`
 }
 
 func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
@@ -51,14 +53,16 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	output, err := h.CreateUC.Execute(create.CreateCategoryInput{
+	output, err := h.CreateUC.Execute(r.Context(), create.CreateCategoryInput{
 		Name:        req.Name,
+		Slug:        req.Slug,
 		Description: req.Description,
 		IsActive:    req.IsActive,
+		ParentID:    req.ParentID,
 	})
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondCategoryError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
@@ -72,12 +76,12 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
-	output, err := h.GetByIDUC.Execute(retrive.GetCategoryByIDInput{
+	output, err := h.GetByIDUC.Execute(r.Context(), retrive.GetCategoryByIDInput{
 		ID: id,
 	})
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		respondError(w, r, err, http.StatusNotFound)
 		return
 	}
 
@@ -85,9 +89,11 @@ func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request
 }
 
 type UpdateCategoryRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	IsActive    bool   `json:"is_active"`
+	Name        string  `json:"name"`
+	Slug        string  `json:"slug"`
+	Description string  `json:"description"`
+	IsActive    bool    `json:"is_active"`
+	ParentID    *string `json:"parent_id"`
 }
 
 func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
@@ -100,15 +106,17 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	output, err := h.UpdateUC.Execute(update.UpdateCategoryInput{
+	output, err := h.UpdateUC.Execute(r.Context(), update.UpdateCategoryInput{
 		ID:          id,
 		Name:        req.Name,
+		Slug:        req.Slug,
 		Description: req.Description,
 		IsActive:    req.IsActive,
+		ParentID:    req.ParentID,
 	})
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondCategoryError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
@@ -119,12 +127,12 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
-	err := h.DeleteUC.Execute(delete.DeleteCategoryInput{
+	err := h.DeleteUC.Execute(r.Context(), delete.DeleteCategoryInput{
 		ID: id,
 	})
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondCategoryError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
@@ -143,10 +151,10 @@ func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request)
 		Direction: query.Get("direction"),
 	}
 
-	output, err := h.ListUC.Execute(input)
+	output, err := h.ListUC.Execute(r.Context(), input)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
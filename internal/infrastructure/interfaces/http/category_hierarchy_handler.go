@@ -0,0 +1,81 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/hierarchy"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type HierarchyHandler struct {
+	GetChildrenUC  *hierarchy.GetChildrenUseCase
+	GetAncestorsUC *hierarchy.GetAncestorsUseCase
+	GetTreeUC      *hierarchy.GetTreeUseCase
+}
+
+func NewHierarchyHandler(
+	getChildrenUC *hierarchy.GetChildrenUseCase,
+	getAncestorsUC *hierarchy.GetAncestorsUseCase,
+	getTreeUC *hierarchy.GetTreeUseCase,
+) *HierarchyHandler {
+	return &HierarchyHandler{
+		GetChildrenUC:  getChildrenUC,
+		GetAncestorsUC: getAncestorsUC,
+		GetTreeUC:      getTreeUC,
+	}
+}
+
+// ListChildren answers the categories directly below one, ordered by name.
+func (h *HierarchyHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	children, err := h.GetChildrenUC.Execute(r.Context(), hierarchy.GetChildrenInput{
+		ID: r.PathValue("id"),
+	})
+	if err != nil {
+		respondHierarchyError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nonNil(children))
+}
+
+// ListAncestors answers the breadcrumb of a category: its ancestors from
+// the root down to its parent.
+func (h *HierarchyHandler) ListAncestors(w http.ResponseWriter, r *http.Request) {
+	ancestors, err := h.GetAncestorsUC.Execute(r.Context(), hierarchy.GetAncestorsInput{
+		ID: r.PathValue("id"),
+	})
+	if err != nil {
+		respondHierarchyError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nonNil(ancestors))
+}
+
+// GetTree answers every category, nested under its parent.
+func (h *HierarchyHandler) GetTree(w http.ResponseWriter, r *http.Request) {
+	roots, err := h.GetTreeUC.Execute(r.Context())
+	if err != nil {
+		respondError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, roots)
+}
+
+func respondHierarchyError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, category.ErrCategoryNotFound) {
+		respondError(w, r, err, http.StatusNotFound)
+		return
+	}
+	respondError(w, r, err, http.StatusBadRequest)
+}
+
+// nonNil makes an empty list encode as [] rather than null.
+func nonNil(cats []category.Category) []category.Category {
+	if cats == nil {
+		return []category.Category{}
+	}
+	return cats
+}
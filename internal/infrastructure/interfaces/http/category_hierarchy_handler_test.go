@@ -0,0 +1,61 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/hierarchy"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+func hierarchyRoutes(streamer *streamerStub) *http.ServeMux {
+	gateway := &importGatewayStub{}
+	handler := NewHierarchyHandler(
+		hierarchy.NewGetChildrenUseCase(gateway),
+		hierarchy.NewGetAncestorsUseCase(gateway),
+		hierarchy.NewGetTreeUseCase(streamer),
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /categories/tree", handler.GetTree)
+	mux.HandleFunc("GET /categories/{id}/children", handler.ListChildren)
+	mux.HandleFunc("GET /categories/{id}/ancestors", handler.ListAncestors)
+	return mux
+}
+
+func TestGetTreeNestsChildren(t *testing.T) {
+	movies, _ := category.NewCategory("Movies", "", true)
+	action, _ := category.NewCategory("Action", "", true)
+	action.ParentID = &movies.ID
+	routes := hierarchyRoutes(&streamerStub{categories: []category.Category{*action, *movies}})
+
+	rec := httptest.NewRecorder()
+	routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/categories/tree", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+
+	var roots []hierarchy.TreeNode
+	if err := json.NewDecoder(rec.Body).Decode(&roots); err != nil {
+		t.Fatal(err)
+	}
+	if len(roots) != 1 || roots[0].ID != movies.ID || len(roots[0].Children) != 1 || roots[0].Children[0].ID != action.ID {
+		t.Errorf("expected Action below Movies, got %+v", roots)
+	}
+}
+
+func TestHierarchyRoutesAnswerNotFound(t *testing.T) {
+	routes := hierarchyRoutes(&streamerStub{})
+	id := category.NewCategoryID().String()
+
+	for _, path := range []string{"/categories/" + id + "/children", "/categories/" + id + "/ancestors"} {
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", path, rec.Code)
+		}
+	}
+}
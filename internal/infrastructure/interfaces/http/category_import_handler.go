@@ -0,0 +1,195 @@
+package http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/importing"
+)
+
+// maxImportBytes bounds the request body of an import.
+const maxImportBytes = 10 << 20
+
+type ImportHandler struct {
+	ImportUC *importing.ImportCategoriesUseCase
+}
+
+func NewImportHandler(importUC *importing.ImportCategoriesUseCase) *ImportHandler {
+	return &ImportHandler{
+		ImportUC: importUC,
+	}
+}
+
+// ImportCategories reads CSV (text/csv) or NDJSON (application/x-ndjson)
+// rows with name, description and is_active, and answers with a per-row
+// report. ?mode= is all_or_nothing (the default) or best_effort; an
+// all-or-nothing import that created nothing answers 422.
+func (h *ImportHandler) ImportCategories(w http.ResponseWriter, r *http.Request) {
+	mode := importing.Mode(r.URL.Query().Get("mode"))
+	if mode == "" {
+		mode = importing.ModeAllOrNothing
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	var decode func(io.Reader) ([]importing.Row, error)
+	switch mediaType {
+	case "text/csv":
+		decode = decodeCSVRows
+	case "application/x-ndjson", "application/ndjson":
+		decode = decodeNDJSONRows
+	default:
+		respondProblem(w, r, http.StatusUnsupportedMediaType, "send text/csv or application/x-ndjson")
+		return
+	}
+
+	rows, err := decode(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondProblem(w, r, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		respondProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := h.ImportUC.Execute(r.Context(), importing.ImportCategoriesInput{
+		Mode: mode,
+		Rows: rows,
+	})
+
+	if err != nil {
+		respondError(w, r, err, importErrorStatus(err))
+		return
+	}
+
+	status := http.StatusOK
+	if mode == importing.ModeAllOrNothing && output.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	respondJSON(w, status, output)
+}
+
+func importErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, importing.ErrUnknownMode):
+		return http.StatusBadRequest
+	case errors.Is(err, importing.ErrTooManyRows):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// decodeCSVRows expects a header naming the columns; only name is required
+// and is_active defaults to true. Malformed rows become row errors.
+func decodeCSVRows(body io.Reader) ([]importing.Row, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty CSV: a header row is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("CSV header must have a name column")
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var rows []importing.Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, importing.Row{Line: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := importing.Row{
+			Line:        line,
+			Name:        field(record, "name"),
+			Description: field(record, "description"),
+		}
+		row.IsActive, row.Err = parseActive(field(record, "is_active"))
+		rows = append(rows, row)
+	}
+}
+
+type importRowRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+// decodeNDJSONRows reads one JSON object per line, skipping blank lines;
+// is_active defaults to true.
+func decodeNDJSONRows(body io.Reader) ([]importing.Row, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64<<10), maxImportBytes)
+
+	var rows []importing.Row
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var req importRowRequest
+		if err := json.Unmarshal([]byte(text), &req); err != nil {
+			rows = append(rows, importing.Row{Line: line, Err: fmt.Errorf("invalid JSON: %w", err)})
+			continue
+		}
+
+		row := importing.Row{Line: line, Name: req.Name, Description: req.Description, IsActive: true}
+		if req.IsActive != nil {
+			row.IsActive = *req.IsActive
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, scanner.Err()
+}
+
+func parseActive(value string) (bool, error) {
+	if strings.TrimSpace(value) == "" {
+		return true, nil
+	}
+	active, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("is_active: %q is not a boolean", value)
+	}
+	return active, nil
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/importing"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+)
+
+type importGatewayStub struct {
+	created []*category.Category
+}
+
+func (g *importGatewayStub) CreateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	g.created = append(g.created, cat)
+	return cat, nil
+}
+
+func (g *importGatewayStub) GetCategoryByID(ctx context.Context, id category.CategoryID) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (g *importGatewayStub) UpdateCategory(ctx context.Context, cat *category.Category) (*category.Category, error) {
+	return cat, nil
+}
+
+func (g *importGatewayStub) DeleteCategory(ctx context.Context, id category.CategoryID) error {
+	return nil
+}
+
+func (g *importGatewayStub) FindCategoryByName(ctx context.Context, name string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (g *importGatewayStub) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	return nil, category.ErrCategoryNotFound
+}
+
+func (g *importGatewayStub) FindChildren(ctx context.Context, parentID category.CategoryID) ([]category.Category, error) {
+	return nil, nil
+}
+
+func (g *importGatewayStub) FindAll(ctx context.Context, query category.SearchCategoryQuery) (*pagination.Pagination[category.Category], error) {
+	return nil, nil
+}
+
+type discardPublisher struct{}
+
+func (discardPublisher) Publish(category.CategoryEvent) {}
+
+// discardRevisions stores nothing and finds no revision.
+type discardRevisions struct{}
+
+func (discardRevisions) AppendRevision(ctx context.Context, rev *category.CategoryRevision) (*category.CategoryRevision, error) {
+	return rev, nil
+}
+
+func (discardRevisions) GetRevision(ctx context.Context, id category.CategoryID, revision int) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (discardRevisions) GetRevisionAsOf(ctx context.Context, id category.CategoryID, at time.Time) (*category.CategoryRevision, error) {
+	return nil, category.ErrRevisionNotFound
+}
+
+func (discardRevisions) FindRevisions(ctx context.Context, query category.SearchRevisionQuery) (*pagination.Pagination[category.CategoryRevision], error) {
+	return nil, nil
+}
+
+func postImport(t *testing.T, contentType, query, body string) (*importGatewayStub, *httptest.ResponseRecorder, importing.ImportCategoriesOutput) {
+	t.Helper()
+
+	gateway := &importGatewayStub{}
+	handler := NewImportHandler(
+		importing.NewImportCategoriesUseCase(gateway, discardRevisions{}, discardPublisher{}, usecase.NoTransaction{}, 100),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/categories/import"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	handler.ImportCategories(rec, req)
+
+	var output importing.ImportCategoriesOutput
+	if strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(rec.Body).Decode(&output); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return gateway, rec, output
+}
+
+func TestImportCategoriesCSV(t *testing.T) {
+	body := "name,description,is_active\nMovies,\"films, mostly\",true\nSeries,,\nDrafts,,maybe\n"
+
+	gateway, rec, output := postImport(t, "text/csv; charset=utf-8", "?mode=best_effort", body)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if len(gateway.created) != 2 || gateway.created[0].Description != "films, mostly" || !gateway.created[1].IsActive {
+		t.Errorf("unexpected categories %+v", gateway.created)
+	}
+	if output.Failed != 1 || output.Rows[2].Line != 4 || output.Rows[2].Status != importing.RowError {
+		t.Errorf("expected line 4 to fail, got %+v", output)
+	}
+}
+
+func TestImportCategoriesNDJSONAllOrNothing(t *testing.T) {
+	body := `{"name":"Movies","is_active":false}` + "\n\n" + `{"name":` + "\n"
+
+	gateway, rec, output := postImport(t, "application/x-ndjson", "", body)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d: %s", rec.Code, rec.Body)
+	}
+	if len(gateway.created) != 0 {
+		t.Error("expected nothing to be created")
+	}
+	if output.Mode != importing.ModeAllOrNothing || output.Rows[1].Line != 3 {
+		t.Errorf("unexpected report %+v", output)
+	}
+}
+
+func TestImportCategoriesRejectsUnknownFormats(t *testing.T) {
+	_, rec, _ := postImport(t, "application/json", "", "[]")
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415, got %d", rec.Code)
+	}
+
+	_, rec, _ = postImport(t, "text/csv", "", "description\nfilms\n")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a name column, got %d", rec.Code)
+	}
+}
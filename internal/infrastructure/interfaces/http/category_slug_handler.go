@@ -0,0 +1,47 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/retrive"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type SlugHandler struct {
+	GetBySlugUC *retrive.GetCategoryBySlugUseCase
+}
+
+func NewSlugHandler(getBySlugUC *retrive.GetCategoryBySlugUseCase) *SlugHandler {
+	return &SlugHandler{
+		GetBySlugUC: getBySlugUC,
+	}
+}
+
+// GetCategoryBySlug answers the category holding the slug. A slug the
+// category had before redirects permanently to its current one, so old
+// links keep working and clients learn the new URL.
+func (h *SlugHandler) GetCategoryBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+
+	cat, err := h.GetBySlugUC.Execute(r.Context(), retrive.GetCategoryBySlugInput{
+		Slug: slug,
+	})
+
+	if errors.Is(err, category.ErrCategoryNotFound) {
+		respondError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		respondError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	if cat.Slug != slug {
+		http.Redirect(w, r, "/categories/by-slug/"+url.PathEscape(cat.Slug), http.StatusMovedPermanently)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, cat)
+}
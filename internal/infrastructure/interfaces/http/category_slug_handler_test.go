@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/retrive"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+// slugGatewayStub resolves slugs, including aliases, from a map.
+type slugGatewayStub struct {
+	importGatewayStub
+	slugs map[string]*category.Category
+}
+
+func (g *slugGatewayStub) FindCategoryBySlug(ctx context.Context, slug string) (*category.Category, error) {
+	if cat, ok := g.slugs[slug]; ok {
+		return cat, nil
+	}
+	return nil, category.ErrCategoryNotFound
+}
+
+func slugRoutes() (RouteSet, *category.Category) {
+	cat, _ := category.NewCategory("Ação e Aventura", "", true)
+	gateway := &slugGatewayStub{slugs: map[string]*category.Category{
+		"acao-e-aventura": cat,
+		"acao":            cat,
+	}}
+	handler := NewSlugHandler(retrive.NewGetCategoryBySlugUseCase(gateway))
+
+	slugMux := http.NewServeMux()
+	slugMux.HandleFunc("GET /categories/by-slug/{slug}", handler.GetCategoryBySlug)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /categories/{id}/history", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	return RouteSet{slugMux, mux}, cat
+}
+
+func getSlug(routes RouteSet, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestGetCategoryBySlug(t *testing.T) {
+	routes, cat := slugRoutes()
+
+	rec := getSlug(routes, "/categories/by-slug/acao-e-aventura")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var got category.Category
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != cat.ID {
+		t.Errorf("expected category %s, got %s", cat.ID, got.ID)
+	}
+}
+
+func TestGetCategoryBySlugRedirectsAliases(t *testing.T) {
+	routes, _ := slugRoutes()
+
+	rec := getSlug(routes, "/categories/by-slug/acao")
+	if rec.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected 301, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Location"); got != "/categories/by-slug/acao-e-aventura" {
+		t.Errorf("unexpected Location %q", got)
+	}
+
+	if rec := getSlug(routes, "/categories/by-slug/unknown"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestRouteSetFallsThroughToLaterMuxes(t *testing.T) {
+	routes, _ := slugRoutes()
+
+	req := httptest.NewRequest(http.MethodGet, "/categories/by-slug/history", nil)
+	if _, pattern := routes.Handler(req); pattern != "GET /categories/by-slug/{slug}" {
+		t.Errorf("expected the slug route to win, got %q", pattern)
+	}
+
+	if rec := getSlug(routes, "/categories/"+category.NewCategoryID().String()+"/history"); rec.Code != http.StatusTeapot {
+		t.Errorf("expected the second mux to serve the request, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/events"
+)
+
+type EventStreamHandler struct {
+	Stream            *events.Stream
+	HeartbeatInterval time.Duration
+}
+
+func NewEventStreamHandler(stream *events.Stream, heartbeatInterval time.Duration) *EventStreamHandler {
+	return &EventStreamHandler{
+		Stream:            stream,
+		HeartbeatInterval: heartbeatInterval,
+	}
+}
+
+func (h *EventStreamHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	types, err := parseEventTypes(r.URL.Query().Get("types"))
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	lastSeq, _ := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+
+	rc := http.NewResponseController(w)
+
+	// Streams outlive any server-wide write timeout.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		respondError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	replay, stream, unsubscribe := h.Stream.Subscribe(lastSeq, types)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range replay {
+		if err := writeStreamEvent(w, event); err != nil {
+			return
+		}
+	}
+
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+			if err := writeStreamEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeStreamEvent(w http.ResponseWriter, event events.StreamEvent) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, event.Data)
+	return err
+}
+
+func parseEventTypes(value string) ([]category.EventType, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var types []category.EventType
+	for _, raw := range strings.Split(value, ",") {
+		t := category.EventType(strings.TrimSpace(raw))
+		if !t.IsValid() {
+			return nil, fmt.Errorf("unknown event type %q", raw)
+		}
+		types = append(types, t)
+	}
+
+	return types, nil
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/events"
+)
+
+func newEventStreamServer(t *testing.T, stream *events.Stream) *httptest.Server {
+	t.Helper()
+
+	handler := NewEventStreamHandler(stream, time.Hour)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /events", handler.StreamEvents)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func publishCategoryEvent(stream *events.Stream, eventType category.EventType) category.CategoryEvent {
+	cat, _ := category.NewCategory("Movies", "desc", true)
+	event := category.NewCategoryEvent(eventType, nil, cat)
+	stream.Publish(event)
+	return event
+}
+
+// readStreamEvent reads one SSE frame and returns its fields.
+func readStreamEvent(t *testing.T, reader *bufio.Reader) map[string]string {
+	t.Helper()
+
+	fields := map[string]string{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("error reading stream: %v", err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if len(fields) == 0 {
+				continue
+			}
+			return fields
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, ": ")
+		fields[key] = value
+	}
+}
+
+func TestEventStreamHandler_StreamsAndResumes(t *testing.T) {
+	stream := events.NewStream(10)
+	server := newEventStreamServer(t, stream)
+
+	publishCategoryEvent(stream, category.EventCategoryCreated)
+	second := publishCategoryEvent(stream, category.EventCategoryUpdated)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events", nil)
+	req.Header.Set("Last-Event-ID", "1")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+
+	replayed := readStreamEvent(t, reader)
+	if replayed["id"] != "2" || replayed["event"] != "category.updated" {
+		t.Fatalf("expected replay of event 2, got %v", replayed)
+	}
+
+	if !strings.Contains(replayed["data"], second.ID) {
+		t.Errorf("expected payload to carry the event ID, got %s", replayed["data"])
+	}
+
+	publishCategoryEvent(stream, category.EventCategoryDeleted)
+
+	live := readStreamEvent(t, reader)
+	if live["id"] != "3" || live["event"] != "category.deleted" {
+		t.Fatalf("expected live event 3, got %v", live)
+	}
+}
+
+func TestEventStreamHandler_FiltersByType(t *testing.T) {
+	stream := events.NewStream(10)
+	server := newEventStreamServer(t, stream)
+
+	publishCategoryEvent(stream, category.EventCategoryCreated)
+	publishCategoryEvent(stream, category.EventCategoryDeleted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events?types=category.deleted", nil)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	event := readStreamEvent(t, bufio.NewReader(resp.Body))
+	if event["event"] != "category.deleted" {
+		t.Fatalf("expected only deleted events, got %v", event)
+	}
+}
+
+func TestEventStreamHandler_RejectsUnknownType(t *testing.T) {
+	server := newEventStreamServer(t, events.NewStream(10))
+
+	resp, err := http.Get(server.URL + "/events?types=category.renamed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestEventStreamHandler_UnsubscribesOnDisconnect(t *testing.T) {
+	stream := events.NewStream(10)
+	server := newEventStreamServer(t, stream)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events", nil)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stream.SubscriberCount() != 1 {
+		t.Fatalf("expected one subscriber, got %d", stream.SubscriberCount())
+	}
+
+	cancel()
+	resp.Body.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for stream.SubscriberCount() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscriber was not released after disconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/health"
+)
+
+type HealthHandler struct {
+	Health *health.Health
+}
+
+func NewHealthHandler(h *health.Health) *HealthHandler {
+	return &HealthHandler{Health: h}
+}
+
+// Liveness answers as long as the process can serve HTTP at all; it checks
+// no dependencies so a database outage does not get the process restarted.
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]string{"status": health.StatusOK})
+}
+
+// Readiness reports per-dependency status, failing with 503 when any check
+// fails or the server is shutting down.
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	report := h.Health.Readiness(r.Context())
+
+	status := http.StatusOK
+	if !report.OK() {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	respondJSON(w, status, report)
+}
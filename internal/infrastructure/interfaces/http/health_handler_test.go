@@ -0,0 +1,52 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/health"
+)
+
+func TestHealthHandlerReadiness(t *testing.T) {
+	h := health.New(health.Config{Timeout: time.Second})
+	var dbErr error
+	h.AddCheck("database", func(ctx context.Context) error { return dbErr })
+	handler := NewHealthHandler(h)
+
+	ready := func() (int, health.Report) {
+		rec := httptest.NewRecorder()
+		handler.Readiness(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		var report health.Report
+		if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+			t.Fatal(err)
+		}
+		return rec.Code, report
+	}
+
+	if code, report := ready(); code != http.StatusOK || report.Checks["database"].Status != health.StatusOK {
+		t.Errorf("expected ready, got %d %+v", code, report)
+	}
+
+	dbErr = errors.New("connection refused")
+	if code, report := ready(); code != http.StatusServiceUnavailable || report.Checks["database"].Error != "connection refused" {
+		t.Errorf("expected database failure, got %d %+v", code, report)
+	}
+
+	dbErr = nil
+	h.Shutdown()
+	if code, _ := ready(); code != http.StatusServiceUnavailable {
+		t.Errorf("expected not ready during shutdown, got %d", code)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.Liveness(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected liveness to stay ok during shutdown, got %d", rec.Code)
+	}
+}
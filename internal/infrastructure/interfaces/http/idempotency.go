@@ -0,0 +1,169 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/idempotency"
+)
+
+const (
+	headerIdempotencyKey = "Idempotency-Key"
+	headerReplayed       = "Idempotent-Replayed"
+	maxIdempotencyKeyLen = 255
+	// maxIdempotentBody bounds the request bodies read for hashing; it
+	// matches the largest body any POST route accepts.
+	maxIdempotentBody = maxImportBytes
+)
+
+// Idempotency makes POST requests carrying an Idempotency-Key safe to retry.
+// The first request with a key runs and its response is stored for ttl;
+// retries with the same body get that response again, marked with
+// Idempotent-Replayed. Reusing a key for a different body answers 422, and a
+// retry that arrives while the first request is still running answers 409.
+// Keys are scoped to the caller and route. Responses the client should
+// retry for real (5xx and 429) are not stored.
+func Idempotency(store idempotency.Store, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(headerIdempotencyKey)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLen {
+				respondProblem(w, r, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBody))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					respondProblem(w, r, http.StatusRequestEntityTooLarge, err.Error())
+					return
+				}
+				respondProblem(w, r, http.StatusBadRequest, "error reading request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			storeKey := scopedKey(r, key)
+			requestHash := hashRequest(r, body)
+
+			existing, err := store.Reserve(r.Context(), storeKey, requestHash, ttl)
+			if err != nil {
+				respondError(w, r, err, http.StatusInternalServerError)
+				return
+			}
+
+			if existing != nil {
+				switch {
+				case existing.RequestHash != requestHash:
+					respondProblem(w, r, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request")
+				case !existing.Completed:
+					w.Header().Set("Retry-After", "1")
+					respondProblem(w, r, http.StatusConflict, "a request with this Idempotency-Key is still being processed")
+				default:
+					replay(w, existing.Response)
+				}
+				return
+			}
+
+			// Store calls outlive a client that disconnects mid-request.
+			ctx := context.WithoutCancel(r.Context())
+			recorder := &responseRecorder{statusRecorder: newStatusRecorder(w)}
+
+			defer func() {
+				if p := recover(); p != nil {
+					store.Release(ctx, storeKey)
+					panic(p)
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			status := recorder.status
+			if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+				err = store.Release(ctx, storeKey)
+			} else {
+				err = store.Complete(ctx, storeKey, idempotency.Response{
+					Status: status,
+					Header: recorder.header,
+					Body:   recorder.body.Bytes(),
+				})
+			}
+			if err != nil {
+				// The response is already sent; a retry will be told the
+				// key is in flight until it expires.
+				slog.ErrorContext(ctx, "error storing idempotency key",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Any("error", err),
+				)
+			}
+		})
+	}
+}
+
+// scopedKey keeps callers from colliding on, or replaying, each other's keys.
+func scopedKey(r *http.Request, key string) string {
+	subject := ""
+	if principal, ok := requestctx.CurrentPrincipal(r.Context()); ok {
+		subject = principal.ID()
+	}
+
+	sum := sha256.Sum256([]byte(subject + "\n" + r.Method + " " + r.URL.Path + "\n" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.URL.RawQuery+"\n"+r.Header.Get("Content-Type")+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replay writes a stored response. Headers the outer middleware already set
+// for this request, such as its request ID, are kept.
+func replay(w http.ResponseWriter, response idempotency.Response) {
+	for name, values := range response.Header {
+		if _, ok := w.Header()[name]; !ok {
+			w.Header()[name] = values
+		}
+	}
+	w.Header().Set(headerReplayed, "true")
+	w.WriteHeader(response.Status)
+	w.Write(response.Body)
+}
+
+// responseRecorder keeps a copy of the response for replay.
+type responseRecorder struct {
+	*statusRecorder
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.header == nil {
+		r.header = r.Header().Clone()
+	}
+	r.statusRecorder.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.header == nil {
+		r.header = r.Header().Clone()
+	}
+	r.body.Write(b)
+	return r.statusRecorder.Write(b)
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/idempotency"
+)
+
+// countingHandler echoes the body and counts how often it ran.
+func countingHandler(calls *int, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(body)
+	})
+}
+
+func postWithKey(handler http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(headerIdempotencyKey, key)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	calls := 0
+	handler := Idempotency(idempotency.NewMemoryStore(), time.Hour)(countingHandler(&calls, http.StatusCreated))
+
+	first := postWithKey(handler, "abc", `{"name":"Movies"}`)
+	second := postWithKey(handler, "abc", `{"name":"Movies"}`)
+
+	if calls != 1 {
+		t.Fatalf("expected the handler to run once, ran %d times", calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("expected the first response again, got %d %q", second.Code, second.Body)
+	}
+	if second.Header().Get(headerReplayed) != "true" || second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected replay headers %v", second.Header())
+	}
+	if first.Header().Get(headerReplayed) != "" {
+		t.Error("expected the first response not to be marked as replayed")
+	}
+}
+
+func TestIdempotencyRejectsKeyReuseWithDifferentBody(t *testing.T) {
+	calls := 0
+	handler := Idempotency(idempotency.NewMemoryStore(), time.Hour)(countingHandler(&calls, http.StatusCreated))
+
+	postWithKey(handler, "abc", `{"name":"Movies"}`)
+	rec := postWithKey(handler, "abc", `{"name":"Series"}`)
+
+	if rec.Code != http.StatusUnprocessableEntity || calls != 1 {
+		t.Errorf("expected 422 without running the handler, got %d after %d calls", rec.Code, calls)
+	}
+}
+
+func TestIdempotencyRejectsRetryWhileInFlight(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	var retry *httptest.ResponseRecorder
+
+	var handler http.Handler
+	handler = Idempotency(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retry == nil {
+			retry = postWithKey(handler, "abc", `{}`)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	postWithKey(handler, "abc", `{}`)
+
+	if retry.Code != http.StatusConflict || retry.Header().Get("Retry-After") == "" {
+		t.Errorf("expected 409 with Retry-After, got %d", retry.Code)
+	}
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	calls := 0
+	handler := Idempotency(idempotency.NewMemoryStore(), time.Hour)(countingHandler(&calls, http.StatusServiceUnavailable))
+
+	postWithKey(handler, "abc", `{}`)
+	postWithKey(handler, "abc", `{}`)
+
+	if calls != 2 {
+		t.Errorf("expected the retry to run again, ran %d times", calls)
+	}
+}
+
+func TestIdempotencyIgnoresRequestsWithoutKey(t *testing.T) {
+	calls := 0
+	handler := Idempotency(idempotency.NewMemoryStore(), time.Hour)(countingHandler(&calls, http.StatusCreated))
+
+	postWithKey(handler, "", `{}`)
+	postWithKey(handler, "", `{}`)
+
+	if calls != 2 {
+		t.Errorf("expected both requests to run, ran %d times", calls)
+	}
+	if rec := postWithKey(handler, strings.Repeat("k", 256), `{}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an oversized key, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details document.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+func respondProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
+
+// respondError answers with err's message and logs it with the request's
+// metadata; failures the client cannot fix are logged as errors.
+func respondError(w http.ResponseWriter, r *http.Request, err error, status int) {
+	level := slog.LevelWarn
+	if status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+
+	slog.Log(r.Context(), level, "request failed",
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Int("status", status),
+		slog.Any("error", err),
+	)
+
+	http.Error(w, err.Error(), status)
+}
+
+// ConflictProblem is the problem answered when a category name or slug is
+// taken, or when a category with children is deleted.
+type ConflictProblem struct {
+	Problem
+	ExistingID string `json:"existing_id,omitempty"`
+}
+
+// respondCategoryError answers a name or slug conflict with 409, naming the
+// category that holds the value, and so a delete refused because of
+// children; any other error is answered with status.
+func respondCategoryError(w http.ResponseWriter, r *http.Request, err error, status int) {
+	if !errors.Is(err, category.ErrNameConflict) && !errors.Is(err, category.ErrSlugConflict) &&
+		!errors.Is(err, category.ErrCategoryHasChildren) {
+		respondError(w, r, err, status)
+		return
+	}
+
+	problem := ConflictProblem{Problem: Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusConflict),
+		Status:   http.StatusConflict,
+		Detail:   err.Error(),
+		Instance: r.URL.Path,
+	}}
+	var nameConflict *category.NameConflictError
+	var slugConflict *category.SlugConflictError
+	switch {
+	case errors.As(err, &nameConflict):
+		problem.ExistingID = nameConflict.ExistingID.String()
+	case errors.As(err, &slugConflict):
+		problem.ExistingID = slugConflict.ExistingID.String()
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(http.StatusConflict)
+	json.NewEncoder(w).Encode(problem)
+}
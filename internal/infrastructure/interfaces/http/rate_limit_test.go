@@ -0,0 +1,105 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/ratelimit"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func TestRateLimit(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+	handler := RateLimit(ratelimit.NewMemoryStore(), "read", limit, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	request := func(subject string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+		ctx := requestctx.WithClientIP(req.Context(), "10.0.0.1")
+		if subject != "" {
+			ctx = requestctx.WithPrincipal(ctx, requestctx.Principal{Subject: subject})
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, req.WithContext(ctx))
+		return rec
+	}
+
+	first := request("alice")
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", first.Code)
+	}
+	if first.Header().Get("RateLimit-Limit") != "2" || first.Header().Get("RateLimit-Remaining") != "1" {
+		t.Errorf("unexpected quota headers %v", first.Header())
+	}
+	if first.Header().Get("RateLimit-Policy") != "2;w=60" {
+		t.Errorf("unexpected policy %q", first.Header().Get("RateLimit-Policy"))
+	}
+
+	request("alice")
+	limited := request("alice")
+
+	if limited.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", limited.Code)
+	}
+	if limited.Header().Get("Retry-After") != "30" {
+		t.Errorf("expected Retry-After 30, got %q", limited.Header().Get("Retry-After"))
+	}
+	if limited.Header().Get("Content-Type") != problemContentType {
+		t.Errorf("expected problem document, got %q", limited.Header().Get("Content-Type"))
+	}
+
+	if rec := request("bob"); rec.Code != http.StatusOK {
+		t.Errorf("expected another subject from the same IP to have its own quota, got %d", rec.Code)
+	}
+	if rec := request(""); rec.Code != http.StatusOK {
+		t.Errorf("expected anonymous client to be keyed by IP, got %d", rec.Code)
+	}
+}
+
+func TestRateLimit_FailsOpen(t *testing.T) {
+	called := false
+	handler := RateLimit(failingStore{}, "read", ratelimit.Limit{Requests: 1, Period: time.Second}, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/categories", nil))
+
+	if !called {
+		t.Error("expected request to pass when the store fails")
+	}
+}
+
+func TestRateLimitByIP_LimitsRejectedCredentials(t *testing.T) {
+	rejectAll := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	handler := RateLimitByIP(ratelimit.NewMemoryStore(), ratelimit.Limit{Requests: 2, Period: time.Minute})(rejectAll)
+
+	request := func(ip string) int {
+		req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req.WithContext(requestctx.WithClientIP(req.Context(), ip)))
+		return rec.Code
+	}
+
+	request("10.0.0.1")
+	request("10.0.0.1")
+
+	if code := request("10.0.0.1"); code != http.StatusTooManyRequests {
+		t.Errorf("expected failed logins to use up the IP's quota, got %d", code)
+	}
+	if code := request("10.0.0.2"); code != http.StatusUnauthorized {
+		t.Errorf("expected another IP to have its own quota, got %d", code)
+	}
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+)
+
+const HeaderRequestID = "X-Request-ID"
+
+// maxRequestIDLength keeps caller-supplied IDs from bloating every log line.
+const maxRequestIDLength = 128
+
+// RequestContext copies per-request metadata from the incoming request into
+// its context so use cases can attribute the changes they make. Requests
+// without a usable X-Request-ID get a generated one, echoed in the response.
+func RequestContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(HeaderRequestID)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = newRequestID()
+		}
+
+		w.Header().Set(HeaderRequestID, requestID)
+
+		ctx := requestctx.WithRequestID(r.Context(), requestID)
+		ctx = requestctx.WithClientIP(ctx, clientIP(r))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func newRequestID() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		panic(err)
+	}
+	return id.String()
+}
+
+// clientIP is the address of the peer connection. Forwarding headers are not
+// trusted, since any client can set them.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
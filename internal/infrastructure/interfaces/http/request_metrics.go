@@ -0,0 +1,25 @@
+package http
+
+import (
+	"net/http"
+	"time"
+)
+
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+// RequestMetrics reports every request to observer, labelled by route
+// pattern rather than path.
+func RequestMetrics(observer RequestObserver, routes RouteMatcher) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := newStatusRecorder(w)
+
+			next.ServeHTTP(recorder, r)
+
+			observer.ObserveRequest(r.Method, routePattern(routes, r), recorder.status, time.Since(start))
+		})
+	}
+}
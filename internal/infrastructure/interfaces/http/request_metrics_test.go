@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type observedRequest struct {
+	method, route string
+	status        int
+}
+
+type requestObserverFunc func(method, route string, status int, duration time.Duration)
+
+func (f requestObserverFunc) ObserveRequest(method, route string, status int, duration time.Duration) {
+	f(method, route, status, duration)
+}
+
+func TestRequestMetrics(t *testing.T) {
+	var observed []observedRequest
+	observer := requestObserverFunc(func(method, route string, status int, duration time.Duration) {
+		observed = append(observed, observedRequest{method, route, status})
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /categories/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	handler := RequestMetrics(observer, mux)(mux)
+
+	for _, path := range []string{"/categories/1", "/categories/2", "/unknown"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, path, nil))
+	}
+
+	expected := []observedRequest{
+		{"DELETE", "DELETE /categories/{id}", http.StatusNoContent},
+		{"DELETE", "DELETE /categories/{id}", http.StatusNoContent},
+		{"DELETE", "unmatched", http.StatusNotFound},
+	}
+
+	if len(observed) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, observed)
+	}
+	for i := range expected {
+		if observed[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], observed[i])
+		}
+	}
+}
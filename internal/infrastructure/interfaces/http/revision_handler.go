@@ -0,0 +1,124 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/category/revision"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+)
+
+type RevisionHandler struct {
+	ListUC   *revision.ListRevisionsUseCase
+	GetUC    *revision.GetRevisionUseCase
+	RevertUC *revision.RevertCategoryUseCase
+}
+
+func NewRevisionHandler(
+	listUC *revision.ListRevisionsUseCase,
+	getUC *revision.GetRevisionUseCase,
+	revertUC *revision.RevertCategoryUseCase,
+) *RevisionHandler {
+	return &RevisionHandler{
+		ListUC:   listUC,
+		GetUC:    getUC,
+		RevertUC: revertUC,
+	}
+}
+
+func (h *RevisionHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	output, err := h.ListUC.Execute(r.Context(), revision.ListRevisionsInput{
+		CategoryID: r.PathValue("id"),
+		Page:       parseInt(query.Get("page"), 1),
+		PerPage:    parseInt(query.Get("per_page"), 10),
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, output)
+}
+
+func (h *RevisionHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.Atoi(r.PathValue("revision"))
+	if err != nil || number < 1 {
+		http.Error(w, "invalid revision number", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.GetUC.Execute(r.Context(), revision.GetRevisionInput{
+		CategoryID: r.PathValue("id"),
+		Revision:   number,
+	})
+
+	if err != nil {
+		respondCategoryError(w, r, err, revisionErrorStatus(err))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, output)
+}
+
+// GetAsOf accepts either ?revision=N or ?at=<RFC 3339 timestamp>.
+func (h *RevisionHandler) GetAsOf(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	input := revision.GetRevisionInput{
+		CategoryID: r.PathValue("id"),
+		Revision:   parseInt(query.Get("revision"), 0),
+	}
+
+	if at := query.Get("at"); at != "" {
+		parsed, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			http.Error(w, "invalid timestamp, expected RFC 3339", http.StatusBadRequest)
+			return
+		}
+		input.At = parsed
+	}
+
+	output, err := h.GetUC.Execute(r.Context(), input)
+
+	if err != nil {
+		respondError(w, r, err, revisionErrorStatus(err))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, output)
+}
+
+func (h *RevisionHandler) Revert(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.Atoi(r.PathValue("revision"))
+	if err != nil || number < 1 {
+		http.Error(w, "invalid revision number", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.RevertUC.Execute(r.Context(), revision.RevertCategoryInput{
+		CategoryID: r.PathValue("id"),
+		Revision:   number,
+	})
+
+	if err != nil {
+		respondCategoryError(w, r, err, revisionErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/categories/%s", output.ID))
+
+	respondJSON(w, http.StatusOK, output)
+}
+
+func revisionErrorStatus(err error) int {
+	if errors.Is(err, category.ErrRevisionNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,29 @@
+package http
+
+import "net/http"
+
+// RouteSet serves each request from the first mux with a pattern matching
+// it. Patterns net/http rejects as conflicting, like
+// "GET /categories/by-slug/{slug}" beside "GET /categories/{id}/history",
+// can live on separate muxes, the more specific one first. It implements
+// RouteMatcher.
+type RouteSet []*http.ServeMux
+
+func (s RouteSet) match(r *http.Request) *http.ServeMux {
+	for _, mux := range s {
+		if _, pattern := mux.Handler(r); pattern != "" {
+			return mux
+		}
+	}
+	return s[len(s)-1]
+}
+
+func (s RouteSet) Handler(r *http.Request) (http.Handler, string) {
+	return s.match(r).Handler(r)
+}
+
+// ServeHTTP lets the matching mux serve the request, so path values are
+// set as usual.
+func (s RouteSet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.match(r).ServeHTTP(w, r)
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing opens a server span per request, continuing the caller's trace
+// when a W3C traceparent header is present. Spans are named after the route
+// pattern so traces group the same way metrics do.
+func Tracing(tracer trace.Tracer, propagator propagation.TextMapPropagator, routes RouteMatcher) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			route := routePattern(routes, r)
+			ctx, span := tracer.Start(ctx, route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("http.route", route),
+					attribute.String("url.path", r.URL.Path),
+					attribute.String("client.address", requestctx.ClientIP(r.Context())),
+					attribute.String("request.id", requestctx.RequestID(r.Context())),
+				),
+			)
+			defer span.End()
+
+			recorder := newStatusRecorder(w)
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+
+			span.SetAttributes(attribute.Int("http.response.status_code", recorder.status))
+			if recorder.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(recorder.status))
+			}
+		})
+	}
+}
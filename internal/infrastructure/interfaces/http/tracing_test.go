@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+
+	var handlerSpan trace.SpanContext
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /categories/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, child := tracer.Start(r.Context(), "usecase get_category_by_id")
+		child.End()
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+
+	handler := Tracing(tracer, propagation.TraceContext{}, mux)(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/categories/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	child, server := spans[0], spans[1]
+	if server.Name() != "GET /categories/{id}" {
+		t.Errorf("expected span named after the route, got %q", server.Name())
+	}
+	if server.SpanKind() != trace.SpanKindServer {
+		t.Errorf("expected server span, got %v", server.SpanKind())
+	}
+	if got := server.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected trace ID from traceparent, got %s", got)
+	}
+	if got := server.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("expected remote parent span, got %s", got)
+	}
+	if handlerSpan.SpanID() != server.SpanContext().SpanID() {
+		t.Error("expected the handler context to carry the server span")
+	}
+	if child.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Error("expected the use case span to be a child of the server span")
+	}
+	if server.Status().Code != codes.Error {
+		t.Errorf("expected error status for 500, got %v", server.Status().Code)
+	}
+
+	var status int64
+	for _, kv := range server.Attributes() {
+		if kv.Key == "http.response.status_code" {
+			status = kv.Value.AsInt64()
+		}
+	}
+	if status != http.StatusInternalServerError {
+		t.Errorf("expected status attribute 500, got %d", status)
+	}
+}
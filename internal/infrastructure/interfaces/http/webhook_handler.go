@@ -0,0 +1,181 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/create"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/delete"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/deliver"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/retrive"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/update"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+type WebhookHandler struct {
+	CreateUC         *create.CreateSubscriptionUseCase
+	UpdateUC         *update.UpdateSubscriptionUseCase
+	DeleteUC         *delete.DeleteSubscriptionUseCase
+	GetByIDUC        *retrive.GetSubscriptionByIDUseCase
+	ListUC           *retrive.ListSubscriptionsUseCase
+	ListDeliveriesUC *retrive.ListDeliveriesUseCase
+	RedeliverUC      *deliver.RedeliverUseCase
+}
+
+func NewWebhookHandler(
+	createUC *create.CreateSubscriptionUseCase,
+	updateUC *update.UpdateSubscriptionUseCase,
+	deleteUC *delete.DeleteSubscriptionUseCase,
+	getByIDUC *retrive.GetSubscriptionByIDUseCase,
+	listUC *retrive.ListSubscriptionsUseCase,
+	listDeliveriesUC *retrive.ListDeliveriesUseCase,
+	redeliverUC *deliver.RedeliverUseCase,
+) *WebhookHandler {
+	return &WebhookHandler{
+		CreateUC:         createUC,
+		UpdateUC:         updateUC,
+		DeleteUC:         deleteUC,
+		GetByIDUC:        getByIDUC,
+		ListUC:           listUC,
+		ListDeliveriesUC: listDeliveriesUC,
+		RedeliverUC:      redeliverUC,
+	}
+}
+
+type SubscriptionRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+	IsActive   bool     `json:"is_active"`
+}
+
+func (h *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req SubscriptionRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.CreateUC.Execute(r.Context(), create.CreateSubscriptionInput{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+		IsActive:   req.IsActive,
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	location := fmt.Sprintf("/webhooks/%s", output.ID)
+	w.Header().Set("Location", location)
+
+	respondJSON(w, http.StatusCreated, output)
+}
+
+func (h *WebhookHandler) GetSubscriptionByID(w http.ResponseWriter, r *http.Request) {
+	output, err := h.GetByIDUC.Execute(r.Context(), retrive.GetSubscriptionByIDInput{
+		ID: r.PathValue("id"),
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, output)
+}
+
+func (h *WebhookHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req SubscriptionRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.UpdateUC.Execute(r.Context(), update.UpdateSubscriptionInput{
+		ID:         r.PathValue("id"),
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+		IsActive:   req.IsActive,
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, output)
+}
+
+func (h *WebhookHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	err := h.DeleteUC.Execute(r.Context(), delete.DeleteSubscriptionInput{
+		ID: r.PathValue("id"),
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	output, err := h.ListUC.Execute(r.Context(), retrive.ListSubscriptionsInput{
+		Page:    parseInt(query.Get("page"), 1),
+		PerPage: parseInt(query.Get("per_page"), 10),
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, output)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	output, err := h.ListDeliveriesUC.Execute(r.Context(), retrive.ListDeliveriesInput{
+		SubscriptionID: r.PathValue("id"),
+		Status:         query.Get("status"),
+		Page:           parseInt(query.Get("page"), 1),
+		PerPage:        parseInt(query.Get("per_page"), 10),
+	})
+
+	if err != nil {
+		respondError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, output)
+}
+
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	output, err := h.RedeliverUC.Execute(r.Context(), deliver.RedeliverInput{
+		SubscriptionID: r.PathValue("id"),
+		DeliveryID:     r.PathValue("deliveryID"),
+	})
+
+	if errors.Is(err, webhook.ErrDeliveryPending) {
+		respondError(w, r, err, http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		respondError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, output)
+}
@@ -0,0 +1,63 @@
+// Package logging builds the application's structured logger.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Config struct {
+	Level  slog.Level
+	Format string
+}
+
+// New returns a logger writing to w in the configured format. Records logged
+// with a context carry that request's ID and, when traced, its trace and
+// span IDs.
+func New(cfg Config, w io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{Level: cfg.Level}
+
+	var handler slog.Handler
+	if cfg.Format == FormatText {
+		handler = slog.NewTextHandler(w, options)
+	} else {
+		handler = slog.NewJSONHandler(w, options)
+	}
+
+	return slog.New(contextHandler{Handler: handler})
+}
+
+// contextHandler adds request metadata from the context to every record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := requestctx.RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", span.TraceID().String()),
+			slog.String("span_id", span.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
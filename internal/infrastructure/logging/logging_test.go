@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/requestctx"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew_AddsRequestIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Level: slog.LevelInfo, Format: FormatJSON}, &buf)
+
+	ctx := requestctx.WithRequestID(context.Background(), "req-123")
+	logger.With("component", "test").ErrorContext(ctx, "gateway failed", "error", "boom")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected JSON output, got %q", buf.String())
+	}
+
+	if record["request_id"] != "req-123" || record["component"] != "test" || record["msg"] != "gateway failed" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestNew_AddsTraceIDsFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Level: slog.LevelInfo, Format: FormatJSON}, &buf)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+	logger.InfoContext(ctx, "traced")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected JSON output, got %q", buf.String())
+	}
+
+	if record["trace_id"] != traceID.String() || record["span_id"] != spanID.String() {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestNew_RespectsLevelAndFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Level: slog.LevelWarn, Format: FormatText}, &buf)
+
+	logger.Info("hidden")
+	logger.Warn("shown")
+
+	if strings.Contains(buf.String(), "hidden") {
+		t.Error("expected info record to be filtered out")
+	}
+	if !strings.Contains(buf.String(), "level=WARN msg=shown") {
+		t.Errorf("expected text output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,100 @@
+// Package metrics exposes Prometheus metrics for HTTP requests, use cases
+// and the database pool.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "catalog"
+
+// Metrics owns its registry instead of using the global one, so tests can
+// build and scrape an isolated instance.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	useCaseDuration *prometheus.HistogramVec
+	useCaseErrors   *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by method, route pattern and status.",
+		}, []string{"method", "route", "status"}),
+
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time spent serving HTTP requests, by method, route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+
+		useCaseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "use_case_duration_seconds",
+			Help:      "Time spent executing use cases.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"use_case"}),
+
+		useCaseErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "use_case_errors_total",
+			Help:      "Use case executions that returned an error.",
+		}, []string{"use_case"}),
+	}
+
+	m.Registry.MustRegister(
+		m.httpRequests,
+		m.httpDuration,
+		m.useCaseDuration,
+		m.useCaseErrors,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// RegisterDB exports the pool statistics of db (open, in use, idle
+// connections, waits and their duration) under the given name.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.Registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry})
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+
+	m.httpRequests.With(labels).Inc()
+	m.httpDuration.With(labels).Observe(duration.Seconds())
+}
+
+// Start implements usecase.Observer.
+func (m *Metrics) Start(ctx context.Context, name string) (context.Context, func(err error)) {
+	start := time.Now()
+
+	return ctx, func(err error) {
+		m.useCaseDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		if err != nil {
+			m.useCaseErrors.WithLabelValues(name).Inc()
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics_HTTPRequests(t *testing.T) {
+	m := New()
+
+	m.ObserveRequest("GET", "GET /categories", 200, 20*time.Millisecond)
+	m.ObserveRequest("GET", "GET /categories", 200, 30*time.Millisecond)
+	m.ObserveRequest("DELETE", "DELETE /categories/{id}", 403, time.Millisecond)
+
+	if got := testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "GET /categories", "200")); got != 2 {
+		t.Errorf("expected 2 requests, got %v", got)
+	}
+	if got := testutil.CollectAndCount(m.httpDuration); got != 2 {
+		t.Errorf("expected 2 duration series, got %d", got)
+	}
+}
+
+func TestMetrics_UseCases(t *testing.T) {
+	m := New()
+
+	_, end := m.Start(context.Background(), "create_category")
+	end(nil)
+	_, end = m.Start(context.Background(), "create_category")
+	end(errors.New("validation failed"))
+
+	if got := testutil.ToFloat64(m.useCaseErrors.WithLabelValues("create_category")); got != 1 {
+		t.Errorf("expected 1 error, got %v", got)
+	}
+
+	expected := `
+		# HELP catalog_use_case_errors_total Use case executions that returned an error.
+		# TYPE catalog_use_case_errors_total counter
+		catalog_use_case_errors_total{use_case="create_category"} 1
+	`
+	if err := testutil.CollectAndCompare(m.useCaseErrors, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMetrics_HandlerExposesTextFormat(t *testing.T) {
+	m := New()
+
+	// Opening does not connect, so the pool stats are available without MySQL.
+	db, err := sql.Open("mysql", "user:pass@tcp(127.0.0.1:1)/catalog")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	m.RegisterDB(db, "catalog")
+	m.ObserveRequest("GET", "GET /categories", 200, time.Millisecond)
+
+	server := httptest.NewServer(m.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	for _, expected := range []string{
+		`catalog_http_requests_total{method="GET",route="GET /categories",status="200"} 1`,
+		`catalog_http_request_duration_seconds_bucket{method="GET",route="GET /categories",status="200",le="0.005"} 1`,
+		`go_sql_open_connections{db_name="catalog"} 0`,
+		`go_sql_in_use_connections{db_name="catalog"} 0`,
+		`go_sql_idle_connections{db_name="catalog"} 0`,
+		`go_sql_wait_count_total{db_name="catalog"} 0`,
+		`go_sql_wait_duration_seconds_total{db_name="catalog"} 0`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected %q in scrape output", expected)
+		}
+	}
+}
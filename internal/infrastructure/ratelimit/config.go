@@ -0,0 +1,17 @@
+package ratelimit
+
+// Route groups share a limit; each client gets its own bucket per group.
+const (
+	GroupRead  = "read"
+	GroupWrite = "write"
+	GroupAdmin = "admin"
+)
+
+// GroupIP limits each client IP across every route, ahead of
+// authentication.
+const GroupIP = "ip"
+
+type Config struct {
+	Enabled bool
+	Groups  map[string]Limit
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestStore(now *time.Time) *MemoryStore {
+	store := NewMemoryStore()
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestMemoryStore_BurstThenRefill(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newTestStore(&now)
+	limit := Limit{Requests: 3, Period: 3 * time.Second}
+
+	for i := 2; i >= 0; i-- {
+		result, _ := store.Take(context.Background(), "client", limit)
+		if !result.Allowed || result.Remaining != i {
+			t.Fatalf("expected allowed with %d remaining, got %+v", i, result)
+		}
+	}
+
+	result, _ := store.Take(context.Background(), "client", limit)
+	if result.Allowed {
+		t.Fatal("expected bucket to be exhausted")
+	}
+	if result.RetryAfter != time.Second {
+		t.Errorf("expected retry after 1s, got %s", result.RetryAfter)
+	}
+	if result.Reset != 3*time.Second {
+		t.Errorf("expected reset in 3s, got %s", result.Reset)
+	}
+
+	now = now.Add(time.Second)
+
+	result, _ = store.Take(context.Background(), "client", limit)
+	if !result.Allowed || result.Remaining != 0 {
+		t.Errorf("expected one refilled token, got %+v", result)
+	}
+}
+
+func TestMemoryStore_KeysAreIndependent(t *testing.T) {
+	now := time.Now()
+	store := newTestStore(&now)
+	limit := Limit{Requests: 1, Period: time.Minute}
+
+	store.Take(context.Background(), "read:alice", limit)
+
+	if result, _ := store.Take(context.Background(), "read:bob", limit); !result.Allowed {
+		t.Error("expected another client to have its own bucket")
+	}
+	if result, _ := store.Take(context.Background(), "read:alice", limit); result.Allowed {
+		t.Error("expected alice to be limited")
+	}
+}
+
+func TestMemoryStore_SweepsFullBuckets(t *testing.T) {
+	now := time.Now()
+	store := newTestStore(&now)
+	limit := Limit{Requests: 10, Period: time.Second}
+
+	store.Take(context.Background(), "idle", limit)
+	now = now.Add(time.Minute)
+	store.sweep(now)
+
+	if size := store.size(); size != 0 {
+		t.Errorf("expected idle bucket to be swept, %d left", size)
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit("100/1m")
+	if err != nil || limit != (Limit{Requests: 100, Period: time.Minute}) {
+		t.Errorf("unexpected limit %+v, err %v", limit, err)
+	}
+
+	for _, invalid := range []string{"", "100", "0/1m", "abc/1m", "10/forever", "10/-1s"} {
+		if _, err := ParseLimit(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
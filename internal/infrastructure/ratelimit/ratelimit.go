@@ -0,0 +1,63 @@
+// Package ratelimit implements token-bucket rate limiting.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit allows Requests per Period, refilled continuously, with bursts of up
+// to Requests.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseLimit reads limits written as "<requests>/<period>", e.g. "100/1m".
+func ParseLimit(value string) (Limit, error) {
+	rawRequests, rawPeriod, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<period>", value)
+	}
+
+	requests, err := strconv.Atoi(rawRequests)
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", value)
+	}
+
+	period, err := time.ParseDuration(rawPeriod)
+	if err != nil || period <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: period must be a positive duration", value)
+	}
+
+	return Limit{Requests: requests, Period: period}, nil
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// rate is the number of tokens added per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until the next request would be allowed; zero
+	// when this one was.
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. MemoryStore serves a single instance; a shared
+// implementation (e.g. Redis) can be plugged in for several replicas.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
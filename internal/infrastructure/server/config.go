@@ -0,0 +1,18 @@
+package server
+
+import "time"
+
+type Config struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// ReadinessDelay is how long the server keeps accepting requests once
+	// shutdown starts, so that load balancers notice the failing readiness
+	// check before connections are refused.
+	ReadinessDelay time.Duration
+	// ShutdownTimeout bounds how long in-flight requests may take to finish
+	// once shutdown starts.
+	ShutdownTimeout time.Duration
+}
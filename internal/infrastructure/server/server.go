@@ -0,0 +1,94 @@
+// Package server runs the HTTP server and shuts it down gracefully.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Server struct {
+	HTTP            *http.Server
+	ReadinessDelay  time.Duration
+	ShutdownTimeout time.Duration
+
+	onShutdown []func()
+}
+
+func New(cfg Config, handler http.Handler) *Server {
+	return &Server{
+		HTTP: &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           handler,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+		},
+		ReadinessDelay:  cfg.ReadinessDelay,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+	}
+}
+
+// OnShutdown registers f to run as soon as shutdown begins, before
+// ReadinessDelay and the drain of in-flight requests; e.g. failing readiness
+// or ending long-lived streams.
+func (s *Server) OnShutdown(f func()) {
+	s.onShutdown = append(s.onShutdown, f)
+}
+
+// ListenAndServe listens on the configured address and serves until ctx is
+// cancelled; see Serve.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.HTTP.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve accepts connections on ln until ctx is cancelled. It then runs the
+// OnShutdown functions, keeps serving for ReadinessDelay, stops accepting and
+// waits up to ShutdownTimeout for in-flight requests. Requests still running
+// after that are cut off and an error is returned.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.HTTP.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	for _, f := range s.onShutdown {
+		f()
+	}
+
+	if s.ReadinessDelay > 0 {
+		time.Sleep(s.ReadinessDelay)
+	}
+
+	shutdownCtx := context.Background()
+	if s.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, s.ShutdownTimeout)
+		defer cancel()
+	}
+
+	if err := s.HTTP.Shutdown(shutdownCtx); err != nil {
+		s.HTTP.Close()
+		<-serveErr
+		return fmt.Errorf("draining requests: %w", err)
+	}
+
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
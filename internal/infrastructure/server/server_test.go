@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func listen(t *testing.T) net.Listener {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ln
+}
+
+func TestServeCompletesInFlightRequestDuringShutdown(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		io.WriteString(w, "done")
+	})
+
+	srv := New(Config{ShutdownTimeout: 5 * time.Second}, handler)
+	shutdownStarted := make(chan struct{})
+	srv.OnShutdown(func() { close(shutdownStarted) })
+
+	ln := listen(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ctx, ln) }()
+
+	type result struct {
+		body string
+		err  error
+	}
+	response := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			response <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		response <- result{body: string(body), err: err}
+	}()
+
+	<-started
+	cancel()
+	<-shutdownStarted
+
+	got := <-response
+	if got.err != nil || got.body != "done" {
+		t.Fatalf("expected in-flight request to complete, got %q, %v", got.body, got.err)
+	}
+
+	if err := <-served; err != nil {
+		t.Fatalf("expected clean shutdown, got %v", err)
+	}
+
+	if _, err := http.Get("http://" + ln.Addr().String()); err == nil {
+		t.Error("expected new connections to be refused after shutdown")
+	}
+}
+
+func TestServeGivesUpAfterShutdownTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+
+	srv := New(Config{ShutdownTimeout: 50 * time.Millisecond}, handler)
+
+	ln := listen(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ctx, ln) }()
+
+	go http.Get("http://" + ln.Addr().String())
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-served:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected Serve to return after the shutdown timeout")
+	}
+}
+
+func TestServeKeepsServingForReadinessDelay(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})
+
+	srv := New(Config{ReadinessDelay: 300 * time.Millisecond, ShutdownTimeout: time.Second}, handler)
+	shutdownStarted := make(chan time.Time, 1)
+	srv.OnShutdown(func() { shutdownStarted <- time.Now() })
+
+	ln := listen(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ctx, ln) }()
+
+	cancel()
+	started := <-shutdownStarted
+
+	resp, err := http.Get("http://" + ln.Addr().String())
+	if err != nil {
+		t.Fatalf("expected requests to be served during the readiness delay, got %v", err)
+	}
+	resp.Body.Close()
+
+	if err := <-served; err != nil {
+		t.Fatalf("expected clean shutdown, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed < 300*time.Millisecond {
+		t.Errorf("expected the drain to wait for the readiness delay, stopped after %s", elapsed)
+	}
+
+	if _, err := http.Get("http://" + ln.Addr().String()); err == nil {
+		t.Error("expected new connections to be refused after the delay")
+	}
+}
@@ -0,0 +1,100 @@
+// Package tracing configures OpenTelemetry trace export and records spans
+// for use case executions.
+package tracing
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Name identifies this application's instrumentation scope.
+const Name = "github.com/renamrgb/code-flix-admin-catalog"
+
+// Config selects the exporter. The OTLP exporter reads its endpoint, headers
+// and TLS settings from the standard OTEL_EXPORTER_OTLP_* variables.
+type Config struct {
+	Exporter    string
+	ServiceName string
+	SampleRatio float64
+}
+
+// NewTracerProvider builds a provider exporting through the configured
+// exporter; stdout spans are written to w. With ExporterNone spans are still
+// created, so trace context keeps propagating, but nothing is exported.
+// Callers must Shutdown the provider to flush pending spans.
+func NewTracerProvider(ctx context.Context, cfg Config, w io.Writer) (*sdktrace.TracerProvider, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+
+	switch cfg.Exporter {
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// Propagator reads and writes W3C traceparent/tracestate and baggage
+// headers.
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// UseCaseObserver opens a span around every use case execution. It is
+// registered with usecase.AddObserver.
+type UseCaseObserver struct {
+	Tracer trace.Tracer
+}
+
+func NewUseCaseObserver(provider trace.TracerProvider) *UseCaseObserver {
+	return &UseCaseObserver{Tracer: provider.Tracer(Name)}
+}
+
+func (o *UseCaseObserver) Start(ctx context.Context, name string) (context.Context, func(error)) {
+	ctx, span := o.Tracer.Start(ctx, "usecase "+name,
+		trace.WithAttributes(attribute.String("use_case", name)),
+	)
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/usecase"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestUseCaseObserverRecordsSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	usecase.AddObserver(NewUseCaseObserver(provider))
+	t.Cleanup(usecase.ResetObservers)
+
+	ctx, end := usecase.Observe(context.Background(), "create_category")
+	_, innerEnd := usecase.Observe(ctx, "record_audit_entry")
+	innerEnd(errors.New("boom"))
+	end(nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	child, parent := spans[0], spans[1]
+	if parent.Name() != "usecase create_category" {
+		t.Errorf("unexpected span name %q", parent.Name())
+	}
+	if child.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("expected nested use case span to be a child")
+	}
+	if child.Status().Code != codes.Error || parent.Status().Code == codes.Error {
+		t.Errorf("unexpected statuses: child %v, parent %v", child.Status().Code, parent.Status().Code)
+	}
+}
+
+func TestNewTracerProviderWritesStdoutSpans(t *testing.T) {
+	var out bytes.Buffer
+	provider, err := NewTracerProvider(context.Background(), Config{
+		Exporter:    ExporterStdout,
+		ServiceName: "test",
+		SampleRatio: 1,
+	}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := provider.Tracer(Name).Start(context.Background(), "hello")
+	span.End()
+
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte(`"Name":"hello"`)) {
+		t.Errorf("expected exported span in output, got %s", out.String())
+	}
+}
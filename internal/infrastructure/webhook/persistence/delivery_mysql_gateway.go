@@ -0,0 +1,270 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+)
+
+type MySQLDeliveryGateway struct {
+	DB *sql.DB
+}
+
+func NewMySQLDeliveryGateway(db *sql.DB) *MySQLDeliveryGateway {
+	return &MySQLDeliveryGateway{DB: db}
+}
+
+const deliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts,
+	attempt_offset, response_status, last_error, next_attempt_at, last_attempt_at, created_at, updated_at`
+
+func (g *MySQLDeliveryGateway) CreateDelivery(ctx context.Context, d *webhook.Delivery) (*webhook.Delivery, error) {
+	query := `
+		INSERT INTO webhook_deliveries (` + deliveryColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
+		query,
+		d.ID.String(),
+		d.SubscriptionID.String(),
+		d.EventID,
+		string(d.EventType),
+		d.Payload,
+		string(d.Status),
+		d.Attempts,
+		d.AttemptOffset,
+		nullInt(d.ResponseStatus),
+		nullString(d.LastError),
+		nullTime(d.NextAttemptAt),
+		nullTime(d.LastAttemptAt),
+		d.CreatedAt,
+		d.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (g *MySQLDeliveryGateway) GetDeliveryByID(ctx context.Context, id webhook.DeliveryID) (*webhook.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = ?`
+
+	return scanDelivery(mysql.Conn(ctx, g.DB).QueryRowContext(ctx, query, id.String()))
+}
+
+func (g *MySQLDeliveryGateway) UpdateDelivery(ctx context.Context, d *webhook.Delivery) (*webhook.Delivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, attempt_offset = ?, response_status = ?, last_error = ?,
+			next_attempt_at = ?, last_attempt_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	_, err := mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
+		query,
+		string(d.Status),
+		d.Attempts,
+		d.AttemptOffset,
+		nullInt(d.ResponseStatus),
+		nullString(d.LastError),
+		nullTime(d.NextAttemptAt),
+		nullTime(d.LastAttemptAt),
+		d.UpdatedAt,
+		d.ID.String(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (g *MySQLDeliveryGateway) FindBySubscription(ctx context.Context, query webhook.SearchDeliveryQuery) (*pagination.Pagination[webhook.Delivery], error) {
+	offset := (query.Page - 1) * query.PerPage
+
+	whereClause := "WHERE subscription_id = ?"
+	args := []any{query.SubscriptionID.String()}
+
+	if query.Status != "" {
+		whereClause += " AND status = ?"
+		args = append(args, string(query.Status))
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM webhook_deliveries %s`, whereClause)
+	if err := mysql.Conn(ctx, g.DB).QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	searchQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM webhook_deliveries
+		%s
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`, deliveryColumns, whereClause)
+
+	args = append(args, query.PerPage, offset)
+
+	rows, err := mysql.Conn(ctx, g.DB).QueryContext(ctx, searchQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries, err := scanDeliveries(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pagination.Pagination[webhook.Delivery]{
+		CurrentPage: query.Page,
+		PerPage:     query.PerPage,
+		Total:       total,
+		Items:       deliveries,
+	}, nil
+}
+
+// ClaimDue locks the due rows with SKIP LOCKED, so concurrent workers claim
+// disjoint batches, and pushes their next attempt past the lease before the
+// transaction commits.
+func (g *MySQLDeliveryGateway) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) (claimed []webhook.Delivery, err error) {
+	err = mysql.NewTransactor(g.DB).WithinTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			SELECT ` + deliveryColumns + `
+			FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		`
+
+		rows, err := mysql.Conn(ctx, g.DB).QueryContext(ctx, query, string(webhook.DeliveryPending), now, limit)
+		if err != nil {
+			return err
+		}
+
+		deliveries, err := scanDeliveries(rows)
+		rows.Close()
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		leaseUntil := now.Add(lease)
+		args := make([]any, 0, len(deliveries)+1)
+		args = append(args, leaseUntil)
+		for i := range deliveries {
+			deliveries[i].NextAttemptAt = leaseUntil
+			args = append(args, deliveries[i].ID.String())
+		}
+
+		update := `UPDATE webhook_deliveries SET next_attempt_at = ? WHERE id IN (?` +
+			strings.Repeat(", ?", len(deliveries)-1) + `)`
+		if _, err := mysql.Conn(ctx, g.DB).ExecContext(ctx, update, args...); err != nil {
+			return err
+		}
+
+		claimed = deliveries
+		return nil
+	})
+
+	return claimed, err
+}
+
+func scanDelivery(row rowScanner) (*webhook.Delivery, error) {
+	var d webhook.Delivery
+	var id, subscriptionID, eventType, status string
+	var responseStatus sql.NullInt64
+	var lastError sql.NullString
+	var nextAttemptAt, lastAttemptAt sql.NullTime
+
+	err := row.Scan(
+		&id,
+		&subscriptionID,
+		&d.EventID,
+		&eventType,
+		&d.Payload,
+		&status,
+		&d.Attempts,
+		&d.AttemptOffset,
+		&responseStatus,
+		&lastError,
+		&nextAttemptAt,
+		&lastAttemptAt,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.ID, err = webhook.ParseDeliveryID(id); err != nil {
+		return nil, err
+	}
+
+	if d.SubscriptionID, err = webhook.ParseSubscriptionID(subscriptionID); err != nil {
+		return nil, err
+	}
+
+	d.EventType = category.EventType(eventType)
+	d.Status = webhook.DeliveryStatus(status)
+	d.ResponseStatus = int(responseStatus.Int64)
+	d.LastError = lastError.String
+
+	if nextAttemptAt.Valid {
+		d.NextAttemptAt = nextAttemptAt.Time
+	}
+
+	if lastAttemptAt.Valid {
+		d.LastAttemptAt = lastAttemptAt.Time
+	}
+
+	return &d, nil
+}
+
+func scanDeliveries(rows *sql.Rows) ([]webhook.Delivery, error) {
+	var deliveries []webhook.Delivery
+
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func nullInt(v int) any {
+	if v == 0 {
+		return nil
+	}
+	return v
+}
+
+func nullString(v string) any {
+	if v == "" {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,196 @@
+// Package persistence provides MySQL gateway implementations for webhook subscriptions and deliveries.
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/pagination"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/infrastructure/database/mysql"
+)
+
+type MySQLSubscriptionGateway struct {
+	DB *sql.DB
+}
+
+func NewMySQLSubscriptionGateway(db *sql.DB) *MySQLSubscriptionGateway {
+	return &MySQLSubscriptionGateway{DB: db}
+}
+
+const subscriptionColumns = `id, url, event_types, secret, activated, created_at, updated_at`
+
+func (g *MySQLSubscriptionGateway) CreateSubscription(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	query := `
+		INSERT INTO webhook_subscriptions (id, url, event_types, secret, activated, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
+		query,
+		sub.ID.String(),
+		sub.URL,
+		joinEventTypes(sub.EventTypes),
+		sub.Secret,
+		sub.IsActive,
+		sub.CreatedAt,
+		sub.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (g *MySQLSubscriptionGateway) GetSubscriptionByID(ctx context.Context, id webhook.SubscriptionID) (*webhook.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions WHERE id = ?`
+
+	return scanSubscription(mysql.Conn(ctx, g.DB).QueryRowContext(ctx, query, id.String()))
+}
+
+func (g *MySQLSubscriptionGateway) UpdateSubscription(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = ?, event_types = ?, secret = ?, activated = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	_, err := mysql.Conn(ctx, g.DB).ExecContext(
+		ctx,
+		query,
+		sub.URL,
+		joinEventTypes(sub.EventTypes),
+		sub.Secret,
+		sub.IsActive,
+		sub.UpdatedAt,
+		sub.ID.String(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (g *MySQLSubscriptionGateway) DeleteSubscription(ctx context.Context, id webhook.SubscriptionID) error {
+	query := `DELETE FROM webhook_subscriptions WHERE id = ?`
+	_, err := mysql.Conn(ctx, g.DB).ExecContext(ctx, query, id.String())
+	return err
+}
+
+func (g *MySQLSubscriptionGateway) FindAll(ctx context.Context, query webhook.SearchSubscriptionQuery) (*pagination.Pagination[webhook.Subscription], error) {
+	offset := (query.Page - 1) * query.PerPage
+
+	var total int
+	if err := mysql.Conn(ctx, g.DB).QueryRowContext(ctx, `SELECT COUNT(*) FROM webhook_subscriptions`).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	rows, err := mysql.Conn(ctx, g.DB).QueryContext(
+		ctx,
+		`SELECT `+subscriptionColumns+` FROM webhook_subscriptions ORDER BY created_at ASC LIMIT ? OFFSET ?`,
+		query.PerPage,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions, err := scanSubscriptions(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pagination.Pagination[webhook.Subscription]{
+		CurrentPage: query.Page,
+		PerPage:     query.PerPage,
+		Total:       total,
+		Items:       subscriptions,
+	}, nil
+}
+
+// FindActiveByEventType narrows candidates in SQL and leaves the exact match
+// to Subscription.Matches, since event types are stored as a comma list.
+func (g *MySQLSubscriptionGateway) FindActiveByEventType(ctx context.Context, eventType category.EventType) ([]webhook.Subscription, error) {
+	rows, err := mysql.Conn(ctx, g.DB).QueryContext(
+		ctx,
+		`SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE activated = true AND FIND_IN_SET(?, event_types) > 0`,
+		string(eventType),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSubscriptions(rows)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubscription(row rowScanner) (*webhook.Subscription, error) {
+	var sub webhook.Subscription
+	var id, eventTypes string
+
+	err := row.Scan(
+		&id,
+		&sub.URL,
+		&eventTypes,
+		&sub.Secret,
+		&sub.IsActive,
+		&sub.CreatedAt,
+		&sub.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.ID, err = webhook.ParseSubscriptionID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.EventTypes = splitEventTypes(eventTypes)
+
+	return &sub, nil
+}
+
+func scanSubscriptions(rows *sql.Rows) ([]webhook.Subscription, error) {
+	var subscriptions []webhook.Subscription
+
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, *sub)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func joinEventTypes(eventTypes []category.EventType) string {
+	values := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		values = append(values, string(t))
+	}
+	return strings.Join(values, ",")
+}
+
+func splitEventTypes(value string) []category.EventType {
+	var eventTypes []category.EventType
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			eventTypes = append(eventTypes, category.EventType(t))
+		}
+	}
+	return eventTypes
+}
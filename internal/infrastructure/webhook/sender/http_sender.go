@@ -0,0 +1,68 @@
+// Package sender provides the HTTP transport used to deliver signed webhook payloads.
+package sender
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+const (
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderEventID    = "X-Webhook-Event-ID"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+
+	defaultTimeout = 10 * time.Second
+	maxDrainBytes  = 64 << 10
+)
+
+type HTTPSender struct {
+	Client *http.Client
+	Now    func() time.Time
+}
+
+func NewHTTPSender(client *http.Client) *HTTPSender {
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &HTTPSender{
+		Client: client,
+		Now:    time.Now,
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, subscription *webhook.Subscription, delivery *webhook.Delivery) (webhook.SendResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return webhook.SendResult{}, err
+	}
+
+	timestamp := s.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "code-flix-admin-catalog-webhooks/1.0")
+	req.Header.Set(HeaderDeliveryID, delivery.ID.String())
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, webhook.Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return webhook.SendResult{}, err
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded amount so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+
+	return webhook.SendResult{StatusCode: resp.StatusCode}, nil
+}
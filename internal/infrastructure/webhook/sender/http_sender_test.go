@@ -0,0 +1,100 @@
+package sender
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/category"
+	"github.com/renamrgb/code-flix-admin-catalog/internal/domain/webhook"
+)
+
+const secret = "0123456789abcdef"
+
+func TestHTTPSenderSend_SignsPayload(t *testing.T) {
+	var received *http.Request
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	subscription, _ := webhook.NewSubscription(server.URL, []category.EventType{category.EventCategoryCreated}, secret, true)
+	delivery := webhook.NewDelivery(subscription.ID, "event-id", category.EventCategoryCreated, []byte(`{"id":"event-id"}`))
+
+	sender := NewHTTPSender(server.Client())
+	sender.Now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	result, err := sender.Send(context.Background(), subscription, delivery)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.StatusCode != http.StatusAccepted {
+		t.Errorf("expected status 202, got %d", result.StatusCode)
+	}
+
+	if received.Method != http.MethodPost {
+		t.Errorf("expected POST, got %s", received.Method)
+	}
+
+	if string(body) != `{"id":"event-id"}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+
+	timestamp, err := strconv.ParseInt(received.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil || timestamp != 1700000000 {
+		t.Fatalf("unexpected timestamp header: %q", received.Header.Get(HeaderTimestamp))
+	}
+
+	if !webhook.VerifySignature(secret, timestamp, body, received.Header.Get(HeaderSignature)) {
+		t.Error("expected a valid HMAC-SHA256 signature")
+	}
+
+	if received.Header.Get(HeaderEvent) != "category.created" {
+		t.Errorf("unexpected event header: %q", received.Header.Get(HeaderEvent))
+	}
+
+	if received.Header.Get(HeaderDeliveryID) != delivery.ID.String() {
+		t.Errorf("unexpected delivery header: %q", received.Header.Get(HeaderDeliveryID))
+	}
+}
+
+func TestHTTPSenderSend_ReportsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	subscription, _ := webhook.NewSubscription(server.URL, []category.EventType{category.EventCategoryCreated}, secret, true)
+	delivery := webhook.NewDelivery(subscription.ID, "event-id", category.EventCategoryCreated, []byte(`{}`))
+
+	result, err := NewHTTPSender(server.Client()).Send(context.Background(), subscription, delivery)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", result.StatusCode)
+	}
+}
+
+func TestHTTPSenderSend_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	subscription, _ := webhook.NewSubscription(url, []category.EventType{category.EventCategoryCreated}, secret, true)
+	delivery := webhook.NewDelivery(subscription.ID, "event-id", category.EventCategoryCreated, []byte(`{}`))
+
+	if _, err := NewHTTPSender(nil).Send(context.Background(), subscription, delivery); err == nil {
+		t.Fatal("expected transport error")
+	}
+}
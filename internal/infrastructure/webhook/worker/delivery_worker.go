@@ -0,0 +1,51 @@
+// Package worker provides the background loop that sends due webhook deliveries.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/renamrgb/code-flix-admin-catalog/internal/application/webhook/deliver"
+)
+
+type DeliveryWorker struct {
+	UseCase   *deliver.ProcessDeliveriesUseCase
+	Interval  time.Duration
+	BatchSize int
+}
+
+func NewDeliveryWorker(useCase *deliver.ProcessDeliveriesUseCase, interval time.Duration, batchSize int) *DeliveryWorker {
+	return &DeliveryWorker{
+		UseCase:   useCase,
+		Interval:  interval,
+		BatchSize: batchSize,
+	}
+}
+
+// Run polls for due deliveries until ctx is cancelled.
+func (w *DeliveryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *DeliveryWorker) tick(ctx context.Context) {
+	output, err := w.UseCase.Execute(ctx, deliver.ProcessDeliveriesInput{Limit: w.BatchSize})
+	if err != nil {
+		slog.ErrorContext(ctx, "webhook worker: processing deliveries failed", "error", err)
+		return
+	}
+
+	if output.Succeeded+output.Failed > 0 {
+		slog.InfoContext(ctx, "webhook worker: processed deliveries", "succeeded", output.Succeeded, "failed", output.Failed)
+	}
+}
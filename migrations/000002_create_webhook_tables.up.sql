@@ -0,0 +1,30 @@
+create table webhook_subscriptions (
+    id varchar(36) not null primary key,
+    url varchar(2048) not null,
+    event_types varchar(255) not null,
+    secret varchar(255) not null,
+    activated boolean not null default true,
+    created_at datetime(6) not null,
+    updated_at datetime(6) not null
+);
+
+create table webhook_deliveries (
+    id varchar(36) not null primary key,
+    subscription_id varchar(36) not null,
+    event_id varchar(36) not null,
+    event_type varchar(64) not null,
+    payload json not null,
+    status varchar(16) not null,
+    attempts int not null default 0,
+    response_status int,
+    last_error varchar(1024),
+    next_attempt_at datetime(6),
+    last_attempt_at datetime(6),
+    created_at datetime(6) not null,
+    updated_at datetime(6) not null,
+    index idx_webhook_deliveries_subscription (subscription_id, created_at),
+    index idx_webhook_deliveries_due (status, next_attempt_at),
+    constraint fk_webhook_deliveries_subscription
+        foreign key (subscription_id) references webhook_subscriptions (id)
+        on delete cascade
+);
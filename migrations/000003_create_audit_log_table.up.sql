@@ -0,0 +1,11 @@
+create table audit_log (
+    id varchar(36) not null primary key,
+    entity_type varchar(64) not null,
+    entity_id varchar(36) not null,
+    action varchar(16) not null,
+    actor varchar(255) not null,
+    request_id varchar(128),
+    changes json not null,
+    occurred_at datetime(6) not null,
+    index idx_audit_log_entity (entity_type, entity_id, occurred_at)
+);
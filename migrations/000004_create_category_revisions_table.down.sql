@@ -0,0 +1 @@
+drop table if exists category_revisions;
@@ -0,0 +1,14 @@
+create table category_revisions (
+    category_id varchar(36) not null,
+    revision int not null,
+    event_id varchar(36) not null,
+    name varchar(255) not null,
+    description varchar(4000),
+    activated boolean not null,
+    deleted_at datetime(6),
+    actor varchar(255) not null,
+    recorded_at datetime(6) not null,
+    primary key (category_id, revision),
+    unique key uq_category_revisions_event (event_id),
+    index idx_category_revisions_recorded (category_id, recorded_at)
+);
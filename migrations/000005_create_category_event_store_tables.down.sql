@@ -0,0 +1,2 @@
+drop table if exists category_snapshots;
+drop table if exists category_events;
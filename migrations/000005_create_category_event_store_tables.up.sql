@@ -0,0 +1,15 @@
+create table category_events (
+    stream_id varchar(36) not null,
+    version int not null,
+    event_type varchar(64) not null,
+    data json not null,
+    occurred_at datetime(6) not null,
+    primary key (stream_id, version)
+);
+
+create table category_snapshots (
+    stream_id varchar(36) not null primary key,
+    version int not null,
+    state json not null,
+    taken_at datetime(6) not null
+);
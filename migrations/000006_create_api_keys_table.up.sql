@@ -0,0 +1,11 @@
+create table api_keys (
+    id varchar(36) not null primary key,
+    name varchar(255) not null,
+    salt varchar(64) not null,
+    hash varchar(64) not null,
+    permissions varchar(255) not null,
+    allowed_cidrs varchar(1024) not null default '',
+    created_at datetime(6) not null,
+    last_used_at datetime(6),
+    revoked_at datetime(6)
+);
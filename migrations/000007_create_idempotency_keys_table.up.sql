@@ -0,0 +1,10 @@
+create table idempotency_keys (
+    id varchar(64) not null primary key,
+    request_hash varchar(64) not null,
+    status_code int,
+    headers text,
+    body mediumblob,
+    created_at datetime(6) not null,
+    expires_at datetime(6) not null,
+    index idx_idempotency_keys_expires_at (expires_at)
+);
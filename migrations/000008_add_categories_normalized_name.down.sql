@@ -0,0 +1,3 @@
+alter table categories
+    drop index idx_categories_normalized_name,
+    drop column normalized_name;
@@ -0,0 +1,18 @@
+alter table categories
+    add column normalized_name varchar(300) character set utf8mb4 collate utf8mb4_bin;
+
+update categories
+set normalized_name = lower(regexp_replace(trim(name), '[[:space:]]+', ' '));
+
+-- Names were not unique before. Every clash but the oldest gets its id
+-- appended so the index can be built; those categories conflict with the
+-- oldest one until they are renamed.
+update categories c
+join categories older
+    on older.normalized_name = c.normalized_name
+    and (older.created_at < c.created_at or (older.created_at = c.created_at and older.id < c.id))
+set c.normalized_name = concat(c.normalized_name, '#', c.id);
+
+alter table categories
+    modify normalized_name varchar(300) character set utf8mb4 collate utf8mb4_bin not null,
+    add unique index idx_categories_normalized_name (normalized_name);
@@ -0,0 +1,5 @@
+drop table if exists category_slug_aliases;
+
+alter table categories
+    drop index idx_categories_slug,
+    drop column slug;
@@ -0,0 +1,35 @@
+alter table categories
+    add column slug varchar(100);
+
+-- Existing categories get the slug the application would generate for
+-- common accented letters; anything else becomes a hyphen.
+update categories
+set slug = trim(both '-' from left(regexp_replace(
+    regexp_replace(regexp_replace(regexp_replace(regexp_replace(
+    regexp_replace(regexp_replace(regexp_replace(lower(name),
+        '[áàâãäå]', 'a'), '[éèêë]', 'e'), '[íìîï]', 'i'), '[óòôõöø]', 'o'),
+        '[úùûü]', 'u'), 'ç', 'c'), 'ñ', 'n'),
+    '[^a-z0-9]+', '-'), 90));
+
+update categories
+set slug = left(id, 8)
+where slug = '';
+
+-- Every clash but the oldest gets the start of its id appended.
+update categories c
+join categories older
+    on older.slug = c.slug
+    and (older.created_at < c.created_at or (older.created_at = c.created_at and older.id < c.id))
+set c.slug = concat(c.slug, '-', left(c.id, 8));
+
+alter table categories
+    modify slug varchar(100) not null,
+    add unique index idx_categories_slug (slug);
+
+create table category_slug_aliases (
+    slug varchar(100) not null primary key,
+    category_id varchar(36) not null,
+    created_at datetime(6) not null,
+    index idx_category_slug_aliases_category_id (category_id),
+    foreign key (category_id) references categories (id) on delete cascade
+);
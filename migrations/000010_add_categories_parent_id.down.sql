@@ -0,0 +1,4 @@
+alter table categories
+    drop foreign key fk_categories_parent_id,
+    drop index idx_categories_parent_id,
+    drop column parent_id;
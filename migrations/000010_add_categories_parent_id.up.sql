@@ -0,0 +1,6 @@
+-- Deleting a category with children is refused, so the key restricts
+-- deletes rather than cascading them.
+alter table categories
+    add column parent_id varchar(36) null after slug,
+    add index idx_categories_parent_id (parent_id),
+    add constraint fk_categories_parent_id foreign key (parent_id) references categories (id);
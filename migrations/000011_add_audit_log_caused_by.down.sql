@@ -0,0 +1,2 @@
+alter table audit_log
+    drop column caused_by;
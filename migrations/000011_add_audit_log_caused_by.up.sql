@@ -0,0 +1,2 @@
+alter table audit_log
+    add column caused_by varchar(36) after request_id;
@@ -0,0 +1,3 @@
+alter table category_revisions
+    drop column parent_id,
+    drop column slug;
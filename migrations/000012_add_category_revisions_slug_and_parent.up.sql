@@ -0,0 +1,9 @@
+alter table category_revisions
+    add column slug varchar(100) after name,
+    add column parent_id varchar(36) after slug;
+
+-- Earlier revisions take the current slug and parent of their category, so
+-- reverting to one leaves both as they are.
+update category_revisions r
+join categories c on c.id = r.category_id
+set r.slug = c.slug, r.parent_id = c.parent_id;
@@ -0,0 +1,2 @@
+alter table webhook_deliveries
+    drop column attempt_offset;
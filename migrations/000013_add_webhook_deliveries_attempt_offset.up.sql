@@ -0,0 +1,2 @@
+alter table webhook_deliveries
+    add column attempt_offset int not null default 0 after attempts;
@@ -0,0 +1,14 @@
+create table audit_outbox (
+    id varchar(36) not null primary key,
+    entity_type varchar(64) not null,
+    entity_id varchar(36) not null,
+    action varchar(16) not null,
+    actor varchar(255) not null,
+    request_id varchar(128),
+    caused_by varchar(36),
+    changes json not null,
+    occurred_at datetime(6) not null,
+    attempts int not null,
+    next_attempt_at datetime(6) not null,
+    index idx_audit_outbox_next_attempt_at (next_attempt_at)
+);
@@ -0,0 +1,5 @@
+drop trigger if exists trg_category_slug_aliases_claim_slug;
+drop trigger if exists trg_categories_claim_slug_on_update;
+drop trigger if exists trg_categories_claim_slug_on_insert;
+
+drop table if exists category_slugs;
@@ -0,0 +1,64 @@
+-- Every current slug and every alias is registered here with the category
+-- that holds it, so the primary key keeps a slug with one category across
+-- categories.slug and category_slug_aliases, even under concurrent writes.
+create table category_slugs (
+    slug varchar(100) not null primary key,
+    category_id varchar(36) not null,
+    index idx_category_slugs_category_id (category_id),
+    foreign key (category_id) references categories (id) on delete cascade
+);
+
+-- A current slug wins over another category's alias, as it does on lookup.
+delete a
+from category_slug_aliases a
+join categories c on c.slug = a.slug and c.id <> a.category_id;
+
+insert into category_slugs (slug, category_id)
+select slug, id from categories;
+
+insert ignore into category_slugs (slug, category_id)
+select slug, category_id from category_slug_aliases;
+
+-- Each trigger claims the slug for its category: the upsert waits for a
+-- concurrent claim of the same slug to commit, and the locking read sees the
+-- winner. The error matches a duplicate key so callers handle it as one.
+create trigger trg_categories_claim_slug_on_insert
+after insert on categories
+for each row
+begin
+    insert into category_slugs (slug, category_id) values (new.slug, new.id)
+    on duplicate key update category_id = category_id;
+
+    if (select category_id from category_slugs where slug = new.slug for update) <> new.id then
+        signal sqlstate '23000'
+            set message_text = 'Duplicate entry for key category_slugs.PRIMARY', mysql_errno = 1062;
+    end if;
+end;
+
+create trigger trg_categories_claim_slug_on_update
+after update on categories
+for each row
+begin
+    if new.slug <> old.slug then
+        insert into category_slugs (slug, category_id) values (new.slug, new.id)
+        on duplicate key update category_id = category_id;
+
+        if (select category_id from category_slugs where slug = new.slug for update) <> new.id then
+            signal sqlstate '23000'
+                set message_text = 'Duplicate entry for key category_slugs.PRIMARY', mysql_errno = 1062;
+        end if;
+    end if;
+end;
+
+create trigger trg_category_slug_aliases_claim_slug
+after insert on category_slug_aliases
+for each row
+begin
+    insert into category_slugs (slug, category_id) values (new.slug, new.category_id)
+    on duplicate key update category_id = category_id;
+
+    if (select category_id from category_slugs where slug = new.slug for update) <> new.category_id then
+        signal sqlstate '23000'
+            set message_text = 'Duplicate entry for key category_slugs.PRIMARY', mysql_errno = 1062;
+    end if;
+end;
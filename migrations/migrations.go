@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL schema migrations into the binary, so
+// the server does not depend on its working directory.
+package migrations
+
+import "embed"
+
+// FS holds every up/down migration file, at its root.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddedMatchesDisk(t *testing.T) {
+	onDisk, err := filepath.Glob("*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	embedded, err := fs.Glob(FS, "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(embedded) != len(onDisk) {
+		t.Fatalf("expected %d embedded migrations, got %d: %v", len(onDisk), len(embedded), embedded)
+	}
+
+	for _, name := range onDisk {
+		want, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := fs.ReadFile(FS, name)
+		if err != nil {
+			t.Errorf("%s is not embedded: %v", name, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("embedded %s differs from the file on disk", name)
+		}
+	}
+}